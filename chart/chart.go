@@ -0,0 +1,217 @@
+// Package chart draws simple bar and line charts as PNG images using the
+// standard library only, so reports can be sent as photos without any chart
+// service.
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+)
+
+type Kind int
+
+const (
+	KindBar Kind = iota
+	KindLine
+)
+
+// Chart is a single series of values, one for each label along the x axis.
+// Unit is appended to the values written on the chart, e.g. "%".
+type Chart struct {
+	Kind   Kind
+	Title  string
+	Labels []string
+	Values []float64
+	Unit   string
+}
+
+const (
+	width  = 800
+	height = 450
+
+	padding    = 24
+	titleScale = 3
+	labelScale = 2
+	gridLines  = 4
+	tickLength = 6
+	pointSize  = 8
+	lineWidth  = 3
+)
+
+var (
+	backgroundColor = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	textColor       = color.RGBA{0x1f, 0x29, 0x37, 0xff}
+	axisColor       = color.RGBA{0x6b, 0x72, 0x80, 0xff}
+	gridColor       = color.RGBA{0xe5, 0xe7, 0xeb, 0xff}
+	seriesColor     = color.RGBA{0x25, 0x63, 0xeb, 0xff}
+)
+
+// PNG encodes the rendered chart.
+func (c Chart) PNG() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Render()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render draws the title, the y axis scaled to the largest value with its grid
+// and the series over the labels.
+func (c Chart) Render() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{backgroundColor}, image.Point{}, draw.Src)
+
+	drawText(img, padding, padding, fitText(c.Title, width-2*padding, titleScale), titleScale, textColor)
+
+	max := niceMax(c.maxValue())
+	tickWidth := textWidth(formatValue(max, ""), labelScale)
+
+	plot := image.Rect(
+		padding+tickWidth+tickLength+4,
+		padding+glyphHeight*titleScale+24,
+		width-padding,
+		height-padding-glyphHeight*labelScale-tickLength-4,
+	)
+
+	for i := 0; i <= gridLines; i++ {
+		value := max * float64(i) / gridLines
+		y := plot.Max.Y - int(float64(plot.Dy())*float64(i)/gridLines)
+
+		if i > 0 {
+			fillRect(img, plot.Min.X, y, plot.Dx(), 1, gridColor)
+		}
+
+		label := formatValue(value, "")
+		drawText(img, plot.Min.X-tickLength-4-textWidth(label, labelScale), y-glyphHeight*labelScale/2, label, labelScale, axisColor)
+	}
+
+	fillRect(img, plot.Min.X, plot.Min.Y, 1, plot.Dy()+1, axisColor)
+	fillRect(img, plot.Min.X, plot.Max.Y, plot.Dx(), 1, axisColor)
+
+	if len(c.Values) == 0 {
+		return img
+	}
+
+	slot := plot.Dx() / len(c.Values)
+	yOf := func(value float64) int {
+		return plot.Max.Y - int(math.Round(float64(plot.Dy())*value/max))
+	}
+
+	var previous image.Point
+	for i, value := range c.Values {
+		center := plot.Min.X + slot*i + slot/2
+		y := yOf(value)
+
+		switch c.Kind {
+		case KindLine:
+			point := image.Pt(center, y)
+			if i > 0 {
+				drawLine(img, previous, point, seriesColor)
+			}
+			fillRect(img, center-pointSize/2, y-pointSize/2, pointSize, pointSize, seriesColor)
+			previous = point
+		default:
+			barWidth := slot * 3 / 5
+			if barWidth < 1 {
+				barWidth = 1
+			}
+			fillRect(img, center-barWidth/2, y, barWidth, plot.Max.Y-y, seriesColor)
+		}
+
+		valueLabel := formatValue(value, c.Unit)
+		if textWidth(valueLabel, labelScale) <= slot {
+			top := y - glyphHeight*labelScale - 6
+			if c.Kind == KindLine {
+				top -= pointSize / 2
+			}
+			drawText(img, center-textWidth(valueLabel, labelScale)/2, top, valueLabel, labelScale, textColor)
+		}
+
+		if i < len(c.Labels) {
+			label := fitText(c.Labels[i], slot-4, labelScale)
+			fillRect(img, center, plot.Max.Y, 1, tickLength, axisColor)
+			drawText(img, center-textWidth(label, labelScale)/2, plot.Max.Y+tickLength+4, label, labelScale, textColor)
+		}
+	}
+
+	return img
+}
+
+func (c Chart) maxValue() float64 {
+	max := 0.0
+	for _, value := range c.Values {
+		if value > max {
+			max = value
+		}
+	}
+	return max
+}
+
+// niceMax rounds the largest value up to 1, 2 or 5 times a power of ten so the
+// grid lines fall on readable values.
+func niceMax(max float64) float64 {
+	if max <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(max)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if max <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// formatValue writes the value with at most one decimal.
+func formatValue(value float64, unit string) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64) + unit
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.Color) {
+	draw.Draw(img, image.Rect(x, y, x+w, y+h), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawLine draws a thick line between the points with Bresenham's algorithm.
+func drawLine(img *image.RGBA, from, to image.Point, c color.Color) {
+	dx := abs(to.X - from.X)
+	dy := -abs(to.Y - from.Y)
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+
+	err := dx + dy
+	x, y := from.X, from.Y
+	for {
+		fillRect(img, x-lineWidth/2, y-lineWidth/2, lineWidth, lineWidth, c)
+		if x == to.X && y == to.Y {
+			return
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
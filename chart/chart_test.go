@@ -0,0 +1,61 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPNG(t *testing.T) {
+	for _, kind := range []Kind{KindBar, KindLine} {
+		c := Chart{
+			Kind:   kind,
+			Title:  "Peminjaman per bulan",
+			Labels: []string{"Jan 21", "Feb 21", "Mar 21"},
+			Values: []float64{3, 8, 5},
+		}
+
+		r, err := c.PNG()
+		assert.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(r))
+		assert.NoError(t, err)
+		assert.Equal(t, width, img.Bounds().Dx())
+		assert.Equal(t, height, img.Bounds().Dy())
+	}
+}
+
+func TestRenderDrawsSeries(t *testing.T) {
+	c := Chart{Kind: KindBar, Labels: []string{"A"}, Values: []float64{10}}
+	img := c.Render()
+
+	// the only bar fills the middle of the plot up to the top grid line
+	assert.Equal(t, seriesColor, img.RGBAAt(width/2+tickLength, height/2))
+}
+
+func TestRenderWithoutValues(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Chart{Title: "Kosong"}.Render()
+	})
+}
+
+func TestNiceMax(t *testing.T) {
+	assert.Equal(t, 1.0, niceMax(0))
+	assert.Equal(t, 1.0, niceMax(0.8))
+	assert.Equal(t, 10.0, niceMax(8))
+	assert.Equal(t, 20.0, niceMax(13))
+	assert.Equal(t, 50.0, niceMax(42))
+	assert.Equal(t, 100.0, niceMax(100))
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "ABC", fitText("ABC", 100, 1))
+	assert.Equal(t, "OSI.", fitText("OSILOSKOP", textWidth("OSI.", 1), 1))
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "3", formatValue(3, ""))
+	assert.Equal(t, "12.5%", formatValue(12.46, "%"))
+}
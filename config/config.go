@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 )
 
 const port = "3000"
@@ -14,3 +15,19 @@ func GetPort() string {
 
 	return p
 }
+
+// defaultNIMPattern matches the 14 digit NIM of Universitas Diponegoro, e.g.
+// 21120117130000 is faculty 21, program 1201 and the 2017 batch.
+const defaultNIMPattern = `^(?P<faculty>\d{2})(?P<program>\d{4})(?P<year>\d{2})\d{6}$`
+
+// NIMPatterns returns the regular expressions a NIM must match, separated by
+// spaces in NIM_PATTERNS. The "faculty", "program" and "year" groups are
+// optional, the year is checked against the batch of the student.
+func NIMPatterns() []string {
+	patterns := strings.Fields(os.Getenv("NIM_PATTERNS"))
+	if len(patterns) == 0 {
+		patterns = []string{defaultNIMPattern}
+	}
+
+	return patterns
+}
@@ -22,3 +22,18 @@ func TestGetPort(t *testing.T) {
 		assert.Equal(t, "1234", p)
 	})
 }
+
+func TestNIMPatterns(t *testing.T) {
+	t.Run("use default pattern", func(t *testing.T) {
+		os.Unsetenv("NIM_PATTERNS")
+
+		assert.Equal(t, []string{defaultNIMPattern}, NIMPatterns())
+	})
+
+	t.Run("can get patterns using env", func(t *testing.T) {
+		os.Setenv("NIM_PATTERNS", `^\d{14}$  ^(?P<year>\d{4})\d{5}$`)
+		defer os.Unsetenv("NIM_PATTERNS")
+
+		assert.Equal(t, []string{`^\d{14}$`, `^(?P<year>\d{4})\d{5}$`}, NIMPatterns())
+	})
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 )
 
@@ -8,3 +9,9 @@ func WebhookUrl() string {
 	var url string = "https://api.telegram.org/" + os.Getenv("BOT_TOKEN")
 	return url
 }
+
+// BotDeepLink links to a private chat with the bot that starts with the payload,
+// see https://core.telegram.org/bots/features#deep-linking
+func BotDeepLink(payload string) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s", os.Getenv("BOT_USERNAME"), payload)
+}
@@ -0,0 +1,134 @@
+package conversation
+
+import (
+	"errors"
+
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+var (
+	// ErrUnknownState is returned when the latest chat session detail has a topic
+	// that is not registered in any flow.
+	ErrUnknownState = errors.New("conversation: unknown state")
+
+	// ErrNotAllowed is returned when the guard of a flow refuses the sender.
+	ErrNotAllowed = errors.New("conversation: not allowed")
+)
+
+type (
+	// Input is a single answer sent by the user while a conversation is in progress.
+	Input struct {
+		Text    string
+		Message types.TeleMessage
+	}
+
+	// Transition moves the conversation into the state with the given topic and
+	// records Data as the chat session detail of that state.
+	Transition struct {
+		Topic types.TopicType
+		Data  string
+
+		// Silent records the detail without entering the state again, e.g. for the
+		// remaining photos of an album.
+		Silent bool
+	}
+
+	// State is one step of a flow. A conversation is in the state whose topic is
+	// the topic of the latest chat session detail.
+	State struct {
+		Topic types.TopicType
+
+		// Enter runs when the conversation reaches this state. It asks the next
+		// question, or finishes the flow when the state is final.
+		Enter func(c *Context) error
+
+		// Accept validates the answer received while in this state and returns the
+		// transition to the next state. Returning an InvalidInput error keeps the
+		// conversation in this state.
+		Accept func(c *Context) (Transition, error)
+
+		// Final states complete the chat session before Enter runs.
+		Final bool
+
+		// Label is the catalog key naming the answer recorded when entering this
+		// state. Labelled answers can be changed later through the "Ubah jawaban"
+		// button, which asks the question of the previous state again.
+		Label string
+	}
+
+	// Flow is a named group of states, e.g. borrowing a tool.
+	Flow struct {
+		Name   string
+		States []State
+
+		// Guard, when set, must allow the sender before any state of the flow runs.
+		Guard func(c *Context) bool
+	}
+
+	// InvalidInput is an answer that cannot be accepted. Its message is sent back
+	// to the user and the conversation stays in the same state.
+	InvalidInput struct {
+		Message string
+	}
+)
+
+func (e InvalidInput) Error() string {
+	return e.Message
+}
+
+// Invalid builds an InvalidInput error with the message shown to the user.
+func Invalid(message string) error {
+	return InvalidInput{Message: message}
+}
+
+// Context carries a running conversation through the state callbacks.
+type Context struct {
+	UserID      int64
+	RequestType types.RequestType
+	Session     types.ChatSession
+	Input       Input
+
+	// Printer renders the messages of the conversation in the user's language.
+	Printer i18n.Printer
+
+	// Details are the recorded chat session details, the latest first.
+	Details []types.ChatSessionDetail
+
+	replies []types.MessageRequest
+}
+
+// Reply queues a message to be sent after the current state has been handled.
+func (c *Context) Reply(req types.MessageRequest) {
+	c.replies = append(c.replies, req)
+}
+
+// Replies returns the queued messages in order.
+func (c *Context) Replies() []types.MessageRequest {
+	return c.replies
+}
+
+// attachButtons adds a row of buttons below the last queued message. A message
+// showing a reply keyboard cannot have buttons as well, so it is left as is.
+func (c *Context) attachButtons(buttons []types.InlineKeyboardButton) {
+	if len(buttons) == 0 || len(c.replies) == 0 {
+		return
+	}
+
+	last := &c.replies[len(c.replies)-1]
+	markup, ok := last.ReplyMarkup.(types.InlineKeyboardMarkup)
+	if !ok && last.ReplyMarkup != nil {
+		return
+	}
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, buttons)
+	last.ReplyMarkup = markup
+}
+
+// Topic returns the topic of the current state.
+func (c *Context) Topic() types.TopicType {
+	if len(c.Details) == 0 {
+		return ""
+	}
+	return c.Details[0].Topic
+}
@@ -0,0 +1,157 @@
+package conversation
+
+import (
+	"errors"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type registeredState struct {
+	flow  *Flow
+	state State
+	index int
+}
+
+// Machine runs the registered flows and persists their progress through the
+// chat session repository.
+type Machine struct {
+	repository repository.ChatSessionRepository
+	states     map[types.TopicType]registeredState
+}
+
+func NewMachine(chatSessionRepository repository.ChatSessionRepository, flows ...Flow) *Machine {
+	m := &Machine{
+		repository: chatSessionRepository,
+		states:     make(map[types.TopicType]registeredState),
+	}
+
+	for i := range flows {
+		flow := &flows[i]
+		for j, state := range flow.States {
+			m.states[state.Topic] = registeredState{flow: flow, state: state, index: j}
+		}
+	}
+
+	return m
+}
+
+// Lookup returns the state registered for the topic.
+func (m *Machine) Lookup(topic types.TopicType) (State, bool) {
+	rs, ok := m.states[topic]
+	return rs.state, ok
+}
+
+// Start moves the conversation into the first state of a flow, creating the chat
+// session when the user doesn't have one yet.
+func (m *Machine) Start(c *Context, t Transition) error {
+	if c.Session.ID == 0 {
+		session, err := m.repository.Save(&types.ChatSession{
+			Status: types.ChatSessionStatus["progress"],
+			UserID: c.UserID,
+		}, c.RequestType)
+		if err != nil {
+			return err
+		}
+		c.Session = session
+	}
+
+	return m.transit(c, t)
+}
+
+// Handle passes the input to the current state of the conversation.
+func (m *Machine) Handle(c *Context) error {
+	if c.Topic() == types.Topic["edit_answer"] {
+		return m.handleEdit(c)
+	}
+
+	rs, ok := m.states[c.Topic()]
+	if !ok || rs.state.Accept == nil {
+		return ErrUnknownState
+	}
+
+	if rs.flow.Guard != nil && !rs.flow.Guard(c) {
+		return ErrNotAllowed
+	}
+
+	if handled, err := m.navigate(c, rs); handled {
+		return err
+	}
+
+	t, accepted, err := accept(c, rs.state)
+	if !accepted {
+		return err
+	}
+
+	return m.transit(c, t)
+}
+
+// accept runs the Accept of the state. An invalid input is answered right away
+// and reported as not accepted without an error.
+func accept(c *Context, state State) (Transition, bool, error) {
+	t, err := state.Accept(c)
+	if err != nil {
+		var invalid InvalidInput
+		if errors.As(err, &invalid) {
+			c.Reply(types.MessageRequest{Text: invalid.Message})
+			return t, false, nil
+		}
+		return t, false, err
+	}
+
+	return t, true, nil
+}
+
+func (m *Machine) transit(c *Context, t Transition) error {
+	rs, ok := m.states[t.Topic]
+	if !ok {
+		return ErrUnknownState
+	}
+
+	if rs.flow.Guard != nil && !rs.flow.Guard(c) {
+		return ErrNotAllowed
+	}
+
+	data := t.Data
+	if len(data) == 0 {
+		data = "{}"
+	}
+
+	detail, err := m.repository.SaveDetail(&types.ChatSessionDetail{
+		Topic:         t.Topic,
+		ChatSessionID: c.Session.ID,
+		Data:          data,
+	})
+	if err != nil {
+		return err
+	}
+	c.Details = append([]types.ChatSessionDetail{detail}, c.Details...)
+
+	if t.Silent {
+		return nil
+	}
+
+	if rs.state.Final {
+		if err := m.repository.UpdateStatus(c.Session.ID, types.ChatSessionStatus["complete"]); err != nil {
+			return err
+		}
+		c.Session.Status = types.ChatSessionStatus["complete"]
+	}
+
+	return m.enter(c, rs)
+}
+
+func (m *Machine) enter(c *Context, rs registeredState) error {
+	if rs.state.Enter == nil {
+		return nil
+	}
+
+	if err := rs.state.Enter(c); err != nil {
+		return err
+	}
+
+	if !rs.state.Final {
+		m.attachNavigation(c, rs)
+	}
+	return nil
+}
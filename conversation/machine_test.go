@@ -0,0 +1,221 @@
+package conversation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChatSessionRepository struct {
+	details  []types.ChatSessionDetail
+	statuses map[int64]types.ChatSessionStatusType
+}
+
+func (r *fakeChatSessionRepository) Save(chatSession *types.ChatSession, requestType types.RequestType) (types.ChatSession, error) {
+	chatSession.ID = 1
+	return *chatSession, nil
+}
+
+func (r *fakeChatSessionRepository) UpdateStatus(id int64, status types.ChatSessionStatusType) error {
+	if r.statuses == nil {
+		r.statuses = make(map[int64]types.ChatSessionStatusType)
+	}
+	r.statuses[id] = status
+	return nil
+}
+
+func (r *fakeChatSessionRepository) Delete(id int64) error {
+	return nil
+}
+
+func (r *fakeChatSessionRepository) SaveDetail(chatSessionDetail *types.ChatSessionDetail) (types.ChatSessionDetail, error) {
+	chatSessionDetail.ID = int64(len(r.details) + 1)
+	r.details = append(r.details, *chatSessionDetail)
+	return *chatSessionDetail, nil
+}
+
+func (r *fakeChatSessionRepository) DeleteDetailByChatSessionID(id int64) error {
+	r.details = nil
+	return nil
+}
+
+func (r *fakeChatSessionRepository) DeleteDetailAfter(chatSessionID, id int64) error {
+	var details []types.ChatSessionDetail
+	for _, detail := range r.details {
+		if detail.ID <= id {
+			details = append(details, detail)
+		}
+	}
+	r.details = details
+	return nil
+}
+
+func (r *fakeChatSessionRepository) UpdateDetailData(id int64, data string) error {
+	for i := range r.details {
+		if r.details[i].ID == id {
+			r.details[i].Data = data
+		}
+	}
+	return nil
+}
+
+// latestFirst returns the recorded details the way the chat session query does.
+func (r *fakeChatSessionRepository) latestFirst() []types.ChatSessionDetail {
+	details := make([]types.ChatSessionDetail, len(r.details))
+	for i, detail := range r.details {
+		details[len(r.details)-1-i] = detail
+	}
+	return details
+}
+
+func testFlow(entered *[]types.TopicType) Flow {
+	enter := func(c *Context) error {
+		*entered = append(*entered, c.Topic())
+		return nil
+	}
+
+	return Flow{
+		Name: "test",
+		States: []State{
+			{
+				Topic: "test_init",
+				Enter: enter,
+				Accept: func(c *Context) (Transition, error) {
+					if c.Input.Text != "ok" {
+						return Transition{}, Invalid("jawaban tidak valid")
+					}
+					return Transition{Topic: "test_done", Data: `{"answer":"ok"}`}, nil
+				},
+			},
+			{
+				Topic: "test_done",
+				Enter: enter,
+				Final: true,
+			},
+		},
+	}
+}
+
+func TestMachineStart(t *testing.T) {
+	var entered []types.TopicType
+	repo := &fakeChatSessionRepository{}
+	m := NewMachine(repo, testFlow(&entered))
+
+	c := &Context{UserID: 10}
+	err := m.Start(c, Transition{Topic: "test_init"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), c.Session.ID)
+	assert.Equal(t, types.TopicType("test_init"), c.Topic())
+	assert.Equal(t, "{}", repo.details[0].Data)
+	assert.Equal(t, []types.TopicType{"test_init"}, entered)
+}
+
+func TestMachineHandle(t *testing.T) {
+	t.Run("valid input moves to the next state", func(t *testing.T) {
+		var entered []types.TopicType
+		repo := &fakeChatSessionRepository{}
+		m := NewMachine(repo, testFlow(&entered))
+
+		c := &Context{
+			Session: types.ChatSession{ID: 1},
+			Details: []types.ChatSessionDetail{{Topic: "test_init"}},
+			Input:   Input{Text: "ok"},
+		}
+		err := m.Handle(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, types.TopicType("test_done"), c.Topic())
+		assert.Len(t, c.Details, 2)
+		assert.Equal(t, `{"answer":"ok"}`, repo.details[0].Data)
+		assert.Equal(t, types.ChatSessionStatus["complete"], repo.statuses[1])
+		assert.Equal(t, []types.TopicType{"test_done"}, entered)
+	})
+
+	t.Run("invalid input stays in the same state", func(t *testing.T) {
+		var entered []types.TopicType
+		repo := &fakeChatSessionRepository{}
+		m := NewMachine(repo, testFlow(&entered))
+
+		c := &Context{
+			Session: types.ChatSession{ID: 1},
+			Details: []types.ChatSessionDetail{{Topic: "test_init"}},
+			Input:   Input{Text: "not ok"},
+		}
+		err := m.Handle(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, types.TopicType("test_init"), c.Topic())
+		assert.Empty(t, repo.details)
+		assert.Equal(t, []types.MessageRequest{{Text: "jawaban tidak valid"}}, c.Replies())
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		m := NewMachine(&fakeChatSessionRepository{})
+
+		c := &Context{Details: []types.ChatSessionDetail{{Topic: "unknown"}}}
+
+		assert.Equal(t, ErrUnknownState, m.Handle(c))
+	})
+
+	t.Run("final state does not accept input", func(t *testing.T) {
+		var entered []types.TopicType
+		m := NewMachine(&fakeChatSessionRepository{}, testFlow(&entered))
+
+		c := &Context{Details: []types.ChatSessionDetail{{Topic: "test_done"}}}
+
+		assert.Equal(t, ErrUnknownState, m.Handle(c))
+	})
+
+	t.Run("guard refuses the sender", func(t *testing.T) {
+		var entered []types.TopicType
+		flow := testFlow(&entered)
+		flow.Guard = func(c *Context) bool { return false }
+		m := NewMachine(&fakeChatSessionRepository{}, flow)
+
+		c := &Context{
+			Details: []types.ChatSessionDetail{{Topic: "test_init"}},
+			Input:   Input{Text: "ok"},
+		}
+
+		assert.Equal(t, ErrNotAllowed, m.Handle(c))
+		assert.Empty(t, entered)
+	})
+
+	t.Run("error from accept", func(t *testing.T) {
+		expected := errors.New("failed")
+		m := NewMachine(&fakeChatSessionRepository{}, Flow{
+			States: []State{
+				{
+					Topic: "test_init",
+					Accept: func(c *Context) (Transition, error) {
+						return Transition{}, expected
+					},
+				},
+			},
+		})
+
+		c := &Context{Details: []types.ChatSessionDetail{{Topic: "test_init"}}}
+
+		assert.Equal(t, expected, m.Handle(c))
+	})
+}
+
+func TestMachineSilentTransition(t *testing.T) {
+	var entered []types.TopicType
+	repo := &fakeChatSessionRepository{}
+	flow := testFlow(&entered)
+	flow.States[0].Accept = func(c *Context) (Transition, error) {
+		return Transition{Topic: "test_init", Silent: true}, nil
+	}
+	m := NewMachine(repo, flow)
+
+	c := &Context{Details: []types.ChatSessionDetail{{Topic: "test_init"}}}
+	err := m.Handle(c)
+
+	assert.NoError(t, err)
+	assert.Len(t, repo.details, 1)
+	assert.Empty(t, entered)
+}
@@ -0,0 +1,243 @@
+package conversation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// The navigation inputs start with a slash like a command, so that no typed
+// answer, such as a tool named "back", is taken for a button.
+const (
+	// InputBack is sent by the "Kembali" button. It asks the previous question
+	// again, or cancels changing an answer.
+	InputBack = "/nav back"
+
+	// InputEdit is sent by the "Ubah jawaban" button. Once the user picks an
+	// answer it is followed by the topic of that answer, e.g.
+	// "/nav edit BRW_amount".
+	InputEdit = "/nav edit"
+)
+
+func (m *Machine) navigate(c *Context, rs registeredState) (bool, error) {
+	switch {
+	case c.Input.Text == InputBack:
+		return true, m.back(c, rs)
+	case c.Input.Text == InputEdit:
+		m.chooseAnswer(c, rs)
+		return true, nil
+	case strings.HasPrefix(c.Input.Text, InputEdit+" "):
+		topic := types.TopicType(strings.TrimPrefix(c.Input.Text, InputEdit+" "))
+		return true, m.startEdit(c, rs, topic)
+	}
+
+	return false, nil
+}
+
+// back removes the answers recorded since the previous state and enters it again.
+func (m *Machine) back(c *Context, rs registeredState) error {
+	if rs.index == 0 {
+		c.Reply(types.MessageRequest{Text: c.Printer.Text("conversation.no_previous_question")})
+		return nil
+	}
+
+	previous := m.states[rs.flow.States[rs.index-1].Topic]
+	i, found := latestDetail(c.Details, previous.state.Topic)
+	if !found {
+		c.Reply(types.MessageRequest{Text: c.Printer.Text("conversation.no_previous_question")})
+		return nil
+	}
+
+	if err := m.repository.DeleteDetailAfter(c.Session.ID, c.Details[i].ID); err != nil {
+		return err
+	}
+	c.Details = c.Details[i:]
+
+	return m.enter(c, previous)
+}
+
+// editableAnswers returns the labelled states of the flow whose answers have
+// already been recorded.
+func (m *Machine) editableAnswers(c *Context, flow *Flow) []registeredState {
+	var states []registeredState
+	for i, state := range flow.States {
+		if i == 0 || len(state.Label) == 0 || flow.States[i-1].Accept == nil {
+			continue
+		}
+
+		if _, found := latestDetail(c.Details, state.Topic); found {
+			states = append(states, m.states[state.Topic])
+		}
+	}
+
+	return states
+}
+
+func (m *Machine) chooseAnswer(c *Context, rs registeredState) {
+	answers := m.editableAnswers(c, rs.flow)
+	if len(answers) == 0 {
+		c.Reply(types.MessageRequest{Text: c.Printer.Text("conversation.no_editable_answer")})
+		return
+	}
+
+	var keyboard [][]types.InlineKeyboardButton
+	for _, answer := range answers {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         c.Printer.Text(answer.state.Label),
+				CallbackData: fmt.Sprintf("%s %s", InputEdit, answer.state.Topic),
+			},
+		})
+	}
+
+	c.Reply(types.MessageRequest{
+		Text: c.Printer.Text("conversation.choose_answer"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+}
+
+// startEdit records which answer is being changed and asks its question again.
+func (m *Machine) startEdit(c *Context, rs registeredState, topic types.TopicType) error {
+	var answer registeredState
+	var found bool
+	for _, a := range m.editableAnswers(c, rs.flow) {
+		if a.state.Topic == topic {
+			answer, found = a, true
+		}
+	}
+
+	if !found {
+		c.Reply(types.MessageRequest{Text: c.Printer.Text("conversation.answer_not_editable")})
+		return nil
+	}
+
+	data := gabs.New()
+	data.Set(types.Topic["edit_answer"], "type")
+	data.Set(topic, "topic")
+
+	detail, err := m.repository.SaveDetail(&types.ChatSessionDetail{
+		Topic:         types.Topic["edit_answer"],
+		ChatSessionID: c.Session.ID,
+		Data:          data.String(),
+	})
+	if err != nil {
+		return err
+	}
+	c.Details = append([]types.ChatSessionDetail{detail}, c.Details...)
+
+	question := answer.flow.States[answer.index-1]
+	if question.Enter == nil {
+		return nil
+	}
+
+	if err := question.Enter(c); err != nil {
+		return err
+	}
+
+	c.attachButtons([]types.InlineKeyboardButton{
+		{
+			Text:         c.Printer.Text("button.back"),
+			CallbackData: InputBack,
+		},
+	})
+	return nil
+}
+
+// handleEdit accepts the new answer while an answer is being changed. The
+// recorded answer is replaced and the conversation returns to the state it was
+// in before.
+func (m *Machine) handleEdit(c *Context) error {
+	if len(c.Details) < 2 {
+		return ErrUnknownState
+	}
+
+	current, ok := m.states[c.Details[1].Topic]
+	if !ok {
+		return ErrUnknownState
+	}
+
+	if current.flow.Guard != nil && !current.flow.Guard(c) {
+		return ErrNotAllowed
+	}
+
+	if c.Input.Text == InputBack {
+		return m.finishEdit(c, current)
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(c.Details[0].Data))
+	if err != nil {
+		return err
+	}
+	topic, _ := dataParsed.Path("topic").Data().(string)
+
+	answer, ok := m.states[types.TopicType(topic)]
+	if !ok || answer.flow != current.flow || answer.index == 0 {
+		return ErrUnknownState
+	}
+
+	t, accepted, err := accept(c, answer.flow.States[answer.index-1])
+	if !accepted {
+		return err
+	}
+
+	if t.Topic != answer.state.Topic {
+		return fmt.Errorf("conversation: expected answer %s, got %s", answer.state.Topic, t.Topic)
+	}
+
+	data := t.Data
+	if len(data) == 0 {
+		data = "{}"
+	}
+
+	i, _ := latestDetail(c.Details, answer.state.Topic)
+	if err := m.repository.UpdateDetailData(c.Details[i].ID, data); err != nil {
+		return err
+	}
+	c.Details[i].Data = data
+
+	return m.finishEdit(c, current)
+}
+
+func (m *Machine) finishEdit(c *Context, current registeredState) error {
+	if err := m.repository.DeleteDetailAfter(c.Session.ID, c.Details[1].ID); err != nil {
+		return err
+	}
+	c.Details = c.Details[1:]
+
+	return m.enter(c, current)
+}
+
+// attachNavigation adds the "Kembali" and "Ubah jawaban" buttons to the question
+// asked by the state.
+func (m *Machine) attachNavigation(c *Context, rs registeredState) {
+	var buttons []types.InlineKeyboardButton
+
+	if rs.index > 0 {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         c.Printer.Text("button.back"),
+			CallbackData: InputBack,
+		})
+	}
+
+	if len(m.editableAnswers(c, rs.flow)) > 0 {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         c.Printer.Text("button.edit_answer"),
+			CallbackData: InputEdit,
+		})
+	}
+
+	c.attachButtons(buttons)
+}
+
+func latestDetail(details []types.ChatSessionDetail, topic types.TopicType) (int, bool) {
+	for i, detail := range details {
+		if detail.Topic == topic {
+			return i, true
+		}
+	}
+	return 0, false
+}
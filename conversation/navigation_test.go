@@ -0,0 +1,164 @@
+package conversation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func navigationFlow(asked *[]string) Flow {
+	ask := func(question string) func(c *Context) error {
+		return func(c *Context) error {
+			*asked = append(*asked, question)
+			c.Reply(types.MessageRequest{Text: question})
+			return nil
+		}
+	}
+
+	answer := func(topic types.TopicType) func(c *Context) (Transition, error) {
+		return func(c *Context) (Transition, error) {
+			return Transition{Topic: topic, Data: fmt.Sprintf(`{"answer":"%s"}`, c.Input.Text)}, nil
+		}
+	}
+
+	return Flow{
+		Name: "navigation",
+		States: []State{
+			{Topic: "nav_init", Enter: ask("name"), Accept: answer("nav_name")},
+			{Topic: "nav_name", Label: "label.name", Enter: ask("amount"), Accept: answer("nav_amount")},
+			{Topic: "nav_amount", Label: "label.amount", Enter: ask("summary"), Accept: answer("nav_done")},
+			{Topic: "nav_done", Final: true},
+		},
+	}
+}
+
+// answer sends the input to the machine the way a new webhook request does.
+func answer(m *Machine, repo *fakeChatSessionRepository, text string) (*Context, error) {
+	c := &Context{
+		Session: types.ChatSession{ID: 1},
+		Details: repo.latestFirst(),
+		Input:   Input{Text: text},
+	}
+	return c, m.Handle(c)
+}
+
+func startNavigation(t *testing.T) (*Machine, *fakeChatSessionRepository, *[]string) {
+	var asked []string
+	repo := &fakeChatSessionRepository{}
+	m := NewMachine(repo, navigationFlow(&asked))
+
+	err := m.Start(&Context{}, Transition{Topic: "nav_init"})
+	assert.NoError(t, err)
+
+	return m, repo, &asked
+}
+
+func TestNavigationButtons(t *testing.T) {
+	var asked []string
+	m := NewMachine(&fakeChatSessionRepository{}, navigationFlow(&asked))
+
+	c := &Context{}
+	assert.NoError(t, m.Start(c, Transition{Topic: "nav_init"}))
+	assert.Nil(t, c.Replies()[0].ReplyMarkup)
+
+	c.Input.Text = "pipet"
+	c.replies = nil
+	assert.NoError(t, m.Handle(c))
+	assert.Equal(t, [][]types.InlineKeyboardButton{
+		{
+			{Text: "Kembali", CallbackData: InputBack},
+			{Text: "Ubah jawaban", CallbackData: InputEdit},
+		},
+	}, c.Replies()[0].ReplyMarkup.(types.InlineKeyboardMarkup).InlineKeyboard)
+}
+
+func TestNavigationBack(t *testing.T) {
+	m, repo, asked := startNavigation(t)
+
+	_, err := answer(m, repo, "pipet")
+	assert.NoError(t, err)
+	_, err = answer(m, repo, "3")
+	assert.NoError(t, err)
+
+	c, err := answer(m, repo, InputBack)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TopicType("nav_name"), c.Topic())
+	assert.Len(t, repo.details, 2)
+	assert.Equal(t, []string{"name", "amount", "summary", "amount"}, *asked)
+
+	t.Run("no previous question", func(t *testing.T) {
+		m, repo, _ := startNavigation(t)
+
+		c, err := answer(m, repo, InputBack)
+		assert.NoError(t, err)
+		assert.Equal(t, "Tidak ada pertanyaan sebelumnya.", c.Replies()[0].Text)
+		assert.Len(t, repo.details, 1)
+	})
+}
+
+func TestNavigationEditAnswer(t *testing.T) {
+	m, repo, asked := startNavigation(t)
+
+	_, err := answer(m, repo, "pipet")
+	assert.NoError(t, err)
+	_, err = answer(m, repo, "3")
+	assert.NoError(t, err)
+
+	c, err := answer(m, repo, InputEdit)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]types.InlineKeyboardButton{
+		{{Text: "Nama", CallbackData: InputEdit + " nav_name"}},
+		{{Text: "Jumlah", CallbackData: InputEdit + " nav_amount"}},
+	}, c.Replies()[0].ReplyMarkup.(types.InlineKeyboardMarkup).InlineKeyboard)
+
+	c, err = answer(m, repo, InputEdit+" nav_name")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Topic["edit_answer"], c.Topic())
+	assert.Equal(t, "name", (*asked)[len(*asked)-1])
+
+	c, err = answer(m, repo, "gelas ukur")
+	assert.NoError(t, err)
+	assert.Equal(t, types.TopicType("nav_amount"), c.Topic())
+	assert.Len(t, repo.details, 3)
+	assert.Equal(t, `{"answer":"gelas ukur"}`, repo.details[1].Data)
+	assert.Equal(t, `{"answer":"3"}`, repo.details[2].Data)
+	assert.Equal(t, "summary", (*asked)[len(*asked)-1])
+
+	t.Run("cancel", func(t *testing.T) {
+		m, repo, asked := startNavigation(t)
+
+		_, err := answer(m, repo, "pipet")
+		assert.NoError(t, err)
+		_, err = answer(m, repo, InputEdit+" nav_name")
+		assert.NoError(t, err)
+
+		c, err := answer(m, repo, InputBack)
+		assert.NoError(t, err)
+		assert.Equal(t, types.TopicType("nav_name"), c.Topic())
+		assert.Len(t, repo.details, 2)
+		assert.Equal(t, `{"answer":"pipet"}`, repo.details[1].Data)
+		assert.Equal(t, []string{"name", "amount", "name", "amount"}, *asked)
+	})
+
+	t.Run("answer not recorded yet", func(t *testing.T) {
+		m, repo, _ := startNavigation(t)
+
+		c, err := answer(m, repo, InputEdit+" nav_amount")
+		assert.NoError(t, err)
+		assert.Equal(t, "Jawaban tersebut tidak dapat diubah.", c.Replies()[0].Text)
+		assert.Len(t, repo.details, 1)
+	})
+}
+
+func TestTypedNavigationWordsAreAnswers(t *testing.T) {
+	for _, text := range []string{"back", "edit", "edit nav_name"} {
+		m, repo, asked := startNavigation(t)
+
+		c, err := answer(m, repo, text)
+		assert.NoError(t, err)
+		assert.Equal(t, types.TopicType("nav_name"), c.Topic(), text)
+		assert.Equal(t, []string{"name", "amount"}, *asked, text)
+	}
+}
@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS tools_search_idx ON tools USING GIN (
+  (lower(coalesce(name, '') || ' ' || coalesce(brand, '') || ' ' || coalesce(product_type, '') || ' ' || coalesce(additional_info, ''))) gin_trgm_ops
+);
\ No newline at end of file
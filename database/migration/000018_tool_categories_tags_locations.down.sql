@@ -0,0 +1,8 @@
+ALTER TABLE tools
+  DROP COLUMN IF EXISTS location_shelf,
+  DROP COLUMN IF EXISTS location_cabinet,
+  DROP COLUMN IF EXISTS location_room,
+  DROP COLUMN IF EXISTS tags,
+  DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS tool_categories;
\ No newline at end of file
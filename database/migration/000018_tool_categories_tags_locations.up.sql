@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS tool_categories (
+  id BIGSERIAL NOT NULL,
+  parent_id BIGINT,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  FOREIGN KEY (parent_id) REFERENCES tool_categories(id)
+);
+
+CREATE INDEX IF NOT EXISTS tool_categories_parentid_idx ON tool_categories ("parent_id");
+CREATE UNIQUE INDEX IF NOT EXISTS tool_categories_parent_name_idx ON tool_categories (coalesce(parent_id, 0), lower(name));
+
+ALTER TABLE tools
+  ADD COLUMN IF NOT EXISTS category_id BIGINT REFERENCES tool_categories(id),
+  ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS location_room VARCHAR(100) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS location_cabinet VARCHAR(100) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS location_shelf VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tools_categoryid_idx ON tools ("category_id");
+CREATE INDEX IF NOT EXISTS tools_tags_idx ON tools USING GIN (tags);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tool_maintenances;
+
+ALTER TABLE tools
+  DROP COLUMN IF EXISTS calibration_interval_days,
+  DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE tools
+  ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+  ADD COLUMN IF NOT EXISTS calibration_interval_days INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS tools_status_idx ON tools ("status");
+
+CREATE TABLE IF NOT EXISTS tool_maintenances (
+  id BIGSERIAL NOT NULL,
+  tool_id BIGINT NOT NULL,
+  maintenance_type VARCHAR(20) NOT NULL,
+  performed_at DATE NOT NULL,
+  technician VARCHAR(100) NOT NULL,
+  notes TEXT,
+  created_at TIMESTAMP DEFAULT NOW(),
+  PRIMARY KEY (id),
+  FOREIGN KEY (tool_id) REFERENCES tools(id)
+);
+
+CREATE INDEX IF NOT EXISTS tool_maintenances_toolid_idx ON tool_maintenances ("tool_id");
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE tools
+  DROP COLUMN IF EXISTS min_stock,
+  DROP COLUMN IF EXISTS kind;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE tools
+  ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'ASSET',
+  ADD COLUMN IF NOT EXISTS min_stock INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS tools_kind_idx ON tools ("kind");
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE borrows DROP COLUMN IF EXISTS admin_notes;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS admin_notes TEXT;
\ No newline at end of file
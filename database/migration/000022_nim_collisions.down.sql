@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS users_nim_unique_idx;
+
+UPDATE users u SET nim = c.nim
+FROM nim_collisions c
+WHERE c.user_id = u.id AND c.status = 'PENDING' AND u.nim = '';
+
+DROP TABLE IF EXISTS nim_collisions;
\ No newline at end of file
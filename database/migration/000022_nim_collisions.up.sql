@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS nim_collisions (
+  id BIGSERIAL NOT NULL,
+  user_id BIGINT NOT NULL,
+  existing_user_id BIGINT NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  nim VARCHAR(20) NOT NULL,
+  batch SMALLINT,
+  address VARCHAR(500),
+  language VARCHAR(5) NOT NULL DEFAULT 'id',
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+  created_at TIMESTAMP DEFAULT NOW(),
+  resolved_at TIMESTAMP,
+  resolved_by VARCHAR(100),
+  PRIMARY KEY (id),
+  FOREIGN KEY (existing_user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS nim_collisions_status_idx ON nim_collisions ("status");
+
+-- accounts registered after another account with the same NIM are put in the
+-- review queue, the first account keeps the NIM
+INSERT INTO nim_collisions (user_id, existing_user_id, name, nim, batch, address, language, created_at)
+SELECT u.id, f.id, u.name, u.nim, u.batch, u.address, u.language, u.created_at
+FROM users u
+JOIN LATERAL (
+  SELECT id FROM users
+  WHERE nim = u.nim
+  ORDER BY created_at, id
+  LIMIT 1
+) f ON f.id <> u.id
+WHERE u.nim <> '';
+
+UPDATE users SET nim = ''
+WHERE id IN (SELECT user_id FROM nim_collisions WHERE status = 'PENDING');
+
+-- an empty NIM belongs to a registration that is not finished yet
+CREATE UNIQUE INDEX IF NOT EXISTS users_nim_unique_idx ON users (nim) WHERE nim <> '';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- users registered before the approval are approved, new ones wait for an admin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'APPROVED';
+ALTER TABLE users ALTER COLUMN status SET DEFAULT 'PENDING';
+
+-- accounts whose NIM is still under review wait for it like new ones
+UPDATE users SET status = 'PENDING'
+WHERE id IN (SELECT user_id FROM nim_collisions WHERE status = 'PENDING');
+
+CREATE INDEX IF NOT EXISTS users_status_idx ON users ("status");
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE nim_collisions DROP COLUMN IF EXISTS phone;
+ALTER TABLE users DROP COLUMN IF EXISTS phone;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- the phone number is optional and shared through the contact button of Telegram
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE nim_collisions ADD COLUMN IF NOT EXISTS phone VARCHAR(20) NOT NULL DEFAULT '';
\ No newline at end of file
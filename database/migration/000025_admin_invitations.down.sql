@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_requests;
+DROP TABLE IF EXISTS admin_invitations;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- admins are looked up by the Telegram username when their access is revoked
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(32) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS admin_invitations (
+  id BIGSERIAL NOT NULL,
+  code VARCHAR(16) NOT NULL,
+  created_by BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  used_by BIGINT,
+  used_at TIMESTAMP,
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS admin_invitations_code_idx ON admin_invitations (code);
+
+CREATE TABLE IF NOT EXISTS admin_requests (
+  id BIGSERIAL NOT NULL,
+  user_id BIGINT NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  username VARCHAR(32) NOT NULL DEFAULT '',
+  language VARCHAR(5) NOT NULL DEFAULT 'id',
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+  created_at TIMESTAMP DEFAULT NOW(),
+  resolved_at TIMESTAMP,
+  resolved_by VARCHAR(100),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS admin_requests_status_idx ON admin_requests ("status");
\ No newline at end of file
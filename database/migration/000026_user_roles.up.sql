@@ -0,0 +1,3 @@
+-- admins before the roles keep every permission
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT '';
+UPDATE users SET role = 'kepala' WHERE user_type IN ('admin', 'both');
\ No newline at end of file
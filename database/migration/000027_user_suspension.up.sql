@@ -0,0 +1,3 @@
+-- a suspended student keeps the account but cannot borrow until an admin lifts it
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '';
\ No newline at end of file
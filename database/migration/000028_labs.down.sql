@@ -0,0 +1,6 @@
+ALTER TABLE admin_requests DROP COLUMN IF EXISTS lab_id;
+ALTER TABLE admin_invitations DROP COLUMN IF EXISTS lab_id;
+ALTER TABLE users DROP COLUMN IF EXISTS lab_id;
+ALTER TABLE users DROP COLUMN IF EXISTS admin_lab_id;
+ALTER TABLE tools DROP COLUMN IF EXISTS lab_id;
+DROP TABLE IF EXISTS labs;
\ No newline at end of file
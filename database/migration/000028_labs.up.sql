@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS labs (
+  id BIGSERIAL NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  admin_group_id BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+-- a lab without admin group waits for ADMIN_GROUP_ID or the first /lab typed in a group
+CREATE UNIQUE INDEX IF NOT EXISTS labs_admin_group_id_idx ON labs (admin_group_id) WHERE admin_group_id <> 0;
+
+-- the tools and admins from before the labs belong to the first lab
+INSERT INTO labs (name) VALUES ('Laboratorium');
+
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS lab_id BIGINT REFERENCES labs(id);
+UPDATE tools SET lab_id = (SELECT MIN(id) FROM labs);
+ALTER TABLE tools ALTER COLUMN lab_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS tools_labid_idx ON tools ("lab_id");
+
+-- admin_lab_id is the lab an admin looks after, lab_id the lab a student borrows from
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS admin_lab_id BIGINT REFERENCES labs(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS lab_id BIGINT REFERENCES labs(id) ON DELETE SET NULL;
+UPDATE users SET admin_lab_id = (SELECT MIN(id) FROM labs) WHERE user_type IN ('admin', 'both');
+
+ALTER TABLE admin_invitations ADD COLUMN IF NOT EXISTS lab_id BIGINT REFERENCES labs(id);
+UPDATE admin_invitations SET lab_id = (SELECT MIN(id) FROM labs);
+ALTER TABLE admin_invitations ALTER COLUMN lab_id SET NOT NULL;
+
+ALTER TABLE admin_requests ADD COLUMN IF NOT EXISTS lab_id BIGINT REFERENCES labs(id);
+UPDATE admin_requests SET lab_id = (SELECT MIN(id) FROM labs);
+ALTER TABLE admin_requests ALTER COLUMN lab_id SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- audit_log is append-only: actor_user_id has no foreign key so the entries of a
+-- revoked or deleted admin are kept, and the trigger refuses updates and deletes
+CREATE TABLE IF NOT EXISTS audit_log (
+  id BIGSERIAL NOT NULL,
+  lab_id BIGINT NOT NULL REFERENCES labs(id),
+  actor_user_id BIGINT NOT NULL,
+  action VARCHAR(30) NOT NULL,
+  entity VARCHAR(30) NOT NULL,
+  entity_id BIGINT NOT NULL,
+  before JSONB,
+  after JSONB,
+  created_at TIMESTAMP DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log ("entity", "entity_id");
+CREATE INDEX IF NOT EXISTS audit_log_actor_user_id_idx ON audit_log ("actor_user_id");
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+  BEFORE UPDATE OR DELETE ON audit_log
+  FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
\ No newline at end of file
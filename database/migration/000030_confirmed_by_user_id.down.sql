@@ -0,0 +1,2 @@
+ALTER TABLE tool_returning DROP COLUMN IF EXISTS confirmed_by_user_id;
+ALTER TABLE borrows DROP COLUMN IF EXISTS confirmed_by_user_id;
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- confirmed_by stays as the name of the admin at the time, shown when the
+-- admin could not be mapped below or has since been deleted
+ALTER TABLE borrows ADD COLUMN IF NOT EXISTS confirmed_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tool_returning ADD COLUMN IF NOT EXISTS confirmed_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS borrows_confirmed_by_user_id_idx ON borrows ("confirmed_by_user_id");
+CREATE INDEX IF NOT EXISTS tool_returning_confirmed_by_user_id_idx ON tool_returning ("confirmed_by_user_id");
+
+-- a stored name is mapped only when exactly one admin has that name
+UPDATE borrows b
+SET confirmed_by_user_id = a.id
+FROM (
+  SELECT MIN(id) AS id, name
+  FROM users
+  WHERE user_type IN ('admin', 'both')
+  GROUP BY name
+  HAVING COUNT(*) = 1
+) a
+WHERE b.confirmed_by = a.name
+  AND b.confirmed_by_user_id IS NULL;
+
+UPDATE tool_returning tr
+SET confirmed_by_user_id = a.id
+FROM (
+  SELECT MIN(id) AS id, name
+  FROM users
+  WHERE user_type IN ('admin', 'both')
+  GROUP BY name
+  HAVING COUNT(*) = 1
+) a
+WHERE tr.confirmed_by = a.name
+  AND tr.confirmed_by_user_id IS NULL;
\ No newline at end of file
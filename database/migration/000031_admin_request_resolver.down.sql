@@ -0,0 +1 @@
+ALTER TABLE admin_requests DROP COLUMN IF EXISTS resolved_by_user_id;
\ No newline at end of file
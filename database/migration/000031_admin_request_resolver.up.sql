@@ -0,0 +1,3 @@
+-- resolved_by stays as the name of the admin at the time, shown once the admin
+-- has been deleted
+ALTER TABLE admin_requests ADD COLUMN IF NOT EXISTS resolved_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
\ No newline at end of file
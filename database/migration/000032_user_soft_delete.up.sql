@@ -0,0 +1,3 @@
+-- a deleted student keeps the row so the borrowing history stays, without
+-- the personal data
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
\ No newline at end of file
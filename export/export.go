@@ -0,0 +1,79 @@
+// Package export writes tables as spreadsheet files that can be sent as
+// Telegram documents.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Column is a column of a table. Numeric columns are written as numbers in
+	// formats that tell numbers and text apart.
+	Column struct {
+		Header  string
+		Numeric bool
+	}
+
+	// Table is a header row followed by rows with a value for each column.
+	Table struct {
+		Columns []Column
+		Rows    [][]string
+	}
+)
+
+// CSV writes the table as comma separated values with a header row.
+func CSV(t Table) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(t.headers()); err != nil {
+		return nil, err
+	}
+
+	for _, row := range t.Rows {
+		values := make([]string, len(row))
+		for i, value := range row {
+			values[i] = t.cell(i, value)
+		}
+
+		if err := w.Write(values); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// cell is the value as written to the file. A text starting with a character
+// a spreadsheet reads as the start of a formula is prefixed with "'", so a
+// name or note typed by a student cannot run as a formula when an admin opens
+// the file. Numbers in numeric columns are kept as they are.
+func (t Table) cell(index int, value string) string {
+	if index < len(t.Columns) && t.Columns[index].Numeric {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	}
+
+	if len(value) > 0 && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func (t Table) headers() []string {
+	headers := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		headers[i] = column.Header
+	}
+	return headers
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var table = Table{
+	Columns: []Column{
+		{Header: "ID", Numeric: true},
+		{Header: "Nama"},
+	},
+	Rows: [][]string{
+		{"1", "Budi, S.T."},
+		{"2", `Andi "<Lab>"`},
+	},
+}
+
+func TestCSV(t *testing.T) {
+	r, err := CSV(table)
+
+	expected := "ID,Nama\n" +
+		"1,\"Budi, S.T.\"\n" +
+		"2,\"Andi \"\"<Lab>\"\"\"\n"
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(r))
+}
+
+func TestXLSX(t *testing.T) {
+	r, err := XLSX("Laporan", table)
+	assert.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(r), int64(len(r)))
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "_rels/.rels")
+	assert.Contains(t, files, "xl/_rels/workbook.xml.rels")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Laporan"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, sheet, `<c r="B3" t="inlineStr"><is><t xml:space="preserve">Andi &#34;&lt;Lab&gt;&#34;</t></is></c>`)
+}
+
+func TestFormulaCellsAreNeutralised(t *testing.T) {
+	formulas := Table{
+		Columns: []Column{
+			{Header: "Jumlah", Numeric: true},
+			{Header: "Catatan"},
+		},
+		Rows: [][]string{
+			{"-2", "=HYPERLINK(\"http://example.com\")"},
+			{"3", "+62 812"},
+			{"4", "@SUM(A1:A2)"},
+			{"5", "-"},
+			{"6", "\tcmd"},
+		},
+	}
+
+	r, err := CSV(formulas)
+	assert.NoError(t, err)
+
+	expected := "Jumlah,Catatan\n" +
+		"-2,\"'=HYPERLINK(\"\"http://example.com\"\")\"\n" +
+		"3,'+62 812\n" +
+		"4,'@SUM(A1:A2)\n" +
+		"5,'-\n" +
+		"6,'\tcmd\n"
+	assert.Equal(t, expected, string(r))
+
+	sheet := worksheet(formulas)
+	assert.Contains(t, sheet, `<c r="A2"><v>-2</v></c>`)
+	assert.Contains(t, sheet, `<c r="B4" t="inlineStr"><is><t xml:space="preserve">&#39;@SUM(A1:A2)</t></is></c>`)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, "AB", ColumnName(27))
+	assert.Equal(t, "BA", ColumnName(52))
+}
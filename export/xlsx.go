@@ -0,0 +1,122 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+	// xlsxMaxSheetName is the longest sheet name spreadsheet applications accept.
+	xlsxMaxSheetName = 31
+)
+
+// XLSX writes the table as the only sheet of an Office Open XML workbook. Only
+// the parts every spreadsheet application requires are written, without styles.
+func XLSX(sheet string, t Table) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXML(sheetName(sheet)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", worksheet(t)},
+	}
+
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func worksheet(t Table) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	writeRow(&buf, 1, t.headers(), nil)
+	for i, row := range t.Rows {
+		values := make([]string, len(row))
+		for j, value := range row {
+			values[j] = t.cell(j, value)
+		}
+		writeRow(&buf, i+2, values, t.Columns)
+	}
+
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.String()
+}
+
+// writeRow writes the values as inline strings, or as numbers in the numeric
+// columns. The header row has no columns to tell so.
+func writeRow(buf *bytes.Buffer, number int, values []string, columns []Column) {
+	fmt.Fprintf(buf, `<row r="%d">`, number)
+	for i, value := range values {
+		ref := fmt.Sprintf("%s%d", ColumnName(i), number)
+
+		if i < len(columns) && columns[i].Numeric {
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, value)
+				continue
+			}
+		}
+
+		fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(value))
+	}
+	buf.WriteString(`</row>`)
+}
+
+// ColumnName is the spreadsheet name of the zero based column index, e.g. 0 is
+// "A" and 27 is "AB".
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func sheetName(name string) string {
+	runes := []rune(name)
+	if len(runes) > xlsxMaxSheetName {
+		runes = runes[:xlsxMaxSheetName]
+	}
+	return string(runes)
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,181 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// Mode is a Telegram parse mode.
+type Mode string
+
+const (
+	MarkdownV2 Mode = "MarkdownV2"
+	HTML       Mode = "HTML"
+)
+
+// markdownV2Special are the characters that must be escaped anywhere in a
+// MarkdownV2 message, see https://core.telegram.org/bots/api#markdownv2-style
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+var htmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// Escape makes s safe to be sent as plain text in the given parse mode.
+func Escape(mode Mode, s string) string {
+	switch mode {
+	case MarkdownV2:
+		return escapeMarkdownV2(s, markdownV2Special)
+	case HTML:
+		return htmlReplacer.Replace(s)
+	}
+
+	return s
+}
+
+// escapeCode escapes the text inside code spans, where MarkdownV2 only
+// requires "`" and "\" to be escaped.
+func escapeCode(mode Mode, s string) string {
+	if mode == MarkdownV2 {
+		return escapeMarkdownV2(s, "`\\")
+	}
+	return Escape(mode, s)
+}
+
+// escapeURL escapes the target of a link, where MarkdownV2 only requires ")"
+// and "\" to be escaped.
+func escapeURL(mode Mode, s string) string {
+	if mode == MarkdownV2 {
+		return escapeMarkdownV2(s, ")\\")
+	}
+	return Escape(mode, s)
+}
+
+func escapeMarkdownV2(s, special string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Builder writes a formatted message. Every text given to it is escaped, so
+// user content such as tool names and reasons can be passed as is.
+type Builder struct {
+	mode Mode
+	sb   strings.Builder
+}
+
+func New(mode Mode) *Builder {
+	return &Builder{
+		mode: mode,
+	}
+}
+
+func (b *Builder) Mode() Mode {
+	return b.mode
+}
+
+// Text writes plain text.
+func (b *Builder) Text(s string) *Builder {
+	b.sb.WriteString(Escape(b.mode, s))
+	return b
+}
+
+func (b *Builder) Bold(s string) *Builder {
+	return b.wrap(s, "*", "<b>", "</b>")
+}
+
+func (b *Builder) Italic(s string) *Builder {
+	return b.wrap(s, "_", "<i>", "</i>")
+}
+
+// Code writes s as inline monospace text, e.g. a command the user can copy.
+func (b *Builder) Code(s string) *Builder {
+	switch b.mode {
+	case MarkdownV2:
+		b.sb.WriteString("`" + escapeCode(b.mode, s) + "`")
+	case HTML:
+		b.sb.WriteString("<code>" + escapeCode(b.mode, s) + "</code>")
+	default:
+		b.sb.WriteString(s)
+	}
+	return b
+}
+
+func (b *Builder) Link(text, url string) *Builder {
+	switch b.mode {
+	case MarkdownV2:
+		b.sb.WriteString(fmt.Sprintf("[%s](%s)", Escape(b.mode, text), escapeURL(b.mode, url)))
+	case HTML:
+		b.sb.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, escapeURL(b.mode, url), Escape(b.mode, text)))
+	default:
+		b.sb.WriteString(fmt.Sprintf("%s (%s)", text, url))
+	}
+	return b
+}
+
+// Line ends the current line.
+func (b *Builder) Line() *Builder {
+	b.sb.WriteString("\n")
+	return b
+}
+
+// Title writes a bold line followed by an empty line.
+func (b *Builder) Title(s string) *Builder {
+	return b.Bold(s).Line().Line()
+}
+
+// Field writes a "label: value" line with a bold label.
+func (b *Builder) Field(label, value string) *Builder {
+	return b.Bold(label + ":").Text(" " + value).Line()
+}
+
+// Block writes a bold label with a value that may span several lines below it.
+func (b *Builder) Block(label, value string) *Builder {
+	return b.Bold(label + ":").Line().Text(value).Line()
+}
+
+// Item writes an entry of a bulleted list.
+func (b *Builder) Item(s string) *Builder {
+	return b.Text("• " + s).Line()
+}
+
+// NumberedItem writes an entry of a numbered list.
+func (b *Builder) NumberedItem(n int, s string) *Builder {
+	return b.Text(fmt.Sprintf("%d. %s", n, s)).Line()
+}
+
+// Raw writes s without escaping it. It is meant for text formatted by another
+// Builder with the same mode.
+func (b *Builder) Raw(s string) *Builder {
+	b.sb.WriteString(s)
+	return b
+}
+
+func (b *Builder) String() string {
+	return b.sb.String()
+}
+
+// Request builds a message request with the formatted text and its parse mode.
+func (b *Builder) Request() types.MessageRequest {
+	return types.MessageRequest{
+		Text:      b.String(),
+		ParseMode: string(b.mode),
+	}
+}
+
+func (b *Builder) wrap(s, markdown, htmlOpen, htmlClose string) *Builder {
+	switch b.mode {
+	case MarkdownV2:
+		b.sb.WriteString(markdown + Escape(b.mode, s) + markdown)
+	case HTML:
+		b.sb.WriteString(htmlOpen + Escape(b.mode, s) + htmlClose)
+	default:
+		b.sb.WriteString(s)
+	}
+	return b
+}
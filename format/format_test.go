@@ -0,0 +1,64 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscape(t *testing.T) {
+	t.Run("markdown v2", func(t *testing.T) {
+		r := Escape(MarkdownV2, "multi_meter (v1.2) - 10% off!")
+		assert.Equal(t, `multi\_meter \(v1\.2\) \- 10% off\!`, r)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		r := Escape(HTML, `<b>"R&D"</b>`)
+		assert.Equal(t, "&lt;b&gt;&quot;R&amp;D&quot;&lt;/b&gt;", r)
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		r := Escape("", "multi_meter")
+		assert.Equal(t, "multi_meter", r)
+	})
+}
+
+func TestBuilderMarkdownV2(t *testing.T) {
+	b := New(MarkdownV2)
+	b.Title("Osiloskop_1")
+	b.Field("Stok", "3")
+	b.Block("Keterangan", "baru*")
+	b.Item("[1] Budi")
+	b.NumberedItem(2, "cek.")
+	b.Text("Contoh: ").Code("/pinjam `1`")
+	b.Line().Italic("a_b").Link("docs", "https://example.com/a_(b)")
+
+	expected := "*Osiloskop\\_1*\n\n" +
+		"*Stok:* 3\n" +
+		"*Keterangan:*\nbaru\\*\n" +
+		"• \\[1\\] Budi\n" +
+		"2\\. cek\\.\n" +
+		"Contoh: `/pinjam \\`1\\``\n" +
+		"_a\\_b_[docs](https://example.com/a_(b\\))"
+
+	assert.Equal(t, expected, b.String())
+}
+
+func TestBuilderHTML(t *testing.T) {
+	b := New(HTML)
+	b.Field("Nama", "<script>")
+	b.Code("a&b").Link("docs", `https://example.com/?q="x"`)
+
+	expected := "<b>Nama:</b> &lt;script&gt;\n" +
+		"<code>a&amp;b</code>" +
+		`<a href="https://example.com/?q=&quot;x&quot;">docs</a>`
+
+	assert.Equal(t, expected, b.String())
+}
+
+func TestBuilderRequest(t *testing.T) {
+	req := New(MarkdownV2).Bold("Laporan").Request()
+
+	assert.Equal(t, "*Laporan*", req.Text)
+	assert.Equal(t, "MarkdownV2", req.ParseMode)
+}
@@ -18,6 +18,7 @@ func WebhookHandler(c echo.Context) error {
 	var chatID int64
 	var senderID int64
 	var messageText string
+	var languageCode string
 	var teleMessage types.TeleMessage
 	var requestType types.RequestType
 	var bodyBytes []byte
@@ -31,6 +32,17 @@ func WebhookHandler(c echo.Context) error {
 	bodyBytes, _ = ioutil.ReadAll(c.Request().Body)
 	c.Request().Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
 
+	inlineQueryBody := new(types.InlineQueryRequest)
+	if err := json.Unmarshal(bodyBytes, inlineQueryBody); err != nil {
+		log.Println("could not decode request body", err)
+		return err
+	}
+
+	// inline query is not sent from a chat, so there is no chat session to continue
+	if len(inlineQueryBody.InlineQuery.ID) > 0 {
+		return service.NewInlineQueryService(inlineQueryBody.InlineQuery).Answer()
+	}
+
 	callbackBody = new(types.InlineCallbackQuery)
 	if err := json.Unmarshal(bodyBytes, callbackBody); err != nil {
 		log.Println("could not decode request body", err)
@@ -48,6 +60,7 @@ func WebhookHandler(c echo.Context) error {
 		chatID = body.Message.Chat.ID
 		messageText = body.Message.Text
 		teleMessage = body.Message
+		languageCode = body.Message.From.LanguageCode
 		if body.Message.Chat.Type == "group" {
 			senderID = body.Message.From.ID
 			requestType = types.RequestTypeGroup
@@ -59,6 +72,10 @@ func WebhookHandler(c echo.Context) error {
 		chatID = callbackBody.CallbackQuery.Message.Chat.ID
 		messageText = callbackBody.CallbackQuery.Data
 		teleMessage = callbackBody.CallbackQuery.Message
+		// the message of a callback query is the one the bot sent with the
+		// buttons, so it is from the bot rather than the one pressing them
+		teleMessage.From = callbackBody.CallbackQuery.From
+		languageCode = callbackBody.CallbackQuery.From.LanguageCode
 		if callbackBody.CallbackQuery.Message.Chat.Type == "group" {
 			senderID = callbackBody.CallbackQuery.From.ID
 			requestType = types.RequestTypeGroup
@@ -68,7 +85,7 @@ func WebhookHandler(c echo.Context) error {
 		}
 	}
 
-	messageService = service.NewMessageService(chatID, senderID, messageText, requestType, teleMessage)
+	messageService = service.NewMessageService(chatID, senderID, messageText, requestType, teleMessage, languageCode)
 
 	user := types.User{ID: senderID}
 
@@ -124,14 +141,32 @@ func commandHandler(message string, ms *service.MessageService) error {
 		return ms.Borrow()
 	case types.CommandReturn:
 		return ms.ReturnTool()
+	case types.CommandHistory:
+		return ms.History()
+	case types.CommandProfile:
+		return ms.Profile()
 	case types.CommandAdmin:
 		return ms.BeAdmin()
+	case types.CommandRole:
+		return ms.Role()
+	case types.CommandUser:
+		return ms.User()
+	case types.CommandAudit:
+		return ms.Audit()
 	case types.CommandRespond:
 		return ms.Respond()
 	case types.CommandManage:
 		return ms.Manage()
 	case types.CommandReport:
 		return ms.Report()
+	case types.CommandMaintenance:
+		return ms.Maintenance()
+	case types.CommandStatistics:
+		return ms.Statistics()
+	case types.CommandLanguage:
+		return ms.Language()
+	case types.CommandLab:
+		return ms.Lab()
 	default:
 		return ms.Unknown()
 	}
@@ -147,33 +182,8 @@ func sessionProcess(chatSession types.ChatSession, messageService *service.Messa
 
 	if len(chatSessionDetails) > 0 {
 		messageService.ChangeChatSessionDetails(chatSessionDetails)
-		return sessionHandler(chatSessionDetails[0].Topic, messageService)
+		return messageService.ContinueConversation(chatSession)
 	}
 
 	return nil
 }
-
-func sessionHandler(topic types.TopicType, ms *service.MessageService) error {
-	switch topic {
-	case types.Topic["register_init"], types.Topic["register_confirm"], types.Topic["register_complete"]:
-		return ms.Register()
-	case types.Topic["borrow_init"], types.Topic["borrow_amount"], types.Topic["borrow_date"], types.Topic["borrow_reason"], types.Topic["borrow_confirm"]:
-		return ms.Borrow()
-	case types.Topic["tool_returning_init"], types.Topic["tool_returning_confirm"]:
-		return ms.ReturnTool()
-	case types.Topic["respond_borrow_init"]:
-		return ms.RespondBorrow()
-	case types.Topic["respond_tool_returning_init"]:
-		return ms.RespondToolReturning()
-	case types.Topic["manage_add_init"], types.Topic["manage_add_name"], types.Topic["manage_add_brand"], types.Topic["manage_add_type"], types.Topic["manage_add_weight"], types.Topic["manage_add_stock"], types.Topic["manage_add_info"], types.Topic["manage_add_photo"], types.Topic["manage_add_confirm"]:
-		return ms.ManageAdd()
-	case types.Topic["manage_edit_init"], types.Topic["manage_edit_field"], types.Topic["manage_edit_complete"]:
-		return ms.ManageEdit()
-	case types.Topic["manage_delete_init"], types.Topic["manage_delete_complete"]:
-		return ms.ManageDelete()
-	case types.Topic["manage_photo_init"], types.Topic["manage_photo_upload"], types.Topic["manage_photo_confirm"]:
-		return ms.ManagePhoto()
-	default:
-		return ms.Unknown()
-	}
-}
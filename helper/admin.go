@@ -0,0 +1,133 @@
+package helper
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// invitationCodeAlphabet leaves out the characters easily mistaken for each
+// other, such as O and 0.
+const (
+	invitationCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	invitationCodeLength   = 8
+)
+
+// GetAdminCommandOrder parses "/pengurus [undang|daftar]",
+// "/pengurus cabut [@username|id]" and "/pengurus [kode undangan]".
+func GetAdminCommandOrder(s string) (types.AdminCommandOrder, bool) {
+	ss := strings.Fields(s)
+	if len(ss) < 1 || len(ss) > 3 {
+		return types.AdminCommandOrder{}, false
+	}
+
+	if len(ss) == 1 {
+		return types.AdminCommandOrder{}, true
+	}
+
+	adminType := types.AdminType(strings.ToLower(ss[1]))
+	switch adminType {
+	case types.AdminTypeInvite, types.AdminTypeList:
+		if len(ss) == 2 {
+			return types.AdminCommandOrder{Type: adminType}, true
+		}
+	case types.AdminTypeRevoke:
+		if len(ss) == 3 {
+			return types.AdminCommandOrder{Type: adminType, Text: ss[2]}, true
+		}
+	default:
+		if len(ss) == 2 {
+			return types.AdminCommandOrder{Text: strings.ToUpper(ss[1])}, true
+		}
+	}
+
+	return types.AdminCommandOrder{}, false
+}
+
+// GetAdminTarget reads the admin a command is about, either "@username" or
+// the ID shown in the list of admins.
+func GetAdminTarget(s string) (int64, string, bool) {
+	if strings.HasPrefix(s, "@") {
+		username := strings.TrimPrefix(s, "@")
+		return 0, username, len(username) > 0
+	}
+
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return id, "", true
+}
+
+// GenerateInvitationCode returns a random code for an admin invitation.
+func GenerateInvitationCode() (string, error) {
+	max := big.NewInt(int64(len(invitationCodeAlphabet)))
+
+	code := make([]byte, invitationCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = invitationCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+func BuildAdminListMessage(p i18n.Printer, users []types.User) string {
+	var message string
+	for _, user := range users {
+		message = fmt.Sprintf("%s[%d] %s", message, user.ID, user.Name)
+		if len(user.Username) > 0 {
+			message = fmt.Sprintf("%s (@%s)", message, user.Username)
+		}
+		if user.Role.IsValid() {
+			message = fmt.Sprintf("%s - %s", message, p.Text(fmt.Sprintf("role.%s", user.Role)))
+		}
+		message += "\n"
+	}
+	return message
+}
+
+// GetRoleCommandOrder parses "/peran" and "/peran [@username|id] [peran]".
+func GetRoleCommandOrder(s string) (types.RoleCommandOrder, bool) {
+	ss := strings.Fields(s)
+	switch len(ss) {
+	case 1:
+		return types.RoleCommandOrder{}, true
+	case 3:
+		return types.RoleCommandOrder{Target: ss[1], Role: types.Role(strings.ToLower(ss[2]))}, true
+	}
+
+	return types.RoleCommandOrder{}, false
+}
+
+// BuildRoleListMessage describes the permissions of every role.
+func BuildRoleListMessage(p i18n.Printer) string {
+	var message string
+	for _, role := range types.Roles {
+		message = fmt.Sprintf("%s%s (%s)\n", message, p.Text(fmt.Sprintf("role.%s", role)), role)
+		for _, permission := range role.Permissions() {
+			message = fmt.Sprintf("%s  • %s\n", message, p.Text(fmt.Sprintf("permission.%s", permission)))
+		}
+	}
+	return message
+}
+
+func BuildAdminRequestListMessage(requests []types.AdminRequest) string {
+	var message string
+	for _, request := range requests {
+		message = fmt.Sprintf("%s[%d] %s", message, request.ID, request.Name)
+		if len(request.Username) > 0 {
+			message = fmt.Sprintf("%s (@%s)", message, request.Username)
+		}
+		message += "\n"
+	}
+	return message
+}
@@ -0,0 +1,117 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAdminCommandOrder(t *testing.T) {
+	t.Run("without argument", func(t *testing.T) {
+		r, ok := GetAdminCommandOrder("/pengurus")
+		assert.True(t, ok)
+		assert.Equal(t, types.AdminCommandOrder{}, r)
+	})
+
+	t.Run("invite and list", func(t *testing.T) {
+		r, ok := GetAdminCommandOrder("/pengurus undang")
+		assert.True(t, ok)
+		assert.Equal(t, types.AdminCommandOrder{Type: types.AdminTypeInvite}, r)
+
+		r, ok = GetAdminCommandOrder("/pengurus Daftar")
+		assert.True(t, ok)
+		assert.Equal(t, types.AdminCommandOrder{Type: types.AdminTypeList}, r)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		r, ok := GetAdminCommandOrder("/pengurus cabut @budi")
+		assert.True(t, ok)
+		assert.Equal(t, types.AdminCommandOrder{Type: types.AdminTypeRevoke, Text: "@budi"}, r)
+
+		_, ok = GetAdminCommandOrder("/pengurus cabut")
+		assert.False(t, ok)
+	})
+
+	t.Run("invitation code", func(t *testing.T) {
+		r, ok := GetAdminCommandOrder("/pengurus ab3kq7zx")
+		assert.True(t, ok)
+		assert.Equal(t, types.AdminCommandOrder{Text: "AB3KQ7ZX"}, r)
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		_, ok := GetAdminCommandOrder("/pengurus undang 1 2")
+		assert.False(t, ok)
+
+		_, ok = GetAdminCommandOrder("/pengurus ab3kq7zx 1")
+		assert.False(t, ok)
+	})
+}
+
+func TestGetAdminTarget(t *testing.T) {
+	id, username, ok := GetAdminTarget("@budi")
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, "budi", username)
+
+	id, username, ok = GetAdminTarget("123")
+	assert.True(t, ok)
+	assert.Equal(t, int64(123), id)
+	assert.Equal(t, "", username)
+
+	_, _, ok = GetAdminTarget("@")
+	assert.False(t, ok)
+
+	_, _, ok = GetAdminTarget("budi")
+	assert.False(t, ok)
+}
+
+func TestGenerateInvitationCode(t *testing.T) {
+	code, err := GenerateInvitationCode()
+	assert.NoError(t, err)
+	assert.Len(t, code, invitationCodeLength)
+
+	for _, c := range code {
+		assert.True(t, strings.ContainsRune(invitationCodeAlphabet, c))
+	}
+}
+
+func TestBuildAdminListMessage(t *testing.T) {
+	users := []types.User{
+		{ID: 1, Name: "Budi", Username: "budi", Role: types.RoleHead},
+		{ID: 2, Name: "Sari"},
+	}
+
+	p := i18n.NewPrinter(types.LanguageIndonesian)
+	assert.Equal(t, "[1] Budi (@budi) - Kepala Laboratorium\n[2] Sari\n", BuildAdminListMessage(p, users))
+}
+
+func TestGetRoleCommandOrder(t *testing.T) {
+	r, ok := GetRoleCommandOrder("/peran")
+	assert.True(t, ok)
+	assert.Equal(t, types.RoleCommandOrder{}, r)
+
+	r, ok = GetRoleCommandOrder("/peran @budi Dosen")
+	assert.True(t, ok)
+	assert.Equal(t, types.RoleCommandOrder{Target: "@budi", Role: types.RoleLecturer}, r)
+
+	_, ok = GetRoleCommandOrder("/peran @budi")
+	assert.False(t, ok)
+}
+
+func TestBuildRoleListMessage(t *testing.T) {
+	p := i18n.NewPrinter(types.LanguageEnglish)
+	r := BuildRoleListMessage(p)
+
+	assert.Contains(t, r, "Viewer (pengamat)\n  • View the tools which are not available\n  • View the reports and statistics\n")
+}
+
+func TestBuildAdminRequestListMessage(t *testing.T) {
+	requests := []types.AdminRequest{
+		{ID: 3, Name: "Budi", Username: "budi"},
+	}
+
+	assert.Equal(t, "[3] Budi (@budi)\n", BuildAdminRequestListMessage(requests))
+}
@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// GetAuditCommandOrder parses "/audit", "/audit [entitas] [id]" and
+// "/audit oleh [@username|id]".
+func GetAuditCommandOrder(s string) (types.AuditCommandOrder, bool) {
+	ss := strings.Fields(s)
+	switch len(ss) {
+	case 1:
+		return types.AuditCommandOrder{}, true
+	case 3:
+	default:
+		return types.AuditCommandOrder{}, false
+	}
+
+	name := strings.ToLower(ss[1])
+	if name == types.AuditCommandActor {
+		return types.AuditCommandOrder{Actor: ss[2]}, true
+	}
+
+	entity, ok := types.AuditEntityCommands[name]
+	if !ok {
+		return types.AuditCommandOrder{}, false
+	}
+
+	id, err := strconv.ParseInt(ss[2], 10, 64)
+	if err != nil || id < 1 {
+		return types.AuditCommandOrder{}, false
+	}
+
+	return types.AuditCommandOrder{Entity: entity, EntityID: id}, true
+}
+
+// AuditSnapshot turns an entity into the JSON kept before or after an action.
+// A nil entity is stored as null.
+func AuditSnapshot(entity interface{}) sql.NullString {
+	if entity == nil {
+		return sql.NullString{}
+	}
+
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: string(b)}
+}
+
+// GetPhotoUniqueIDs keeps only the unique IDs of the photos, which is enough
+// to tell the photos of a tool apart in the audit log.
+func GetPhotoUniqueIDs(photos []types.TelePhotoSize) []string {
+	ids := []string{}
+	for _, photo := range photos {
+		ids = append(ids, photo.FileUniqueID)
+	}
+	return ids
+}
+
+// GetAuditChanges lists the fields changed from before to after as
+// "field: before → after", sorted by field.
+func GetAuditChanges(before, after sql.NullString) []string {
+	var b, a map[string]interface{}
+	if !before.Valid || !after.Valid {
+		return []string{}
+	}
+	if json.Unmarshal([]byte(before.String), &b) != nil || json.Unmarshal([]byte(after.String), &a) != nil {
+		return []string{}
+	}
+
+	keys := []string{}
+	for key := range a {
+		if !reflect.DeepEqual(a[key], b[key]) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := []string{}
+	for _, key := range keys {
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", key, auditValue(b[key]), auditValue(a[key])))
+	}
+	return changes
+}
+
+func auditValue(v interface{}) string {
+	if v == nil {
+		return "-"
+	}
+
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	bs, _ := json.Marshal(v)
+	return string(bs)
+}
+
+// BuildAuditMessage lists the entries with the fields each one changed.
+func BuildAuditMessage(p i18n.Printer, mode format.Mode, entries []types.AuditEntry) string {
+	b := format.New(mode)
+	for _, entry := range entries {
+		actor := entry.ActorName
+		if len(actor) == 0 {
+			actor = fmt.Sprintf("#%d", entry.ActorUserID)
+		}
+
+		b.Item(p.Text("audit.line",
+			entry.ID, p.DateString(entry.CreatedAt), actor, p.Text(fmt.Sprintf("audit.action.%s", entry.Action)), p.Text(fmt.Sprintf("audit.entity.%s", entry.Entity)), entry.EntityID))
+		for _, change := range GetAuditChanges(entry.Before, entry.After) {
+			b.Text("   " + change).Line()
+		}
+	}
+	return b.String()
+}
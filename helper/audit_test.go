@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditCommandOrder(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected types.AuditCommandOrder
+		ok       bool
+	}{
+		{"/audit", types.AuditCommandOrder{}, true},
+		{"/audit barang 5", types.AuditCommandOrder{Entity: types.AuditEntityTool, EntityID: 5}, true},
+		{"/audit Peminjaman 12", types.AuditCommandOrder{Entity: types.AuditEntityBorrow, EntityID: 12}, true},
+		{"/audit oleh @budi", types.AuditCommandOrder{Actor: "@budi"}, true},
+		{"/audit oleh 7", types.AuditCommandOrder{Actor: "7"}, true},
+		{"/audit barang", types.AuditCommandOrder{}, false},
+		{"/audit barang abc", types.AuditCommandOrder{}, false},
+		{"/audit mobil 5", types.AuditCommandOrder{}, false},
+	}
+
+	for _, test := range tests {
+		order, ok := GetAuditCommandOrder(test.text)
+		assert.Equal(t, test.ok, ok, test.text)
+		assert.Equal(t, test.expected, order, test.text)
+	}
+}
+
+func TestAuditSnapshot(t *testing.T) {
+	assert.Equal(t, sql.NullString{}, AuditSnapshot(nil))
+	assert.Equal(t, sql.NullString{Valid: true, String: `{"id":5,"name":"Osiloskop"}`}, AuditSnapshot(struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}{5, "Osiloskop"}))
+}
+
+func TestGetAuditChanges(t *testing.T) {
+	before := sql.NullString{Valid: true, String: `{"name":"Osiloskop","stock":2,"brand":"Rigol"}`}
+	after := sql.NullString{Valid: true, String: `{"name":"Osiloskop","stock":3,"brand":"Siglent"}`}
+
+	assert.Equal(t, []string{"brand: Rigol → Siglent", "stock: 2 → 3"}, GetAuditChanges(before, after))
+	assert.Empty(t, GetAuditChanges(sql.NullString{}, after))
+}
+
+func TestBuildAuditMessage(t *testing.T) {
+	entries := []types.AuditEntry{
+		{
+			ID:          3,
+			ActorUserID: 7,
+			ActorName:   "Budi",
+			Action:      types.AuditActionUpdate,
+			Entity:      types.AuditEntityTool,
+			EntityID:    5,
+			Before:      sql.NullString{Valid: true, String: `{"stock":2}`},
+			After:       sql.NullString{Valid: true, String: `{"stock":3}`},
+			CreatedAt:   "2021-08-03T10:00:00Z",
+		},
+		{
+			ID:          2,
+			ActorUserID: 8,
+			Action:      types.AuditActionDelete,
+			Entity:      types.AuditEntityTool,
+			EntityID:    4,
+			CreatedAt:   "2021-08-02T10:00:00Z",
+		},
+	}
+
+	expected := "• [3] 3 Agustus 2021 - Budi mengubah barang #5\n" +
+		"   stock: 2 → 3\n" +
+		"• [2] 2 Agustus 2021 - #8 menghapus barang #4\n"
+
+	assert.Equal(t, expected, BuildAuditMessage(i18n.NewPrinter(types.LanguageIndonesian), format.HTML, entries))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 )
 
@@ -66,16 +68,16 @@ func GetBorrowFromChatSessionDetail(details []types.ChatSessionDetail) types.Bor
 		}
 
 		switch detail.Topic {
-		case types.Topic["borrow_init"]:
+		case types.Topic["borrow_init"], types.Topic["consume_init"]:
 			toolID, _ := dataParsed.Path("tool_id").Data().(float64)
 			borrow.ToolID = int64(toolID)
-		case types.Topic["borrow_amount"]:
+		case types.Topic["borrow_amount"], types.Topic["consume_amount"]:
 			amount, _ := dataParsed.Path("amount").Data().(float64)
 			borrow.Amount = int(amount)
 		case types.Topic["borrow_date"]:
 			duration, _ := dataParsed.Path("duration").Data().(float64)
 			borrow.Duration = int(duration)
-		case types.Topic["borrow_reason"]:
+		case types.Topic["borrow_reason"], types.Topic["consume_reason"]:
 			reason, _ := dataParsed.Path("reason").Data().(string)
 			borrow.Reason = sql.NullString{Valid: true, String: reason}
 		}
@@ -94,12 +96,94 @@ func GetSameBorrow(borrows []types.Borrow, toolID int64) (types.BorrowStatus, bo
 	return "", false
 }
 
-func BuildBorrowReportMessage(borrows []types.Borrow) string {
-	var message string
+func BuildBorrowReportMessage(p i18n.Printer, mode format.Mode, borrows []types.Borrow) string {
+	b := format.New(mode)
 	for _, borrow := range borrows {
-		message = fmt.Sprintf(
-			"%s[%d] %s - %s, %d buah %s (dikonfirmasi oleh: %s)\n",
-			message, borrow.ID, TranslateDateToBahasa(borrow.ConfirmedAt.Time), borrow.User.Name, borrow.Amount, borrow.Tool.Name, borrow.ConfirmedBy.String)
+		b.Item(p.Text("report.line",
+			borrow.ID, p.Date(borrow.ConfirmedAt.Time), borrow.User.Name, p.Plural("unit.pieces", borrow.Amount, borrow.Amount), borrow.Tool.Name, borrow.ConfirmerName()))
 	}
-	return message
+	return b.String()
+}
+
+func BuildConsumptionReportMessage(p i18n.Printer, mode format.Mode, consumptions []types.Consumption) string {
+	b := format.New(mode)
+	for _, consumption := range consumptions {
+		b.Item(p.Text("report.consumption_line",
+			consumption.Tool.ID, consumption.Tool.Name, p.Plural("unit.pieces", consumption.Amount, consumption.Amount), p.Plural("unit.requests", consumption.Requests, consumption.Requests)))
+	}
+	return b.String()
+}
+
+func BuildLowStockMessage(p i18n.Printer, mode format.Mode, tools []types.Tool) string {
+	b := format.New(mode)
+	for _, tool := range tools {
+		b.Item(p.Text("report.low_stock_line", tool.ID, tool.Name, tool.Stock, tool.MinStock))
+	}
+	return b.String()
+}
+
+// borrowHistoryStatuses orders the sections of the borrowing history.
+var borrowHistoryStatuses = []types.BorrowStatus{
+	types.GetBorrowStatus("request"),
+	types.GetBorrowStatus("progress"),
+	types.GetBorrowStatus("returned"),
+	types.GetBorrowStatus("consumed"),
+	types.GetBorrowStatus("reject"),
+}
+
+// PageBorrows cuts the page, counted from 1, out of the borrows. A page past
+// the last one shows the last page.
+func PageBorrows(borrows []types.Borrow, page, size int) ([]types.Borrow, int, int) {
+	pages := (len(borrows) + size - 1) / size
+	if pages == 0 {
+		return []types.Borrow{}, 1, 1
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if page > pages {
+		page = pages
+	}
+
+	end := page * size
+	if end > len(borrows) {
+		end = len(borrows)
+	}
+
+	return borrows[(page-1)*size : end], page, pages
+}
+
+// BuildBorrowHistoryMessage groups the borrows by status, showing when each
+// was requested and responded to along with the notes of the admin.
+func BuildBorrowHistoryMessage(p i18n.Printer, mode format.Mode, borrows []types.Borrow) string {
+	b := format.New(mode)
+	groups := GroupBorrowStatus(borrows)
+
+	for _, status := range borrowHistoryStatuses {
+		group := groups[status]
+		if len(group) == 0 {
+			continue
+		}
+
+		b.Line().Bold(p.Text(fmt.Sprintf("history.status.%s", status))).Line()
+		for _, borrow := range group {
+			amount := p.Plural("unit.pieces", borrow.Amount, borrow.Amount)
+			if borrow.Tool.IsConsumable() {
+				b.Item(p.Text("history.line_consumable", borrow.ID, borrow.Tool.Name, amount))
+			} else {
+				b.Item(p.Text("history.line", borrow.ID, borrow.Tool.Name, amount, p.Plural("unit.days", borrow.Duration, borrow.Duration)))
+			}
+
+			b.Text("   " + p.Text("history.requested_at", p.DateString(borrow.CreatedAt))).Line()
+			if borrow.ConfirmedAt.Valid {
+				b.Text("   " + p.Text("history.confirmed_at", p.Date(borrow.ConfirmedAt.Time), borrow.ConfirmerName())).Line()
+			}
+			if borrow.AdminNotes.Valid && len(borrow.AdminNotes.String) > 0 {
+				b.Text("   " + p.Text("history.admin_notes", borrow.AdminNotes.String)).Line()
+			}
+		}
+	}
+
+	return b.String()
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -215,6 +217,36 @@ func TestGetBorrowFromChatSessionDetail(t *testing.T) {
 		assert.Equal(t, expected, r)
 	})
 
+	t.Run("consume session", func(t *testing.T) {
+		borrows := []types.ChatSessionDetail{
+			{
+				Topic: types.Topic["consume_init"],
+				Data:  NewSessionDataGenerator().ConsumeInit(toolID),
+			},
+			{
+				Topic: types.Topic["consume_amount"],
+				Data:  NewSessionDataGenerator().ConsumeAmount(amount),
+			},
+			{
+				Topic: types.Topic["consume_reason"],
+				Data:  NewSessionDataGenerator().ConsumeReason(reason),
+			},
+		}
+
+		r := GetBorrowFromChatSessionDetail(borrows)
+
+		expected := types.Borrow{
+			ToolID: toolID,
+			Amount: amount,
+			Reason: sql.NullString{
+				Valid:  true,
+				String: reason,
+			},
+		}
+
+		assert.Equal(t, expected, r)
+	})
+
 	t.Run("not full session", func(t *testing.T) {
 		borrows := []types.ChatSessionDetail{
 			{
@@ -314,9 +346,105 @@ func TestCanBuildBorrowReportMessage(t *testing.T) {
 		},
 	}
 
-	r := BuildBorrowReportMessage(borrows)
+	r := BuildBorrowReportMessage(i18n.NewPrinter(types.LanguageIndonesian), format.MarkdownV2, borrows)
 
 	// todo: make a better assertion
 	assert.Contains(t, r, borrows[0].User.Name)
 	assert.Contains(t, r, borrows[1].User.Name)
+	assert.Contains(t, r, "\\[1\\]")
+}
+
+func TestBuildConsumptionReportMessage(t *testing.T) {
+	consumptions := []types.Consumption{
+		{Tool: types.Tool{ID: 3, Name: "Timah Solder"}, Amount: 12, Requests: 4},
+	}
+
+	r := BuildConsumptionReportMessage(i18n.NewPrinter(types.LanguageEnglish), format.MarkdownV2, consumptions)
+
+	assert.Equal(t, "• \\[3\\] Timah Solder \\- 12 pieces from 4 requests\n", r)
+}
+
+func TestBuildLowStockMessage(t *testing.T) {
+	tools := []types.Tool{
+		{ID: 3, Name: "Timah Solder", Stock: 2, MinStock: 5},
+	}
+
+	r := BuildLowStockMessage(i18n.NewPrinter(types.LanguageIndonesian), format.MarkdownV2, tools)
+
+	assert.Equal(t, "• \\[3\\] Timah Solder \\- sisa 2, minimum 5\n", r)
+}
+
+func TestPageBorrows(t *testing.T) {
+	borrows := []types.Borrow{}
+	for i := 1; i <= 25; i++ {
+		borrows = append(borrows, types.Borrow{ID: int64(i)})
+	}
+
+	t.Run("middle page", func(t *testing.T) {
+		r, page, pages := PageBorrows(borrows, 2, 10)
+
+		assert.Len(t, r, 10)
+		assert.Equal(t, int64(11), r[0].ID)
+		assert.Equal(t, 2, page)
+		assert.Equal(t, 3, pages)
+	})
+
+	t.Run("past the last page", func(t *testing.T) {
+		r, page, pages := PageBorrows(borrows, 9, 10)
+
+		assert.Len(t, r, 5)
+		assert.Equal(t, 3, page)
+		assert.Equal(t, 3, pages)
+	})
+
+	t.Run("no page given", func(t *testing.T) {
+		r, page, _ := PageBorrows(borrows, 0, 10)
+
+		assert.Equal(t, int64(1), r[0].ID)
+		assert.Equal(t, 1, page)
+	})
+
+	t.Run("no borrows", func(t *testing.T) {
+		r, page, pages := PageBorrows([]types.Borrow{}, 1, 10)
+
+		assert.Empty(t, r)
+		assert.Equal(t, 1, page)
+		assert.Equal(t, 1, pages)
+	})
+}
+
+func TestBuildBorrowHistoryMessage(t *testing.T) {
+	borrows := []types.Borrow{
+		{
+			ID:          2,
+			Amount:      1,
+			Duration:    7,
+			Status:      types.GetBorrowStatus("reject"),
+			CreatedAt:   "2021-08-05T10:00:00Z",
+			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Date(2021, time.August, 6, 0, 0, 0, 0, time.UTC)},
+			ConfirmedBy: sql.NullString{Valid: true, String: "Admin"},
+			AdminNotes:  sql.NullString{Valid: true, String: "Stok habis"},
+			Tool:        types.Tool{Name: "Osiloskop", Kind: types.ToolKindAsset},
+		},
+		{
+			ID:        1,
+			Amount:    3,
+			Status:    types.GetBorrowStatus("request"),
+			CreatedAt: "2021-08-03 09:00:00",
+			Tool:      types.Tool{Name: "Timah", Kind: types.ToolKindConsumable},
+		},
+	}
+
+	r := BuildBorrowHistoryMessage(i18n.NewPrinter(types.LanguageIndonesian), "", borrows)
+
+	expected := "\nMenunggu tanggapan\n" +
+		"• [1] Timah - 3 buah\n" +
+		"   Diajukan 3 Agustus 2021\n" +
+		"\nDitolak\n" +
+		"• [2] Osiloskop - 1 buah, 7 hari\n" +
+		"   Diajukan 5 Agustus 2021\n" +
+		"   Ditanggapi 6 Agustus 2021 oleh Admin\n" +
+		"   Catatan admin: Stok habis\n"
+
+	assert.Equal(t, expected, r)
 }
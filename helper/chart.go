@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/chart"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// ReportPeriodMonths is the first day of every month the period touches.
+func ReportPeriodMonths(period types.ReportPeriod) []time.Time {
+	months := []time.Time{}
+	month := time.Date(period.From.Year(), period.From.Month(), 1, 0, 0, 0, 0, period.From.Location())
+	for month.Before(period.To) {
+		months = append(months, month)
+		month = month.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// IsMultiMonthPeriod tells whether the period is long enough for a monthly
+// chart to show a trend.
+func IsMultiMonthPeriod(period types.ReportPeriod) bool {
+	return len(ReportPeriodMonths(period)) > 1
+}
+
+// FillMonthlyUsage puts the counts in every month of the period, leaving the
+// months without borrows at zero.
+func FillMonthlyUsage(period types.ReportPeriod, usages []types.MonthlyUsage) []types.MonthlyUsage {
+	result := []types.MonthlyUsage{}
+	for _, month := range ReportPeriodMonths(period) {
+		usage := types.MonthlyUsage{Month: month}
+		for _, u := range usages {
+			if u.Month.Year() == month.Year() && u.Month.Month() == month.Month() {
+				usage.Borrows = u.Borrows
+				usage.Overdue = u.Overdue
+			}
+		}
+		result = append(result, usage)
+	}
+	return result
+}
+
+// monthLabel shortens the month for the x axis, e.g. "Agu 21".
+func monthLabel(p i18n.Printer, month time.Time) string {
+	name := []rune(p.Month(int(month.Month())))
+	if len(name) > 3 {
+		name = name[:3]
+	}
+	return fmt.Sprintf("%s %02d", string(name), month.Year()%100)
+}
+
+func BuildMonthlyBorrowChart(p i18n.Printer, usages []types.MonthlyUsage) chart.Chart {
+	c := chart.Chart{Kind: chart.KindBar, Title: p.Text("chart.monthly_borrows")}
+	for _, usage := range usages {
+		c.Labels = append(c.Labels, monthLabel(p, usage.Month))
+		c.Values = append(c.Values, float64(usage.Borrows))
+	}
+	return c
+}
+
+func BuildMonthlyOverdueChart(p i18n.Printer, usages []types.MonthlyUsage) chart.Chart {
+	c := chart.Chart{Kind: chart.KindLine, Title: p.Text("chart.monthly_overdue")}
+	for _, usage := range usages {
+		c.Labels = append(c.Labels, monthLabel(p, usage.Month))
+		c.Values = append(c.Values, float64(usage.Overdue))
+	}
+	return c
+}
+
+func BuildUtilizationChart(p i18n.Printer, utilizations []types.ToolUtilization) chart.Chart {
+	c := chart.Chart{Kind: chart.KindBar, Title: p.Text("chart.utilization"), Unit: "%"}
+	for _, utilization := range utilizations {
+		c.Labels = append(c.Labels, utilization.Tool.Name)
+		c.Values = append(c.Values, utilization.Rate()*100)
+	}
+	return c
+}
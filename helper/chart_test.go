@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/chart"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPeriodMonths(t *testing.T) {
+	period, _ := GetReportPeriodFromCommand("2021-11-15..2022-01-10")
+
+	r := ReportPeriodMonths(period)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2021, time.November, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2021, time.December, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2022, time.January, 1, 0, 0, 0, 0, time.Local),
+	}, r)
+	assert.True(t, IsMultiMonthPeriod(period))
+	assert.False(t, IsMultiMonthPeriod(MonthReportPeriod(2021, 8)))
+}
+
+func TestFillMonthlyUsage(t *testing.T) {
+	period := SemesterReportPeriod(2021, 1)
+	usages := []types.MonthlyUsage{
+		{Month: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), Borrows: 5, Overdue: 1},
+	}
+
+	r := FillMonthlyUsage(period, usages)
+
+	assert.Len(t, r, 6)
+	assert.Equal(t, 0, r[1].Borrows)
+	assert.Equal(t, 5, r[2].Borrows)
+	assert.Equal(t, 1, r[2].Overdue)
+}
+
+func TestBuildMonthlyCharts(t *testing.T) {
+	p := i18n.NewPrinter(types.LanguageIndonesian)
+	usages := []types.MonthlyUsage{
+		{Month: time.Date(2021, time.August, 1, 0, 0, 0, 0, time.Local), Borrows: 5, Overdue: 1},
+		{Month: time.Date(2021, time.September, 1, 0, 0, 0, 0, time.Local), Borrows: 3},
+	}
+
+	borrows := BuildMonthlyBorrowChart(p, usages)
+	assert.Equal(t, chart.KindBar, borrows.Kind)
+	assert.Equal(t, []string{"Agu 21", "Sep 21"}, borrows.Labels)
+	assert.Equal(t, []float64{5, 3}, borrows.Values)
+
+	overdue := BuildMonthlyOverdueChart(p, usages)
+	assert.Equal(t, chart.KindLine, overdue.Kind)
+	assert.Equal(t, []float64{1, 0}, overdue.Values)
+}
+
+func TestBuildUtilizationChart(t *testing.T) {
+	r := BuildUtilizationChart(i18n.NewPrinter(types.LanguageEnglish), []types.ToolUtilization{
+		{Tool: types.Tool{Name: "Osiloskop"}, BorrowedDays: 31, AvailableDays: 62},
+	})
+
+	assert.Equal(t, "Tool Utilisation", r.Title)
+	assert.Equal(t, "%", r.Unit)
+	assert.Equal(t, []string{"Osiloskop"}, r.Labels)
+	assert.Equal(t, []float64{50}, r.Values)
+}
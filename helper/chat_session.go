@@ -24,12 +24,55 @@ func NewSessionDataGenerator() SessionDataContainer {
 	}
 }
 
+func (sdc SessionDataContainer) RegisterName(name string) string {
+	sdc.container.Set(types.Topic["register_name"], "type")
+	sdc.container.Set(name, "name")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) RegisterNIM(nim string) string {
+	sdc.container.Set(types.Topic["register_nim"], "type")
+	sdc.container.Set(nim, "nim")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) RegisterBatch(batch int) string {
+	sdc.container.Set(types.Topic["register_batch"], "type")
+	sdc.container.Set(batch, "batch")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) RegisterAddress(address string) string {
+	sdc.container.Set(types.Topic["register_address"], "type")
+	sdc.container.Set(address, "address")
+	return sdc.container.String()
+}
+
+// RegisterPhone records the shared phone number, empty when it was skipped.
+func (sdc SessionDataContainer) RegisterPhone(phone string) string {
+	sdc.container.Set(types.Topic["register_phone"], "type")
+	sdc.container.Set(phone, "phone")
+	return sdc.container.String()
+}
+
 func (sdc SessionDataContainer) RegisterComplete(userResponse bool) string {
 	sdc.container.Set(types.Topic["register_complete"], "type")
 	sdc.container.Set(userResponse, "user_response")
 	return sdc.container.String()
 }
 
+func (sdc SessionDataContainer) ProfileInit(field string) string {
+	sdc.container.Set(types.Topic["profile_init"], "type")
+	sdc.container.Set(field, "field")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) ProfileComplete(newData string) string {
+	sdc.container.Set(types.Topic["profile_complete"], "type")
+	sdc.container.Set(newData, "new_data")
+	return sdc.container.String()
+}
+
 func (sdc SessionDataContainer) BorrowInit(toolID int64) string {
 	sdc.container.Set(types.Topic["borrow_init"], "type")
 	sdc.container.Set(toolID, "tool_id")
@@ -78,6 +121,30 @@ func (sdc SessionDataContainer) ToolReturningComplete(userResponse bool) string
 	return sdc.container.String()
 }
 
+func (sdc SessionDataContainer) ConsumeInit(toolID int64) string {
+	sdc.container.Set(types.Topic["consume_init"], "type")
+	sdc.container.Set(toolID, "tool_id")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) ConsumeAmount(amount int) string {
+	sdc.container.Set(types.Topic["consume_amount"], "type")
+	sdc.container.Set(amount, "amount")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) ConsumeReason(reason string) string {
+	sdc.container.Set(types.Topic["consume_reason"], "type")
+	sdc.container.Set(reason, "reason")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) ConsumeConfirmation(userResponse bool) string {
+	sdc.container.Set(types.Topic["consume_confirm"], "type")
+	sdc.container.Set(userResponse, "user_response")
+	return sdc.container.String()
+}
+
 func (sdc SessionDataContainer) RespondBorrowInit(borrowID int64, userResponse string) string {
 	sdc.container.Set(types.Topic["respond_borrow_init"], "type")
 	sdc.container.Set(borrowID, "borrow_id")
@@ -204,3 +271,39 @@ func (sdc SessionDataContainer) ManagePhotoConfirm(userResponse bool) string {
 	sdc.container.Set(userResponse, "user_response")
 	return sdc.container.String()
 }
+
+func (sdc SessionDataContainer) MaintenanceInit(toolID int64) string {
+	sdc.container.Set(types.Topic["maintenance_init"], "type")
+	sdc.container.Set(toolID, "tool_id")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) MaintenanceType(maintenanceType types.MaintenanceType) string {
+	sdc.container.Set(types.Topic["maintenance_type"], "type")
+	sdc.container.Set(maintenanceType, "maintenance_type")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) MaintenanceDate(date string) string {
+	sdc.container.Set(types.Topic["maintenance_date"], "type")
+	sdc.container.Set(date, "performed_at")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) MaintenanceTechnician(technician string) string {
+	sdc.container.Set(types.Topic["maintenance_technician"], "type")
+	sdc.container.Set(technician, "technician")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) MaintenanceNotes(notes string) string {
+	sdc.container.Set(types.Topic["maintenance_notes"], "type")
+	sdc.container.Set(notes, "notes")
+	return sdc.container.String()
+}
+
+func (sdc SessionDataContainer) MaintenanceConfirm(userResponse bool) string {
+	sdc.container.Set(types.Topic["maintenance_confirm"], "type")
+	sdc.container.Set(userResponse, "user_response")
+	return sdc.container.String()
+}
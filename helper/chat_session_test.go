@@ -42,6 +42,38 @@ func TestCanGetChatSessionDetailByTopic(t *testing.T) {
 	})
 }
 
+func TestSessionGeneratorRegister(t *testing.T) {
+	t.Run("name", func(t *testing.T) {
+		r := NewSessionDataGenerator().RegisterName("Fanny Hasbi")
+		expected := fmt.Sprintf(`{"type":"%s","name":"Fanny Hasbi"}`, string(types.Topic["register_name"]))
+		assert.JSONEq(t, expected, r)
+	})
+
+	t.Run("nim", func(t *testing.T) {
+		r := NewSessionDataGenerator().RegisterNIM("21120117130000")
+		expected := fmt.Sprintf(`{"type":"%s","nim":"21120117130000"}`, string(types.Topic["register_nim"]))
+		assert.JSONEq(t, expected, r)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		r := NewSessionDataGenerator().RegisterBatch(2017)
+		expected := fmt.Sprintf(`{"type":"%s","batch":2017}`, string(types.Topic["register_batch"]))
+		assert.JSONEq(t, expected, r)
+	})
+
+	t.Run("address", func(t *testing.T) {
+		r := NewSessionDataGenerator().RegisterAddress("Semarang")
+		expected := fmt.Sprintf(`{"type":"%s","address":"Semarang"}`, string(types.Topic["register_address"]))
+		assert.JSONEq(t, expected, r)
+	})
+
+	t.Run("phone", func(t *testing.T) {
+		r := NewSessionDataGenerator().RegisterPhone("")
+		expected := fmt.Sprintf(`{"type":"%s","phone":""}`, string(types.Topic["register_phone"]))
+		assert.JSONEq(t, expected, r)
+	})
+}
+
 func TestSessionGeneratorRegisterComplete(t *testing.T) {
 	resp := true
 	gen := NewSessionDataGenerator()
@@ -52,6 +84,23 @@ func TestSessionGeneratorRegisterComplete(t *testing.T) {
 	assert.JSONEq(t, expected, r)
 }
 
+func TestSessionGeneratorProfile(t *testing.T) {
+	t.Run("init", func(t *testing.T) {
+		field := string(types.UserFieldAddress)
+		gen := NewSessionDataGenerator()
+		r := gen.ProfileInit(field)
+		expected := fmt.Sprintf(`{"type":"%s","field":"%s"}`, types.Topic["profile_init"], field)
+		assert.JSONEq(t, expected, r)
+	})
+	t.Run("complete", func(t *testing.T) {
+		newData := "Jl. Prof. Soedarto"
+		gen := NewSessionDataGenerator()
+		r := gen.ProfileComplete(newData)
+		expected := fmt.Sprintf(`{"type":"%s","new_data":"%s"}`, types.Topic["profile_complete"], newData)
+		assert.JSONEq(t, expected, r)
+	})
+}
+
 func TestSessionGeneratorBorrowInit(t *testing.T) {
 	var id int64 = 123
 	gen := NewSessionDataGenerator()
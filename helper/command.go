@@ -61,7 +61,7 @@ func GetRespondCommandOrder(s string) (types.RespondCommandOrder, bool) {
 }
 
 func isRespondTypeExists(c types.RespondType) bool {
-	if c == types.RespondTypeBorrow || c == types.RespondTypeToolReturning {
+	if c == types.RespondTypeBorrow || c == types.RespondTypeToolReturning || c == types.RespondTypeNIMCollision || c == types.RespondTypeRegistration || c == types.RespondTypeAdminRequest {
 		return true
 	}
 	return false
@@ -75,7 +75,14 @@ func isManageTypeExists(c types.ManageType) bool {
 }
 
 func isReportTypeExists(c types.ReportType) bool {
-	if c == types.ReportTypeBorrow || c == types.ReportTypeToolReturning {
+	if c == types.ReportTypeBorrow || c == types.ReportTypeToolReturning || c == types.ReportTypeConsumption {
+		return true
+	}
+	return false
+}
+
+func isExportFormatExists(f types.ExportFormat) bool {
+	if f == types.ExportFormatCSV || f == types.ExportFormatXLSX {
 		return true
 	}
 	return false
@@ -113,6 +120,21 @@ func GetManageCommandOrder(s string) (types.ManageCommandOrder, bool) {
 	return result, true
 }
 
+// GetManageCategoryPath returns the category path of "/kelola kategori [path]",
+// which is empty when only "/kelola kategori" is sent.
+func GetManageCategoryPath(s string) (string, bool) {
+	ss := strings.SplitN(strings.TrimSpace(s), " ", 3)
+	if len(ss) < 2 || types.ManageType(strings.ToLower(ss[1])) != types.ManageTypeCategory {
+		return "", false
+	}
+
+	if len(ss) == 2 {
+		return "", true
+	}
+
+	return strings.TrimSpace(ss[2]), true
+}
+
 func GetCheckCommandOrder(s string) (types.CheckCommandOrder, bool) {
 	ss := strings.Split(s, " ")
 	if len(ss) < 2 || len(ss) > 3 {
@@ -131,9 +153,120 @@ func GetCheckCommandOrder(s string) (types.CheckCommandOrder, bool) {
 	return types.CheckCommandOrder{ID: i, Text: ss[2]}, true
 }
 
+// GetCheckPageCursor reads the page requested by "/cek berikutnya [id]" or
+// "/cek sebelumnya [id]".
+func GetCheckPageCursor(s string) (types.Cursor, bool) {
+	ss := strings.Split(s, " ")
+	if len(ss) != 3 {
+		return types.Cursor{}, false
+	}
+
+	id, err := strconv.ParseInt(ss[2], 10, 64)
+	if err != nil || id < 1 {
+		return types.Cursor{}, false
+	}
+
+	switch strings.ToLower(ss[1]) {
+	case types.CheckTypeNext:
+		return types.Cursor{After: id, Limit: types.ToolPageSize}, true
+	case types.CheckTypePrevious:
+		return types.Cursor{Before: id, Limit: types.ToolPageSize}, true
+	}
+
+	return types.Cursor{}, false
+}
+
+// GetCheckFilterOrder reads "/cek kategori [id]" and "/cek tag [tag]", which may
+// be followed by the page to show, e.g. "/cek tag solder berikutnya 20".
+func GetCheckFilterOrder(s string) (types.CheckFilterOrder, bool) {
+	ss := strings.Fields(s)
+	if len(ss) < 2 {
+		return types.CheckFilterOrder{}, false
+	}
+
+	order := types.CheckFilterOrder{
+		Type:   strings.ToLower(ss[1]),
+		Cursor: types.Cursor{Limit: types.ToolPageSize},
+	}
+
+	args := ss[2:]
+	if len(args) > 2 {
+		page := strings.Join(append([]string{"/" + types.CommandCheck}, args[len(args)-2:]...), " ")
+		if cursor, ok := GetCheckPageCursor(page); ok {
+			order.Cursor = cursor
+			args = args[:len(args)-2]
+		}
+	}
+
+	switch order.Type {
+	case types.CheckTypeCategory:
+		if len(args) == 0 {
+			return order, true
+		}
+
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || id < 1 || len(args) > 1 {
+			return types.CheckFilterOrder{}, false
+		}
+		order.Filter.CategoryID = id
+
+	case types.CheckTypeTag:
+		tag := strings.ToLower(strings.Join(args, " "))
+		if len(tag) == 0 {
+			return types.CheckFilterOrder{}, false
+		}
+		order.Filter.Tag = tag
+
+	default:
+		return types.CheckFilterOrder{}, false
+	}
+
+	return order, true
+}
+
+// GetCheckKeyword returns the keyword of a "/cek [kata kunci]" search. Commands
+// opening a tool by its ID are not searches.
+func GetCheckKeyword(s string) (string, bool) {
+	if _, ok := GetCheckCommandOrder(s); ok {
+		return "", false
+	}
+
+	if _, ok := GetCheckPageCursor(s); ok {
+		return "", false
+	}
+
+	if _, ok := GetCheckFilterOrder(s); ok {
+		return "", false
+	}
+
+	ss := strings.SplitN(s, " ", 2)
+	if len(ss) < 2 {
+		return "", false
+	}
+
+	keyword := strings.Join(strings.Fields(ss[1]), " ")
+	return keyword, len(keyword) > 0
+}
+
+// GetStartBorrowToolID returns the tool ID of a "/start pinjam_[id]" command,
+// sent when the user opens the borrow deep link of an inline query result.
+func GetStartBorrowToolID(s string) (int64, bool) {
+	ss := strings.Fields(s)
+	if len(ss) != 2 || !strings.HasPrefix(ss[1], types.StartPayloadBorrow) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(ss[1], types.StartPayloadBorrow), 10, 64)
+	if err != nil || id < 1 {
+		return 0, false
+	}
+
+	return id, true
+}
+
 func GetReportCommandOrder(s string) (types.ReportCommandOrder, bool) {
 	ss := strings.Split(s, " ")
-	if len(ss) < 2 || len(ss) > 3 {
+	if len(ss) < 2 || len(ss) > 4 {
 		return types.ReportCommandOrder{}, false
 	}
 
@@ -147,5 +280,14 @@ func GetReportCommandOrder(s string) (types.ReportCommandOrder, bool) {
 		return types.ReportCommandOrder{Type: reportType}, true
 	}
 
-	return types.ReportCommandOrder{Type: reportType, Text: ss[2]}, true
+	if len(ss) == 3 {
+		return types.ReportCommandOrder{Type: reportType, Text: ss[2]}, true
+	}
+
+	exportFormat := types.ExportFormat(strings.ToLower(ss[3]))
+	if isExist := isExportFormatExists(exportFormat); !isExist {
+		return types.ReportCommandOrder{}, false
+	}
+
+	return types.ReportCommandOrder{Type: reportType, Text: ss[2], Format: exportFormat}, true
 }
@@ -120,6 +120,9 @@ func TestIsRespondTypeExists(t *testing.T) {
 	t.Run("exist", func(t *testing.T) {
 		r := isRespondTypeExists(types.RespondTypeBorrow)
 		assert.True(t, r)
+		assert.True(t, isRespondTypeExists(types.RespondTypeNIMCollision))
+		assert.True(t, isRespondTypeExists(types.RespondTypeRegistration))
+		assert.True(t, isRespondTypeExists(types.RespondTypeAdminRequest))
 	})
 	t.Run("nope", func(t *testing.T) {
 		r := isRespondTypeExists("testdoesnotexists")
@@ -259,6 +262,126 @@ func TestGetCheckCommandOrder(t *testing.T) {
 	})
 }
 
+func TestGetCheckPageCursor(t *testing.T) {
+	t.Run("next page", func(t *testing.T) {
+		r, ok := GetCheckPageCursor(fmt.Sprintf("/%s %s 20", types.CommandCheck, types.CheckTypeNext))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.Cursor{After: 20, Limit: types.ToolPageSize}, r)
+	})
+
+	t.Run("previous page", func(t *testing.T) {
+		r, ok := GetCheckPageCursor(fmt.Sprintf("/%s %s 21", types.CommandCheck, types.CheckTypePrevious))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.Cursor{Before: 21, Limit: types.ToolPageSize}, r)
+	})
+
+	t.Run("not a page", func(t *testing.T) {
+		_, ok := GetCheckPageCursor(fmt.Sprintf("/%s multimeter digital", types.CommandCheck))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGetCheckKeyword(t *testing.T) {
+	t.Run("keyword", func(t *testing.T) {
+		s := fmt.Sprintf("/%s  multi   meter ", types.CommandCheck)
+		r, ok := GetCheckKeyword(s)
+
+		assert.True(t, ok)
+		assert.Equal(t, "multi meter", r)
+	})
+
+	t.Run("tool id is not a keyword", func(t *testing.T) {
+		s := fmt.Sprintf("/%s %d %s", types.CommandCheck, 123, types.CheckTypePhoto)
+		_, ok := GetCheckKeyword(s)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("without keyword", func(t *testing.T) {
+		_, ok := GetCheckKeyword(fmt.Sprintf("/%s ", types.CommandCheck))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGetCheckFilterOrder(t *testing.T) {
+	t.Run("category menu", func(t *testing.T) {
+		r, ok := GetCheckFilterOrder(fmt.Sprintf("/%s %s", types.CommandCheck, types.CheckTypeCategory))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.CheckFilterOrder{Type: types.CheckTypeCategory, Cursor: types.Cursor{Limit: types.ToolPageSize}}, r)
+	})
+
+	t.Run("category page", func(t *testing.T) {
+		r, ok := GetCheckFilterOrder(fmt.Sprintf("/%s %s 3 %s 40", types.CommandCheck, types.CheckTypeCategory, types.CheckTypeNext))
+
+		expected := types.CheckFilterOrder{
+			Type:   types.CheckTypeCategory,
+			Filter: types.ToolFilter{CategoryID: 3},
+			Cursor: types.Cursor{After: 40, Limit: types.ToolPageSize},
+		}
+
+		assert.True(t, ok)
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("tag with spaces", func(t *testing.T) {
+		r, ok := GetCheckFilterOrder(fmt.Sprintf("/%s %s Alat Ukur", types.CommandCheck, types.CheckTypeTag))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.ToolFilter{Tag: "alat ukur"}, r.Filter)
+	})
+
+	t.Run("invalid category", func(t *testing.T) {
+		_, ok := GetCheckFilterOrder(fmt.Sprintf("/%s %s abc", types.CommandCheck, types.CheckTypeCategory))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("not a filter", func(t *testing.T) {
+		_, ok := GetCheckFilterOrder(fmt.Sprintf("/%s multimeter", types.CommandCheck))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGetManageCategoryPath(t *testing.T) {
+	r, ok := GetManageCategoryPath(fmt.Sprintf("/%s %s Elektronika/Alat Ukur", types.CommandManage, types.ManageTypeCategory))
+	assert.True(t, ok)
+	assert.Equal(t, "Elektronika/Alat Ukur", r)
+
+	r, ok = GetManageCategoryPath(fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeCategory))
+	assert.True(t, ok)
+	assert.Equal(t, "", r)
+
+	_, ok = GetManageCategoryPath(fmt.Sprintf("/%s %s 5", types.CommandManage, types.ManageTypeEdit))
+	assert.False(t, ok)
+}
+
+func TestGetStartBorrowToolID(t *testing.T) {
+	t.Run("borrow payload", func(t *testing.T) {
+		r, ok := GetStartBorrowToolID(fmt.Sprintf("/%s %s12", types.CommandStart, types.StartPayloadBorrow))
+
+		assert.True(t, ok)
+		assert.Equal(t, int64(12), r)
+	})
+
+	t.Run("without payload", func(t *testing.T) {
+		_, ok := GetStartBorrowToolID(fmt.Sprintf("/%s", types.CommandStart))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid tool id", func(t *testing.T) {
+		_, ok := GetStartBorrowToolID(fmt.Sprintf("/%s %sabc", types.CommandStart, types.StartPayloadBorrow))
+
+		assert.False(t, ok)
+	})
+}
+
 func TestGetReportCommands(t *testing.T) {
 	t.Run("full value", func(t *testing.T) {
 		s := fmt.Sprintf("/%s %s 2021-07", types.CommandReport, types.ReportTypeBorrow)
@@ -297,7 +420,21 @@ func TestGetReportCommands(t *testing.T) {
 		assert.Equal(t, types.ReportCommandOrder{}, r)
 	})
 
-	t.Run("length exceed 3", func(t *testing.T) {
+	t.Run("with export format", func(t *testing.T) {
+		s := fmt.Sprintf("/%s %s 2021-07 XLSX", types.CommandReport, types.ReportTypeBorrow)
+		r, ok := GetReportCommandOrder(s)
+
+		expected := types.ReportCommandOrder{
+			Type:   types.ReportTypeBorrow,
+			Text:   "2021-07",
+			Format: types.ExportFormatXLSX,
+		}
+
+		assert.True(t, ok)
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("unknown export format", func(t *testing.T) {
 		s := fmt.Sprintf("/%s %s 2021-07 testexceed", types.CommandReport, types.ReportTypeBorrow)
 		r, ok := GetReportCommandOrder(s)
 
@@ -305,6 +442,14 @@ func TestGetReportCommands(t *testing.T) {
 		assert.Equal(t, types.ReportCommandOrder{}, r)
 	})
 
+	t.Run("length exceed 4", func(t *testing.T) {
+		s := fmt.Sprintf("/%s %s 2021-07 csv testexceed", types.CommandReport, types.ReportTypeBorrow)
+		r, ok := GetReportCommandOrder(s)
+
+		assert.False(t, ok)
+		assert.Equal(t, types.ReportCommandOrder{}, r)
+	})
+
 	t.Run("wrong type", func(t *testing.T) {
 		s := fmt.Sprintf("/%s testwrongtype", types.CommandReport)
 		r, ok := GetReportCommandOrder(s)
@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// GetLabCommandOrder parses "/lab", "/lab [id]" and "/lab [nama]".
+func GetLabCommandOrder(s string) types.LabCommandOrder {
+	ss := strings.Fields(s)
+	if len(ss) < 2 {
+		return types.LabCommandOrder{}
+	}
+
+	if id, err := strconv.ParseInt(ss[1], 10, 64); err == nil && len(ss) == 2 {
+		return types.LabCommandOrder{ID: id}
+	}
+
+	return types.LabCommandOrder{Name: strings.Join(ss[1:], " ")}
+}
+
+// FindLab looks the lab up by ID.
+func FindLab(labs []types.Lab, id int64) (types.Lab, bool) {
+	for _, lab := range labs {
+		if lab.ID == id {
+			return lab, true
+		}
+	}
+
+	return types.Lab{}, false
+}
+
+// BuildLabButtons offers a button to choose each lab.
+func BuildLabButtons(labs []types.Lab) [][]types.InlineKeyboardButton {
+	keyboard := [][]types.InlineKeyboardButton{}
+	for _, lab := range labs {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{{
+			Text:         lab.Name,
+			CallbackData: fmt.Sprintf("/%s %d", types.CommandLab, lab.ID),
+		}})
+	}
+	return keyboard
+}
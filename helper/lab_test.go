@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLabCommandOrder(t *testing.T) {
+	assert.Equal(t, types.LabCommandOrder{}, GetLabCommandOrder("/lab"))
+	assert.Equal(t, types.LabCommandOrder{ID: 2}, GetLabCommandOrder("/lab 2"))
+	assert.Equal(t, types.LabCommandOrder{Name: "Laboratorium Elektronika"}, GetLabCommandOrder("/lab  Laboratorium   Elektronika"))
+	assert.Equal(t, types.LabCommandOrder{Name: "Lab 2"}, GetLabCommandOrder("/lab Lab 2"))
+}
+
+func TestFindLab(t *testing.T) {
+	labs := []types.Lab{{ID: 1, Name: "Elektronika"}, {ID: 2, Name: "Kimia"}}
+
+	lab, ok := FindLab(labs, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "Kimia", lab.Name)
+
+	_, ok = FindLab(labs, 3)
+	assert.False(t, ok)
+}
+
+func TestBuildLabButtons(t *testing.T) {
+	labs := []types.Lab{{ID: 1, Name: "Elektronika"}, {ID: 2, Name: "Kimia"}}
+
+	assert.Equal(t, [][]types.InlineKeyboardButton{
+		{{Text: "Elektronika", CallbackData: "/lab 1"}},
+		{{Text: "Kimia", CallbackData: "/lab 2"}},
+	}, BuildLabButtons(labs))
+}
@@ -0,0 +1,134 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// MaintenanceDateLayout is the layout of the maintenance date typed by admins.
+const MaintenanceDateLayout = "2006-01-02"
+
+func GetMaintenanceFromChatSessionDetails(details []types.ChatSessionDetail) types.Maintenance {
+	var maintenance types.Maintenance
+
+	for _, detail := range details {
+		dataParsed, err := gabs.ParseJSON([]byte(detail.Data))
+		if err != nil {
+			return maintenance
+		}
+
+		switch detail.Topic {
+		case types.Topic["maintenance_init"]:
+			toolID, _ := dataParsed.Path("tool_id").Data().(float64)
+			maintenance.ToolID = int64(toolID)
+		case types.Topic["maintenance_type"]:
+			maintenanceType, _ := dataParsed.Path("maintenance_type").Data().(string)
+			maintenance.Type = types.MaintenanceType(maintenanceType)
+		case types.Topic["maintenance_date"]:
+			maintenance.PerformedAt, _ = dataParsed.Path("performed_at").Data().(string)
+		case types.Topic["maintenance_technician"]:
+			maintenance.Technician, _ = dataParsed.Path("technician").Data().(string)
+		case types.Topic["maintenance_notes"]:
+			maintenance.Notes, _ = dataParsed.Path("notes").Data().(string)
+		}
+	}
+
+	return maintenance
+}
+
+func IsMaintenanceTypeExists(s string) bool {
+	for _, t := range types.MaintenanceTypes() {
+		if types.MaintenanceType(s) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMaintenanceDate reads a date such as "2021-08-03". Maintenance cannot be
+// recorded for a day after today.
+func ParseMaintenanceDate(s string, now time.Time) (time.Time, bool) {
+	date, err := time.ParseInLocation(MaintenanceDateLayout, strings.TrimSpace(s), now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if date.After(today) {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+// GetMaintenanceCommandOrder reads "/perawatan [id] [catat|interval [hari]|rusak|aktif]".
+func GetMaintenanceCommandOrder(s string) (types.MaintenanceCommandOrder, bool) {
+	ss := strings.Fields(s)
+	if len(ss) < 2 || len(ss) > 4 {
+		return types.MaintenanceCommandOrder{}, false
+	}
+
+	id, err := strconv.ParseInt(ss[1], 10, 64)
+	if err != nil || id < 1 {
+		return types.MaintenanceCommandOrder{}, false
+	}
+
+	order := types.MaintenanceCommandOrder{ToolID: id}
+	if len(ss) == 2 {
+		return order, true
+	}
+
+	order.Type = strings.ToLower(ss[2])
+	switch order.Type {
+	case types.MaintenanceCommandInterval:
+		if len(ss) == 4 {
+			order.Text = ss[3]
+		}
+		return order, true
+	case types.MaintenanceCommandRecord, types.MaintenanceCommandBroken, types.MaintenanceCommandActive:
+		return order, len(ss) == 3
+	}
+
+	return types.MaintenanceCommandOrder{}, false
+}
+
+// ParseCalibrationInterval reads the number of days between calibrations. Zero
+// means the tool needs no calibration.
+func ParseCalibrationInterval(s string) (int, bool) {
+	days, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || days < 0 {
+		return 0, false
+	}
+	return days, true
+}
+
+func BuildCalibrationDueMessage(p i18n.Printer, mode format.Mode, dues []types.CalibrationDue) string {
+	b := format.New(mode)
+	for _, due := range dues {
+		if !due.LastCalibratedAt.Valid {
+			b.Item(p.Text("maintenance.calibration_never", due.Tool.ID, due.Tool.Name))
+			continue
+		}
+		b.Item(p.Text("maintenance.calibration_line", due.Tool.ID, due.Tool.Name, p.Date(due.DueAt())))
+	}
+	return b.String()
+}
+
+func BuildMaintenanceHistoryMessage(p i18n.Printer, mode format.Mode, maintenances []types.Maintenance) string {
+	b := format.New(mode)
+	for _, m := range maintenances {
+		line := p.Text("maintenance.history.line", p.DateString(m.PerformedAt), p.Text(fmt.Sprintf("maintenance.type.%s", m.Type)), m.Technician)
+		if len(m.Notes) > 0 {
+			line = fmt.Sprintf("%s (%s)", line, m.Notes)
+		}
+		b.Item(line)
+	}
+	return b.String()
+}
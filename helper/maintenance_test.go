@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMaintenanceFromChatSessionDetails(t *testing.T) {
+	details := []types.ChatSessionDetail{
+		{
+			Topic: types.Topic["maintenance_init"],
+			Data:  NewSessionDataGenerator().MaintenanceInit(5),
+		},
+		{
+			Topic: types.Topic["maintenance_type"],
+			Data:  NewSessionDataGenerator().MaintenanceType(types.MaintenanceTypeCalibration),
+		},
+		{
+			Topic: types.Topic["maintenance_date"],
+			Data:  NewSessionDataGenerator().MaintenanceDate("2021-08-03"),
+		},
+		{
+			Topic: types.Topic["maintenance_technician"],
+			Data:  NewSessionDataGenerator().MaintenanceTechnician("Budi"),
+		},
+		{
+			Topic: types.Topic["maintenance_notes"],
+			Data:  NewSessionDataGenerator().MaintenanceNotes("sesuai standar"),
+		},
+	}
+
+	expected := types.Maintenance{
+		ToolID:      5,
+		Type:        types.MaintenanceTypeCalibration,
+		PerformedAt: "2021-08-03",
+		Technician:  "Budi",
+		Notes:       "sesuai standar",
+	}
+
+	assert.Equal(t, expected, GetMaintenanceFromChatSessionDetails(details))
+}
+
+func TestIsMaintenanceTypeExists(t *testing.T) {
+	assert.True(t, IsMaintenanceTypeExists(string(types.MaintenanceTypeRepair)))
+	assert.False(t, IsMaintenanceTypeExists("testincorrecttype"))
+}
+
+func TestParseMaintenanceDate(t *testing.T) {
+	now := time.Date(2021, time.August, 10, 15, 0, 0, 0, time.UTC)
+
+	t.Run("today", func(t *testing.T) {
+		r, ok := ParseMaintenanceDate("2021-08-10", now)
+
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2021, time.August, 10, 0, 0, 0, 0, time.UTC), r)
+	})
+
+	t.Run("future date", func(t *testing.T) {
+		_, ok := ParseMaintenanceDate("2021-08-11", now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		_, ok := ParseMaintenanceDate("10/08/2021", now)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGetMaintenanceCommandOrder(t *testing.T) {
+	t.Run("history", func(t *testing.T) {
+		r, ok := GetMaintenanceCommandOrder(fmt.Sprintf("/%s 5", types.CommandMaintenance))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.MaintenanceCommandOrder{ToolID: 5}, r)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		r, ok := GetMaintenanceCommandOrder(fmt.Sprintf("/%s 5 %s 180", types.CommandMaintenance, types.MaintenanceCommandInterval))
+
+		expected := types.MaintenanceCommandOrder{ToolID: 5, Type: types.MaintenanceCommandInterval, Text: "180"}
+
+		assert.True(t, ok)
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("out of service", func(t *testing.T) {
+		r, ok := GetMaintenanceCommandOrder(fmt.Sprintf("/%s 5 %s", types.CommandMaintenance, types.MaintenanceCommandBroken))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.MaintenanceCommandBroken, r.Type)
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		_, ok := GetMaintenanceCommandOrder(fmt.Sprintf("/%s 5 hapus", types.CommandMaintenance))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("without tool", func(t *testing.T) {
+		_, ok := GetMaintenanceCommandOrder(fmt.Sprintf("/%s", types.CommandMaintenance))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestParseCalibrationInterval(t *testing.T) {
+	r, ok := ParseCalibrationInterval(" 180 ")
+	assert.True(t, ok)
+	assert.Equal(t, 180, r)
+
+	_, ok = ParseCalibrationInterval("-1")
+	assert.False(t, ok)
+}
+
+func TestBuildCalibrationDueMessage(t *testing.T) {
+	dues := []types.CalibrationDue{
+		{
+			Tool: types.Tool{ID: 1, Name: "Osiloskop", CalibrationIntervalDays: 30},
+			LastCalibratedAt: sql.NullTime{
+				Valid: true,
+				Time:  time.Date(2021, time.July, 10, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Tool: types.Tool{ID: 2, Name: "Multimeter", CalibrationIntervalDays: 180},
+		},
+	}
+
+	r := BuildCalibrationDueMessage(i18n.NewPrinter(types.LanguageIndonesian), format.MarkdownV2, dues)
+
+	expected := "• \\[1\\] Osiloskop \\- jatuh tempo 9 Agustus 2021\n" +
+		"• \\[2\\] Multimeter \\- belum pernah dikalibrasi\n"
+
+	assert.Equal(t, expected, r)
+}
+
+func TestBuildMaintenanceHistoryMessage(t *testing.T) {
+	maintenances := []types.Maintenance{
+		{Type: types.MaintenanceTypeRepair, PerformedAt: "2021-08-03T00:00:00Z", Technician: "Budi", Notes: "ganti probe"},
+		{Type: types.MaintenanceTypeCalibration, PerformedAt: "2021-07-10T00:00:00Z", Technician: "Andi"},
+	}
+
+	r := BuildMaintenanceHistoryMessage(i18n.NewPrinter(types.LanguageEnglish), format.MarkdownV2, maintenances)
+
+	expected := "• 3 August 2021 \\- Repair by Budi \\(ganti probe\\)\n" +
+		"• 10 July 2021 \\- Calibration by Andi\n"
+
+	assert.Equal(t, expected, r)
+}
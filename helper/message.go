@@ -5,7 +5,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 )
 
@@ -18,24 +22,201 @@ func RemoveTab(s string) string {
 }
 
 func BuildMessageRequest(data *types.MessageRequest) {
-	if len(data.ReplyMarkup.InlineKeyboard) == 0 {
+	markup, ok := data.ReplyMarkup.(types.InlineKeyboardMarkup)
+	if data.ReplyMarkup == nil || ok && len(markup.InlineKeyboard) == 0 {
 		inlineKeyboard := make([][]types.InlineKeyboardButton, 0)
-		data.ReplyMarkup.InlineKeyboard = inlineKeyboard
+		data.ReplyMarkup = types.InlineKeyboardMarkup{InlineKeyboard: inlineKeyboard}
 	}
 }
 
-func BuildToolListMessage(l []types.Tool) string {
+func BuildToolListMessage(p i18n.Printer, l []types.Tool) string {
 	m := ""
 	for _, t := range l {
 		m = fmt.Sprintf("%s[%d] %s", m, t.ID, t.Name)
 		if t.Stock < 1 {
-			m += " (stok kosong)"
+			m += p.Text("tool.out_of_stock")
 		}
 		m += "\n"
 	}
 	return m
 }
 
+// BuildToolButtons builds one button per tool opening its detail.
+func BuildToolButtons(l []types.Tool) [][]types.InlineKeyboardButton {
+	keyboard := [][]types.InlineKeyboardButton{}
+	for _, t := range l {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("[%d] %s", t.ID, t.Name),
+				CallbackData: fmt.Sprintf("/%s %d", types.CommandCheck, t.ID),
+			},
+		})
+	}
+	return keyboard
+}
+
+// BuildCategoryButtons builds one button per category opening the tools of it.
+func BuildCategoryButtons(categories []types.ToolCategory) [][]types.InlineKeyboardButton {
+	keyboard := [][]types.InlineKeyboardButton{}
+	for _, c := range categories {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         c.Name,
+				CallbackData: fmt.Sprintf("/%s %s %d", types.CommandCheck, types.CheckTypeCategory, c.ID),
+			},
+		})
+	}
+	return keyboard
+}
+
+// BuildPageButtons builds the buttons moving to the previous and the next page
+// of tools listed by the command, e.g. "/cek" or "/cek kategori 3".
+func BuildPageButtons(command string, page types.ToolPage, previousText, nextText string) []types.InlineKeyboardButton {
+	buttons := []types.InlineKeyboardButton{}
+	if len(page.Tools) == 0 {
+		return buttons
+	}
+
+	if page.HasPrevious {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         previousText,
+			CallbackData: fmt.Sprintf("%s %s %d", command, types.CheckTypePrevious, page.Tools[0].ID),
+		})
+	}
+
+	if page.HasNext {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         nextText,
+			CallbackData: fmt.Sprintf("%s %s %d", command, types.CheckTypeNext, page.Tools[len(page.Tools)-1].ID),
+		})
+	}
+
+	return buttons
+}
+
+// BuildToolArticle builds the inline query result of a tool. The message sent
+// when it is chosen shows the tool and a button to borrow it in private chat.
+// The lab is named when the results come from several labs.
+func BuildToolArticle(p i18n.Printer, tool types.Tool, labName, borrowLink string) types.InlineQueryResultArticle {
+	description := p.Text("inline.description", tool.Brand, tool.ProductType, tool.Stock)
+
+	b := format.New(format.MarkdownV2)
+	b.Title(tool.Name)
+	if len(labName) > 0 {
+		b.Field(p.Text("tool_field.lab"), labName)
+		description = labName + " · " + description
+	}
+	b.Field(p.Text("tool_field.brand"), tool.Brand)
+	b.Field(p.Text("tool_field.tipe"), tool.ProductType)
+	b.Field(p.Text("tool_field.stok"), strconv.FormatInt(tool.Stock, 10))
+
+	return types.InlineQueryResultArticle{
+		Type:        "article",
+		ID:          strconv.FormatInt(tool.ID, 10),
+		Title:       tool.Name,
+		Description: description,
+		InputMessageContent: types.InputTextMessageContent{
+			MessageText: b.String(),
+			ParseMode:   string(b.Mode()),
+		},
+		ReplyMarkup: &types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{{Text: p.Text("button.borrow"), URL: borrowLink}},
+			},
+		},
+	}
+}
+
+// GetInlineQueryCursor reads the offset of an inline query, which is the ID of
+// the last tool answered before.
+func GetInlineQueryCursor(offset string) types.Cursor {
+	cursor := types.Cursor{Limit: types.InlineQueryPageSize}
+
+	id, err := strconv.ParseInt(offset, 10, 64)
+	if err == nil && id > 0 {
+		cursor.After = id
+	}
+
+	return cursor
+}
+
+// SplitMessageText splits a text longer than limit into texts that fit in one
+// message. Texts are split between lines, and only lines longer than the limit
+// are cut. The length is counted in UTF-16 code units as Telegram does.
+func SplitMessageText(text string, limit int) []string {
+	if textLength(text) <= limit {
+		return []string{text}
+	}
+
+	var texts []string
+	var current strings.Builder
+	currentLength := 0
+
+	flush := func() {
+		if currentLength > 0 {
+			texts = append(texts, current.String())
+			current.Reset()
+			currentLength = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		length := textLength(line)
+		if currentLength+length > limit {
+			flush()
+		}
+
+		for length > limit {
+			var part string
+			part, line = cutText(line, limit)
+			texts = append(texts, part)
+			length = textLength(line)
+		}
+
+		current.WriteString(line)
+		currentLength += length
+	}
+	flush()
+
+	return texts
+}
+
+func textLength(s string) int {
+	length := 0
+	for _, r := range s {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
+
+// cutText cuts s after at most limit UTF-16 code units. An unpaired trailing
+// backslash is moved to the rest so that a MarkdownV2 escape is never cut in half.
+func cutText(s string, limit int) (string, string) {
+	length := 0
+	end := len(s)
+	for i, r := range s {
+		if length+utf16.RuneLen(r) > limit {
+			end = i
+			break
+		}
+		length += utf16.RuneLen(r)
+	}
+
+	if end == 0 {
+		_, end = utf8.DecodeRuneInString(s)
+	}
+
+	backslashes := 0
+	for i := end - 1; i >= 0 && s[i] == '\\'; i-- {
+		backslashes++
+	}
+	if backslashes%2 == 1 && end > 1 {
+		end--
+	}
+
+	return s[:end], s[end:]
+}
+
 func GetAdminGroupID() int64 {
 	adminGroupID := os.Getenv("ADMIN_GROUP_ID")
 	i, err := strconv.ParseInt(adminGroupID, 10, 64)
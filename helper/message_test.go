@@ -2,10 +2,13 @@ package helper
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,16 +40,15 @@ func TestCanBuildMessageRequest(t *testing.T) {
 			Text:   text,
 		}
 
-		assert.Nil(t, req.ReplyMarkup.InlineKeyboard)
-		assert.Equal(t, 0, len(req.ReplyMarkup.InlineKeyboard))
-		assert.Equal(t, 0, cap(req.ReplyMarkup.InlineKeyboard))
+		assert.Nil(t, req.ReplyMarkup)
 		assert.Empty(t, req.ParseMode)
 
 		BuildMessageRequest(&req)
 
-		assert.NotNil(t, req.ReplyMarkup.InlineKeyboard)
-		assert.Equal(t, 0, len(req.ReplyMarkup.InlineKeyboard))
-		assert.Equal(t, 0, cap(req.ReplyMarkup.InlineKeyboard))
+		markup := req.ReplyMarkup.(types.InlineKeyboardMarkup)
+		assert.NotNil(t, markup.InlineKeyboard)
+		assert.Equal(t, 0, len(markup.InlineKeyboard))
+		assert.Equal(t, 0, cap(markup.InlineKeyboard))
 		assert.Equal(t, id, req.ChatID)
 		assert.Equal(t, text, req.Text)
 		assert.Empty(t, req.ParseMode)
@@ -76,13 +78,25 @@ func TestCanBuildMessageRequest(t *testing.T) {
 			ReplyMarkup: replyMarkup,
 		}
 
-		assert.NotNil(t, req.ReplyMarkup.InlineKeyboard)
+		BuildMessageRequest(&req)
+
+		markup := req.ReplyMarkup.(types.InlineKeyboardMarkup)
+		assert.NotNil(t, markup.InlineKeyboard)
+		assert.Equal(t, len(ikb), len(markup.InlineKeyboard))
+		assert.Equal(t, len(ikb[0]), len(markup.InlineKeyboard[0]))
+		assert.Equal(t, replyMarkup, req.ReplyMarkup)
+	})
+
+	t.Run("reply keyboard", func(t *testing.T) {
+		replyMarkup := types.ReplyKeyboardRemove{RemoveKeyboard: true}
+		req := types.MessageRequest{
+			ChatID:      id,
+			Text:        text,
+			ReplyMarkup: replyMarkup,
+		}
 
 		BuildMessageRequest(&req)
 
-		assert.NotNil(t, req.ReplyMarkup.InlineKeyboard)
-		assert.Equal(t, len(ikb), len(req.ReplyMarkup.InlineKeyboard))
-		assert.Equal(t, len(ikb[0]), len(req.ReplyMarkup.InlineKeyboard[0]))
 		assert.Equal(t, replyMarkup, req.ReplyMarkup)
 	})
 }
@@ -101,11 +115,145 @@ func TestCanBuildToolListMessage(t *testing.T) {
 		},
 	}
 
-	r := BuildToolListMessage(tools)
+	t.Run("indonesian", func(t *testing.T) {
+		r := BuildToolListMessage(i18n.NewPrinter(types.LanguageIndonesian), tools)
+		expected := fmt.Sprintf("[%d] %s\n[%d] %s (stok kosong)\n", tools[0].ID, tools[0].Name, tools[1].ID, tools[1].Name)
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("english", func(t *testing.T) {
+		r := BuildToolListMessage(i18n.NewPrinter(types.LanguageEnglish), tools)
+		expected := fmt.Sprintf("[%d] %s\n[%d] %s (out of stock)\n", tools[0].ID, tools[0].Name, tools[1].ID, tools[1].Name)
+		assert.Equal(t, expected, r)
+	})
+}
+
+func TestCanBuildToolButtons(t *testing.T) {
+	tools := []types.Tool{
+		{ID: 3, Name: "Multimeter"},
+		{ID: 7, Name: "Osiloskop"},
+	}
+
+	expected := [][]types.InlineKeyboardButton{
+		{{Text: "[3] Multimeter", CallbackData: fmt.Sprintf("/%s 3", types.CommandCheck)}},
+		{{Text: "[7] Osiloskop", CallbackData: fmt.Sprintf("/%s 7", types.CommandCheck)}},
+	}
+
+	assert.Equal(t, expected, BuildToolButtons(tools))
+}
+
+func TestCanBuildCategoryButtons(t *testing.T) {
+	categories := []types.ToolCategory{
+		{ID: 1, Name: "Elektronika"},
+		{ID: 5, Name: "Mekanika"},
+	}
+
+	expected := [][]types.InlineKeyboardButton{
+		{{Text: "Elektronika", CallbackData: fmt.Sprintf("/%s %s 1", types.CommandCheck, types.CheckTypeCategory)}},
+		{{Text: "Mekanika", CallbackData: fmt.Sprintf("/%s %s 5", types.CommandCheck, types.CheckTypeCategory)}},
+	}
+
+	assert.Equal(t, expected, BuildCategoryButtons(categories))
+}
+
+func TestCanBuildPageButtons(t *testing.T) {
+	tools := []types.Tool{{ID: 21}, {ID: 40}}
+
+	t.Run("both directions", func(t *testing.T) {
+		page := types.ToolPage{Tools: tools, HasPrevious: true, HasNext: true}
+
+		expected := []types.InlineKeyboardButton{
+			{Text: "prev", CallbackData: fmt.Sprintf("/%s %s 21", types.CommandCheck, types.CheckTypePrevious)},
+			{Text: "next", CallbackData: fmt.Sprintf("/%s %s 40", types.CommandCheck, types.CheckTypeNext)},
+		}
+
+		assert.Equal(t, expected, BuildPageButtons(fmt.Sprintf("/%s", types.CommandCheck), page, "prev", "next"))
+	})
+
+	t.Run("filtered list", func(t *testing.T) {
+		page := types.ToolPage{Tools: tools, HasNext: true}
+		command := fmt.Sprintf("/%s %s 3", types.CommandCheck, types.CheckTypeCategory)
+
+		expected := []types.InlineKeyboardButton{
+			{Text: "next", CallbackData: fmt.Sprintf("/%s %s 3 %s 40", types.CommandCheck, types.CheckTypeCategory, types.CheckTypeNext)},
+		}
+
+		assert.Equal(t, expected, BuildPageButtons(command, page, "prev", "next"))
+	})
 
-	expected := fmt.Sprintf("[%d] %s\n[%d] %s (stok kosong)\n", tools[0].ID, tools[0].Name, tools[1].ID, tools[1].Name)
+	t.Run("longest tag fits the callback data", func(t *testing.T) {
+		page := types.ToolPage{Tools: []types.Tool{{ID: math.MaxInt64}}, HasPrevious: true}
+		command := fmt.Sprintf("/%s %s %s", types.CommandCheck, types.CheckTypeTag, strings.Repeat("a", types.ToolTagMaxLength))
 
-	assert.Equal(t, expected, r)
+		buttons := BuildPageButtons(command, page, "prev", "next")
+		assert.Len(t, buttons, 1)
+		assert.LessOrEqual(t, len(buttons[0].CallbackData), 64)
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		page := types.ToolPage{Tools: tools}
+
+		assert.Empty(t, BuildPageButtons(fmt.Sprintf("/%s", types.CommandCheck), page, "prev", "next"))
+	})
+}
+
+func TestCanBuildToolArticle(t *testing.T) {
+	tool := types.Tool{ID: 5, Name: "Osiloskop_1", Brand: "Rigol", ProductType: "DS1054Z", Stock: 3}
+
+	r := BuildToolArticle(i18n.NewPrinter(types.LanguageEnglish), tool, "", "https://t.me/bot?start=pinjam_5")
+
+	assert.Equal(t, "article", r.Type)
+	assert.Equal(t, "5", r.ID)
+	assert.Equal(t, "Osiloskop_1", r.Title)
+	assert.Equal(t, "Rigol DS1054Z · 3 in stock", r.Description)
+	assert.Equal(t, "*Osiloskop\\_1*\n\n*Brand:* Rigol\n*Type:* DS1054Z\n*Stock:* 3\n", r.InputMessageContent.MessageText)
+	assert.Equal(t, "MarkdownV2", r.InputMessageContent.ParseMode)
+	assert.Equal(t, [][]types.InlineKeyboardButton{
+		{{Text: "Borrow", URL: "https://t.me/bot?start=pinjam_5"}},
+	}, r.ReplyMarkup.InlineKeyboard)
+
+	t.Run("with lab", func(t *testing.T) {
+		r := BuildToolArticle(i18n.NewPrinter(types.LanguageEnglish), tool, "Lab Elektronika", "https://t.me/bot?start=pinjam_5")
+
+		assert.Equal(t, "Lab Elektronika · Rigol DS1054Z · 3 in stock", r.Description)
+		assert.Contains(t, r.InputMessageContent.MessageText, "Lab Elektronika")
+	})
+}
+
+func TestGetInlineQueryCursor(t *testing.T) {
+	assert.Equal(t, types.Cursor{Limit: types.InlineQueryPageSize}, GetInlineQueryCursor(""))
+	assert.Equal(t, types.Cursor{After: 20, Limit: types.InlineQueryPageSize}, GetInlineQueryCursor("20"))
+	assert.Equal(t, types.Cursor{Limit: types.InlineQueryPageSize}, GetInlineQueryCursor("abc"))
+}
+
+func TestSplitMessageText(t *testing.T) {
+	t.Run("short text", func(t *testing.T) {
+		assert.Equal(t, []string{"abc\ndef"}, SplitMessageText("abc\ndef", 10))
+	})
+
+	t.Run("split between lines", func(t *testing.T) {
+		r := SplitMessageText("aaaa\nbbbb\ncccc\n", 10)
+
+		assert.Equal(t, []string{"aaaa\nbbbb\n", "cccc\n"}, r)
+	})
+
+	t.Run("cut long lines", func(t *testing.T) {
+		r := SplitMessageText("ab\ncdefghijkl", 5)
+
+		assert.Equal(t, []string{"ab\n", "cdefg", "hijkl"}, r)
+	})
+
+	t.Run("keep markdown escapes together", func(t *testing.T) {
+		r := SplitMessageText("abc\\.def", 4)
+
+		assert.Equal(t, []string{"abc", "\\.de", "f"}, r)
+	})
+
+	t.Run("count utf-16 code units", func(t *testing.T) {
+		r := SplitMessageText("😀😀😀", 4)
+
+		assert.Equal(t, []string{"😀😀", "😀"}, r)
+	})
 }
 
 func TestCanGetAdminGroupID(t *testing.T) {
@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// CompileNIMPatterns compiles the NIM patterns. An invalid pattern is left out
+// and reported in the error, the valid ones are still returned.
+func CompileNIMPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var err error
+	result := []*regexp.Regexp{}
+
+	for _, pattern := range patterns {
+		re, compileErr := regexp.Compile(pattern)
+		if compileErr != nil {
+			err = fmt.Errorf("invalid NIM pattern %q: %w", pattern, compileErr)
+			continue
+		}
+		result = append(result, re)
+	}
+
+	return result, err
+}
+
+// ParseNIM reads the faculty, program and year out of the NIM with the first
+// pattern it matches.
+func ParseNIM(nim string, patterns []*regexp.Regexp) (types.NIM, bool) {
+	for _, re := range patterns {
+		match := re.FindStringSubmatch(nim)
+		if match == nil {
+			continue
+		}
+
+		result := types.NIM{}
+		for i, name := range re.SubexpNames() {
+			switch name {
+			case "faculty":
+				result.Faculty = match[i]
+			case "program":
+				result.Program = match[i]
+			case "year":
+				year, ok := nimYear(match[i])
+				if !ok {
+					return types.NIM{}, false
+				}
+				result.Year = year
+			}
+		}
+
+		return result, true
+	}
+
+	return types.NIM{}, false
+}
+
+// nimYear reads a year written with 2 or 4 digits, a 2 digit year is in the
+// 2000s.
+func nimYear(s string) (int, bool) {
+	year, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+
+	switch len(s) {
+	case 2:
+		return 2000 + year, true
+	case 4:
+		return year, true
+	default:
+		return 0, false
+	}
+}
+
+func BuildNIMCollisionListMessage(collisions []types.NIMCollision) string {
+	var message string
+	for _, collision := range collisions {
+		message = fmt.Sprintf("%s[%d] %s - %s\n", message, collision.ID, collision.Name, collision.NIM)
+	}
+	return message
+}
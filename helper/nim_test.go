@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileNIMPatterns(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r, err := CompileNIMPatterns([]string{`^\d{14}$`, `^(?P<year>\d{4})\d{5}$`})
+		assert.NoError(t, err)
+		assert.Len(t, r, 2)
+	})
+
+	t.Run("invalid pattern is left out", func(t *testing.T) {
+		r, err := CompileNIMPatterns([]string{`^(\d{14}$`, `^\d{9}$`})
+		assert.Error(t, err)
+		assert.Len(t, r, 1)
+		assert.Equal(t, `^\d{9}$`, r[0].String())
+	})
+}
+
+func TestParseNIM(t *testing.T) {
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`^(?P<faculty>\d{2})(?P<program>\d{4})(?P<year>\d{2})\d{6}$`),
+		regexp.MustCompile(`^[A-Z]\d{2}\.(?P<year>\d{4})\.\d{5}$`),
+	}
+
+	t.Run("faculty, program and year", func(t *testing.T) {
+		r, ok := ParseNIM("21120117130000", patterns)
+		assert.True(t, ok)
+		assert.Equal(t, types.NIM{Faculty: "21", Program: "1201", Year: 2017}, r)
+	})
+
+	t.Run("second pattern with 4 digit year", func(t *testing.T) {
+		r, ok := ParseNIM("A11.2019.12345", patterns)
+		assert.True(t, ok)
+		assert.Equal(t, types.NIM{Year: 2019}, r)
+	})
+
+	t.Run("no pattern matches", func(t *testing.T) {
+		for _, nim := range []string{"2112xxxxxxxxxx", "123", "211201171300001"} {
+			_, ok := ParseNIM(nim, patterns)
+			assert.False(t, ok, nim)
+		}
+	})
+
+	t.Run("pattern without groups", func(t *testing.T) {
+		r, ok := ParseNIM("123456789", []*regexp.Regexp{regexp.MustCompile(`^\d{9}$`)})
+		assert.True(t, ok)
+		assert.Equal(t, types.NIM{}, r)
+	})
+}
+
+func TestBuildNIMCollisionListMessage(t *testing.T) {
+	collisions := []types.NIMCollision{
+		{ID: 1, Name: "Fanny Hasbi", NIM: "21120117130000"},
+		{ID: 2, Name: "Budi", NIM: "21120118130001"},
+	}
+
+	expected := fmt.Sprintf("[%d] %s - %s\n[%d] %s - %s\n", 1, "Fanny Hasbi", "21120117130000", 2, "Budi", "21120118130001")
+	assert.Equal(t, expected, BuildNIMCollisionListMessage(collisions))
+}
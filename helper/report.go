@@ -0,0 +1,257 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/export"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// reportDateLayout keeps exported dates sortable and readable by spreadsheet
+// applications regardless of the user's language.
+const reportDateLayout = "2006-01-02"
+
+const (
+	// reportRangeLayout also accepts days and months without the leading zero.
+	reportRangeLayout    = "2006-1-2"
+	reportSemesterPrefix = "s"
+)
+
+// reportColumn is a column of an exported report. The columns are defined once
+// so that the CSV and XLSX files of the same report always match.
+type reportColumn struct {
+	header  string
+	numeric bool
+}
+
+var (
+	borrowReportColumns = []reportColumn{
+		{"report.column.id", true},
+		{"report.column.confirmed_at", false},
+		{"report.column.user", false},
+		{"report.column.tool", false},
+		{"report.column.amount", true},
+		{"report.column.duration", true},
+		{"report.column.status", false},
+		{"report.column.confirmed_by", false},
+	}
+
+	toolReturningReportColumns = []reportColumn{
+		{"report.column.id", true},
+		{"report.column.borrow_id", true},
+		{"report.column.confirmed_at", false},
+		{"report.column.user", false},
+		{"report.column.tool", false},
+		{"report.column.amount", true},
+		{"report.column.status", false},
+		{"report.column.confirmed_by", false},
+	}
+
+	consumptionReportColumns = []reportColumn{
+		{"report.column.tool_id", true},
+		{"report.column.tool", false},
+		{"report.column.amount", true},
+		{"report.column.requests", true},
+	}
+)
+
+func buildReportTable(p i18n.Printer, columns []reportColumn, rows [][]string) export.Table {
+	table := export.Table{Rows: rows}
+	for _, column := range columns {
+		table.Columns = append(table.Columns, export.Column{Header: p.Text(column.header), Numeric: column.numeric})
+	}
+	return table
+}
+
+func BuildBorrowReportTable(p i18n.Printer, borrows []types.Borrow) export.Table {
+	rows := [][]string{}
+	for _, borrow := range borrows {
+		rows = append(rows, []string{
+			strconv.FormatInt(borrow.ID, 10),
+			borrow.ConfirmedAt.Time.Format(reportDateLayout),
+			borrow.User.Name,
+			borrow.Tool.Name,
+			strconv.Itoa(borrow.Amount),
+			strconv.Itoa(borrow.Duration),
+			string(borrow.Status),
+			borrow.ConfirmerName(),
+		})
+	}
+	return buildReportTable(p, borrowReportColumns, rows)
+}
+
+func BuildToolReturningReportTable(p i18n.Printer, rets []types.ToolReturning) export.Table {
+	rows := [][]string{}
+	for _, ret := range rets {
+		rows = append(rows, []string{
+			strconv.FormatInt(ret.ID, 10),
+			strconv.FormatInt(ret.BorrowID, 10),
+			ret.ConfirmedAt.Time.Format(reportDateLayout),
+			ret.Borrow.User.Name,
+			ret.Borrow.Tool.Name,
+			strconv.Itoa(ret.Borrow.Amount),
+			string(ret.Status),
+			ret.ConfirmerName(),
+		})
+	}
+	return buildReportTable(p, toolReturningReportColumns, rows)
+}
+
+func BuildConsumptionReportTable(p i18n.Printer, consumptions []types.Consumption) export.Table {
+	rows := [][]string{}
+	for _, consumption := range consumptions {
+		rows = append(rows, []string{
+			strconv.FormatInt(consumption.Tool.ID, 10),
+			consumption.Tool.Name,
+			strconv.Itoa(consumption.Amount),
+			strconv.Itoa(consumption.Requests),
+		})
+	}
+	return buildReportTable(p, consumptionReportColumns, rows)
+}
+
+// ExportReport writes the table in the format and names the file after the
+// report type and its period, e.g. "laporan-pinjam-2021-08.csv".
+func ExportReport(reportType types.ReportType, period types.ReportPeriod, exportFormat types.ExportFormat, table export.Table) (string, []byte, error) {
+	name := reportPeriodFileName(period)
+	fileName := fmt.Sprintf("laporan-%s-%s.%s", reportType, name, exportFormat)
+
+	var content []byte
+	var err error
+	if exportFormat == types.ExportFormatXLSX {
+		content, err = export.XLSX(name, table)
+	} else {
+		content, err = export.CSV(table)
+	}
+
+	return fileName, content, err
+}
+
+func MonthReportPeriod(year, month int) types.ReportPeriod {
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	return types.ReportPeriod{Type: types.ReportPeriodMonth, From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// SemesterReportPeriod is the first (January to June) or the second (July to
+// December) half of the year.
+func SemesterReportPeriod(year, semester int) types.ReportPeriod {
+	from := time.Date(year, time.Month(6*(semester-1)+1), 1, 0, 0, 0, 0, time.Local)
+	return types.ReportPeriod{Type: types.ReportPeriodSemester, From: from, To: from.AddDate(0, 6, 0)}
+}
+
+func YearReportPeriod(year int) types.ReportPeriod {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return types.ReportPeriod{Type: types.ReportPeriodYear, From: from, To: from.AddDate(1, 0, 0)}
+}
+
+// RangeReportPeriod covers both the first and the last day.
+func RangeReportPeriod(first, last time.Time) types.ReportPeriod {
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+	to := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	return types.ReportPeriod{Type: types.ReportPeriodRange, From: from, To: to}
+}
+
+// CurrentSemester is the semester of the date, 1 or 2.
+func CurrentSemester(date time.Time) int {
+	if date.Month() <= time.June {
+		return 1
+	}
+	return 2
+}
+
+// GetReportPeriodFromCommand reads the period of "/laporan [jenis] [periode]",
+// which is a month "2021-8", a semester "2021-s1", a year "2021" or a date
+// range "2021-01-01..2021-06-30".
+func GetReportPeriodFromCommand(s string) (types.ReportPeriod, bool) {
+	s = strings.ToLower(s)
+
+	if strings.Contains(s, types.ReportRangeSeparator) {
+		dates := strings.Split(s, types.ReportRangeSeparator)
+		if len(dates) != 2 {
+			return types.ReportPeriod{}, false
+		}
+
+		first, err := time.Parse(reportRangeLayout, dates[0])
+		if err != nil {
+			return types.ReportPeriod{}, false
+		}
+
+		last, err := time.Parse(reportRangeLayout, dates[1])
+		if err != nil || last.Before(first) {
+			return types.ReportPeriod{}, false
+		}
+
+		return RangeReportPeriod(first, last), true
+	}
+
+	parts := strings.Split(s, "-")
+	year, err := strconv.Atoi(parts[0])
+	if err != nil || year < 1 {
+		return types.ReportPeriod{}, false
+	}
+
+	if len(parts) == 1 {
+		return YearReportPeriod(year), true
+	}
+
+	if len(parts) == 2 && strings.HasPrefix(parts[1], reportSemesterPrefix) {
+		semester, err := strconv.Atoi(strings.TrimPrefix(parts[1], reportSemesterPrefix))
+		if err != nil || semester < 1 || semester > 2 {
+			return types.ReportPeriod{}, false
+		}
+
+		return SemesterReportPeriod(year, semester), true
+	}
+
+	year, month, ok := GetReportTimeFromCommand(s)
+	if !ok || year < 1 {
+		return types.ReportPeriod{}, false
+	}
+
+	return MonthReportPeriod(year, month), true
+}
+
+// ReportPeriodCommand is the period written the way GetReportPeriodFromCommand
+// reads it.
+func ReportPeriodCommand(period types.ReportPeriod) string {
+	switch period.Type {
+	case types.ReportPeriodMonth:
+		return fmt.Sprintf("%d-%d", period.From.Year(), int(period.From.Month()))
+	case types.ReportPeriodSemester:
+		return fmt.Sprintf("%d-%s%d", period.From.Year(), reportSemesterPrefix, CurrentSemester(period.From))
+	case types.ReportPeriodYear:
+		return strconv.Itoa(period.From.Year())
+	}
+
+	return period.From.Format(reportDateLayout) + types.ReportRangeSeparator + period.LastDay().Format(reportDateLayout)
+}
+
+func reportPeriodFileName(period types.ReportPeriod) string {
+	switch period.Type {
+	case types.ReportPeriodMonth:
+		return period.From.Format("2006-01")
+	case types.ReportPeriodRange:
+		return period.From.Format(reportDateLayout) + "_" + period.LastDay().Format(reportDateLayout)
+	}
+
+	return ReportPeriodCommand(period)
+}
+
+// ReportPeriodLabel names the period in report titles, e.g. "Bulan Agustus
+// Tahun 2021".
+func ReportPeriodLabel(p i18n.Printer, period types.ReportPeriod) string {
+	switch period.Type {
+	case types.ReportPeriodMonth:
+		return p.Text("report.period.month", p.Month(int(period.From.Month())), period.From.Year())
+	case types.ReportPeriodSemester:
+		return p.Text("report.period.semester", CurrentSemester(period.From), period.From.Year())
+	case types.ReportPeriodYear:
+		return p.Text("report.period.year", period.From.Year())
+	}
+
+	return p.Text("report.period.range", p.Date(period.From), p.Date(period.LastDay()))
+}
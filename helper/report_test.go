@@ -0,0 +1,157 @@
+package helper
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/export"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanBuildBorrowReportTable(t *testing.T) {
+	borrows := []types.Borrow{
+		{
+			ID:          1,
+			Amount:      2,
+			Duration:    7,
+			Status:      types.GetBorrowStatus("returned"),
+			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Date(2021, time.August, 3, 10, 0, 0, 0, time.UTC)},
+			ConfirmedBy: sql.NullString{Valid: true, String: "Admin"},
+			User:        types.User{Name: "Budi"},
+			Tool:        types.Tool{Name: "Osiloskop"},
+		},
+	}
+
+	r := BuildBorrowReportTable(i18n.NewPrinter(types.LanguageIndonesian), borrows)
+
+	assert.Len(t, r.Columns, len(borrowReportColumns))
+	assert.Equal(t, export.Column{Header: "ID", Numeric: true}, r.Columns[0])
+	assert.Equal(t, [][]string{{"1", "2021-08-03", "Budi", "Osiloskop", "2", "7", "RETURNED", "Admin"}}, r.Rows)
+}
+
+func TestCanBuildToolReturningReportTable(t *testing.T) {
+	rets := []types.ToolReturning{
+		{
+			ID:          3,
+			BorrowID:    1,
+			Status:      types.GetToolReturningStatus("complete"),
+			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Date(2021, time.August, 10, 10, 0, 0, 0, time.UTC)},
+			ConfirmedBy: sql.NullString{Valid: true, String: "Admin"},
+			Borrow: types.Borrow{
+				Amount: 2,
+				User:   types.User{Name: "Budi"},
+				Tool:   types.Tool{Name: "Osiloskop"},
+			},
+		},
+	}
+
+	r := BuildToolReturningReportTable(i18n.NewPrinter(types.LanguageEnglish), rets)
+
+	assert.Equal(t, "Borrow ID", r.Columns[1].Header)
+	assert.Equal(t, [][]string{{"3", "1", "2021-08-10", "Budi", "Osiloskop", "2", "COMPLETE", "Admin"}}, r.Rows)
+}
+
+func TestExportReport(t *testing.T) {
+	table := BuildConsumptionReportTable(i18n.NewPrinter(types.LanguageIndonesian), []types.Consumption{
+		{Tool: types.Tool{ID: 4, Name: "Resistor"}, Amount: 10, Requests: 2},
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		name, content, err := ExportReport(types.ReportTypeConsumption, MonthReportPeriod(2021, 8), types.ExportFormatCSV, table)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "laporan-pemakaian-2021-08.csv", name)
+		assert.Equal(t, "ID Alat,Alat,Jumlah,Permintaan\n4,Resistor,10,2\n", string(content))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		period := RangeReportPeriod(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC))
+		name, content, err := ExportReport(types.ReportTypeBorrow, period, types.ExportFormatXLSX, table)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "laporan-pinjam-2021-01-01_2021-06-30.xlsx", name)
+		assert.Equal(t, "PK", string(content[:2]))
+	})
+}
+
+func TestGetReportPeriodFromCommand(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+
+	t.Run("month", func(t *testing.T) {
+		r, ok := GetReportPeriodFromCommand("2021-8")
+
+		assert.True(t, ok)
+		assert.Equal(t, types.ReportPeriodMonth, r.Type)
+		assert.Equal(t, date(2021, time.August, 1), r.From)
+		assert.Equal(t, date(2021, time.September, 1), r.To)
+	})
+
+	t.Run("december ends next year", func(t *testing.T) {
+		r, ok := GetReportPeriodFromCommand("2021-12")
+
+		assert.True(t, ok)
+		assert.Equal(t, date(2022, time.January, 1), r.To)
+	})
+
+	t.Run("semester", func(t *testing.T) {
+		r, ok := GetReportPeriodFromCommand("2021-S2")
+
+		assert.True(t, ok)
+		assert.Equal(t, types.ReportPeriodSemester, r.Type)
+		assert.Equal(t, date(2021, time.July, 1), r.From)
+		assert.Equal(t, date(2022, time.January, 1), r.To)
+	})
+
+	t.Run("year", func(t *testing.T) {
+		r, ok := GetReportPeriodFromCommand("2021")
+
+		assert.True(t, ok)
+		assert.Equal(t, types.ReportPeriodYear, r.Type)
+		assert.Equal(t, date(2021, time.January, 1), r.From)
+		assert.Equal(t, date(2022, time.January, 1), r.To)
+	})
+
+	t.Run("range includes the last day", func(t *testing.T) {
+		r, ok := GetReportPeriodFromCommand("2021-01-01..2021-6-30")
+
+		assert.True(t, ok)
+		assert.Equal(t, types.ReportPeriodRange, r.Type)
+		assert.Equal(t, date(2021, time.January, 1), r.From)
+		assert.Equal(t, date(2021, time.July, 1), r.To)
+		assert.Equal(t, date(2021, time.June, 30), r.LastDay())
+	})
+
+	for _, s := range []string{"2021-s3", "2021-13", "2021-06-30..2021-01-01", "2021-01-01..", "hello", "0"} {
+		t.Run("invalid "+s, func(t *testing.T) {
+			r, ok := GetReportPeriodFromCommand(s)
+
+			assert.False(t, ok)
+			assert.Equal(t, types.ReportPeriod{}, r)
+		})
+	}
+}
+
+func TestReportPeriodCommand(t *testing.T) {
+	assert.Equal(t, "2021-1", ReportPeriodCommand(MonthReportPeriod(2021, 1)))
+	assert.Equal(t, "2021-s2", ReportPeriodCommand(SemesterReportPeriod(2021, 2)))
+	assert.Equal(t, "2021", ReportPeriodCommand(YearReportPeriod(2021)))
+
+	period, _ := GetReportPeriodFromCommand("2021-1-5..2021-2-1")
+	assert.Equal(t, "2021-01-05..2021-02-01", ReportPeriodCommand(period))
+}
+
+func TestReportPeriodLabel(t *testing.T) {
+	p := i18n.NewPrinter(types.LanguageIndonesian)
+
+	assert.Equal(t, "Bulan Agustus Tahun 2021", ReportPeriodLabel(p, MonthReportPeriod(2021, 8)))
+	assert.Equal(t, "Semester 1 Tahun 2021", ReportPeriodLabel(p, SemesterReportPeriod(2021, 1)))
+	assert.Equal(t, "Tahun 2021", ReportPeriodLabel(p, YearReportPeriod(2021)))
+
+	period, _ := GetReportPeriodFromCommand("2021-01-01..2021-06-30")
+	assert.Equal(t, "1 Januari 2021 s.d. 30 Juni 2021", ReportPeriodLabel(p, period))
+}
@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// BuildStatisticsMessage lists the summary followed by the sections that have
+// data in the period.
+func BuildStatisticsMessage(p i18n.Printer, mode format.Mode, statistics types.Statistics) string {
+	b := format.New(mode)
+
+	summary := statistics.Summary
+	b.Field(p.Text("statistics.borrows"), p.Plural("unit.times", summary.Borrows, summary.Borrows))
+	b.Field(p.Text("statistics.average_duration"), p.Text("statistics.days", summary.AverageDuration))
+	b.Field(p.Text("statistics.average_response"), p.Text("statistics.hours", summary.AverageResponse.Hours()))
+	b.Field(p.Text("statistics.late_returns"), p.Text("statistics.late_returns_value", summary.LateReturns, summary.Returns, percent(summary.LateRate())))
+
+	if len(statistics.Utilizations) > 0 {
+		b.Line().Bold(p.Text("statistics.utilization_title")).Line()
+		for _, utilization := range statistics.Utilizations {
+			b.Item(p.Text("statistics.utilization_line",
+				utilization.Tool.ID, utilization.Tool.Name, percent(utilization.Rate()), utilization.BorrowedDays, utilization.AvailableDays))
+		}
+	}
+
+	if len(statistics.TopTools) > 0 {
+		b.Line().Bold(p.Text("statistics.top_tools_title")).Line()
+		for _, count := range statistics.TopTools {
+			b.Item(p.Text("statistics.top_tools_line",
+				count.Tool.ID, count.Tool.Name, p.Plural("unit.times", count.Borrows, count.Borrows), p.Plural("unit.pieces", count.Amount, count.Amount)))
+		}
+	}
+
+	if len(statistics.Batches) > 0 {
+		b.Line().Bold(p.Text("statistics.batches_title")).Line()
+		for _, batch := range statistics.Batches {
+			b.Item(p.Text("statistics.batches_line",
+				batch.Batch, p.Plural("unit.borrowers", batch.Users, batch.Users), p.Plural("unit.times", batch.Borrows, batch.Borrows)))
+		}
+	}
+
+	return b.String()
+}
+
+// percent rounds a rate from 0 to 1 to a whole percentage.
+func percent(rate float64) int {
+	return int(rate*100 + 0.5)
+}
@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStatisticsMessage(t *testing.T) {
+	statistics := types.Statistics{
+		Summary: types.UsageSummary{
+			Borrows:         6,
+			AverageDuration: 4.5,
+			AverageResponse: 90 * time.Minute,
+			Returns:         4,
+			LateReturns:     1,
+		},
+		Utilizations: []types.ToolUtilization{
+			{Tool: types.Tool{ID: 3, Name: "Osiloskop"}, BorrowedDays: 31, AvailableDays: 62},
+		},
+		TopTools: []types.ToolBorrowCount{
+			{Tool: types.Tool{ID: 3, Name: "Osiloskop"}, Borrows: 4, Amount: 5},
+		},
+		Batches: []types.BatchUsage{
+			{Batch: 2018, Users: 3, Borrows: 5},
+		},
+	}
+
+	r := BuildStatisticsMessage(i18n.NewPrinter(types.LanguageIndonesian), "", statistics)
+
+	expected := "Peminjaman: 6 kali\n" +
+		"Rata-rata durasi: 4.5 hari\n" +
+		"Rata-rata waktu tanggapan admin: 1.5 jam\n" +
+		"Pengembalian terlambat: 1 dari 4 (25%)\n" +
+		"\nUtilisasi alat\n" +
+		"• [3] Osiloskop - 50% (31.0 dari 62.0 hari)\n" +
+		"\nAlat paling sering dipinjam\n" +
+		"• [3] Osiloskop - 4 kali, 5 buah\n" +
+		"\nPenggunaan per angkatan\n" +
+		"• Angkatan 2018 - 3 peminjam, 5 kali\n"
+
+	assert.Equal(t, expected, r)
+}
+
+func TestBuildStatisticsMessageSkipsEmptySections(t *testing.T) {
+	r := BuildStatisticsMessage(i18n.NewPrinter(types.LanguageEnglish), "", types.Statistics{})
+
+	assert.NotContains(t, r, "Tool utilisation")
+	assert.NotContains(t, r, "Most borrowed tools")
+	assert.NotContains(t, r, "Usage per batch")
+	assert.Contains(t, r, "Late returns: 0 of 0 (0%)")
+}
@@ -4,11 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 )
 
+var (
+	ErrToolWeightNotNumber = errors.New("weight is not a number")
+	ErrToolStockNotNumber  = errors.New("stock is not a number")
+	ErrToolCategoryInvalid = errors.New("category is not a category id")
+	ErrToolKindInvalid     = errors.New("kind is not a tool kind")
+	ErrToolMinStockInvalid = errors.New("minimum stock is not a number")
+	ErrToolTagTooLong      = errors.New("tag is too long")
+)
+
+// toolKindValues are the words an admin writes to set the kind of a tool.
+var toolKindValues = map[string]types.ToolKind{
+	"aset":        types.ToolKindAsset,
+	"habis_pakai": types.ToolKindConsumable,
+}
+
 func GetToolFromChatSessionDetail(manageType types.ManageType, details []types.ChatSessionDetail) types.Tool {
 	var tool types.Tool
 
@@ -123,6 +140,13 @@ func toolFields() []types.ToolField {
 		types.ToolFieldStock,
 		types.ToolFieldAdditionalInfo,
 		types.ToolFieldPhoto,
+		types.ToolFieldCategory,
+		types.ToolFieldTags,
+		types.ToolFieldRoom,
+		types.ToolFieldCabinet,
+		types.ToolFieldShelf,
+		types.ToolFieldKind,
+		types.ToolFieldMinStock,
 	}
 }
 
@@ -154,6 +178,22 @@ func GetToolValueByField(tool types.Tool, f string) string {
 		result = strconv.FormatInt(tool.Stock, 10)
 	case types.ToolFieldAdditionalInfo:
 		result = tool.AdditionalInformation
+	case types.ToolFieldCategory:
+		if tool.CategoryID > 0 {
+			result = strconv.FormatInt(tool.CategoryID, 10)
+		}
+	case types.ToolFieldTags:
+		result = strings.Join(tool.Tags, ", ")
+	case types.ToolFieldRoom:
+		result = tool.Location.Room
+	case types.ToolFieldCabinet:
+		result = tool.Location.Cabinet
+	case types.ToolFieldShelf:
+		result = tool.Location.Shelf
+	case types.ToolFieldKind:
+		result = ToolKindValue(tool.Kind)
+	case types.ToolFieldMinStock:
+		result = strconv.FormatInt(tool.MinStock, 10)
 	default:
 		result = ""
 	}
@@ -176,20 +216,153 @@ func ChangeToolValueByField(tool types.Tool, field, newValue string) (types.Tool
 	case types.ToolFieldWeight:
 		i, err := strconv.ParseFloat(newValue, 10)
 		if err != nil || i < 0 {
-			return updatedTool, errors.New("mohon sebutkan berat dalam angka")
+			return updatedTool, ErrToolWeightNotNumber
 		}
 		updatedTool.Weight = float32(i)
 
 	case types.ToolFieldStock:
 		i, err := strconv.ParseInt(newValue, 10, 64)
 		if err != nil || i < 0 {
-			return updatedTool, errors.New("mohon sebutkan jumlah stok dalam angka")
+			return updatedTool, ErrToolStockNotNumber
 		}
 		updatedTool.Stock = i
 
 	case types.ToolFieldAdditionalInfo:
 		updatedTool.AdditionalInformation = newValue
+
+	// the category is given by its ID, the path typed by the user is resolved
+	// before as it needs the database
+	case types.ToolFieldCategory:
+		if isEmptyToolValue(newValue) {
+			updatedTool.CategoryID = 0
+			break
+		}
+
+		i, err := strconv.ParseInt(newValue, 10, 64)
+		if err != nil || i < 1 {
+			return updatedTool, ErrToolCategoryInvalid
+		}
+		updatedTool.CategoryID = i
+
+	case types.ToolFieldTags:
+		tags, err := ParseToolTags(newValue)
+		if err != nil {
+			return updatedTool, err
+		}
+		updatedTool.Tags = tags
+	case types.ToolFieldRoom:
+		updatedTool.Location.Room = optionalToolValue(newValue)
+	case types.ToolFieldCabinet:
+		updatedTool.Location.Cabinet = optionalToolValue(newValue)
+	case types.ToolFieldShelf:
+		updatedTool.Location.Shelf = optionalToolValue(newValue)
+
+	case types.ToolFieldKind:
+		kind, ok := toolKindValues[strings.ToLower(strings.TrimSpace(newValue))]
+		if !ok {
+			return updatedTool, ErrToolKindInvalid
+		}
+		updatedTool.Kind = kind
+
+	case types.ToolFieldMinStock:
+		i, err := strconv.ParseInt(strings.TrimSpace(newValue), 10, 64)
+		if err != nil || i < 0 {
+			return updatedTool, ErrToolMinStockInvalid
+		}
+		updatedTool.MinStock = i
 	}
 
 	return updatedTool, nil
 }
+
+// ToolKindValue is the word an admin writes to set the kind, e.g. "habis_pakai".
+// A tool without kind is an asset.
+func ToolKindValue(kind types.ToolKind) string {
+	for value, k := range toolKindValues {
+		if k == kind {
+			return value
+		}
+	}
+	return ToolKindValue(types.ToolKindAsset)
+}
+
+func isEmptyToolValue(s string) bool {
+	s = strings.TrimSpace(s)
+	return len(s) == 0 || s == types.ToolFieldEmptyValue
+}
+
+func optionalToolValue(s string) string {
+	if isEmptyToolValue(s) {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+// ParseToolTags reads comma separated tags, e.g. "Digital, portable". Tags are
+// lower cased and each tag is kept once. A tag longer than
+// types.ToolTagMaxLength is refused.
+func ParseToolTags(s string) ([]string, error) {
+	tags := []string{}
+	if isEmptyToolValue(s) {
+		return tags, nil
+	}
+
+	seen := map[string]bool{}
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if len(tag) == 0 || seen[tag] {
+			continue
+		}
+		if len(tag) > types.ToolTagMaxLength {
+			return []string{}, ErrToolTagTooLong
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// ParseToolCategoryPath reads the category names of a path such as
+// "Elektronika / Alat Ukur", the top level category first.
+func ParseToolCategoryPath(s string) []string {
+	names := []string{}
+	if isEmptyToolValue(s) {
+		return names
+	}
+
+	for _, name := range strings.Split(s, types.ToolCategorySeparator) {
+		name = strings.Join(strings.Fields(name), " ")
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// BuildToolCategoryPath writes the categories as a path, the top level category
+// first.
+func BuildToolCategoryPath(categories []types.ToolCategory) string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	return strings.Join(names, " "+types.ToolCategorySeparator+" ")
+}
+
+// BuildToolLocation writes the room, cabinet and shelf that are known.
+func BuildToolLocation(p i18n.Printer, location types.ToolLocation) string {
+	parts := []string{}
+	if len(location.Room) > 0 {
+		parts = append(parts, p.Text("location.room", location.Room))
+	}
+	if len(location.Cabinet) > 0 {
+		parts = append(parts, p.Text("location.cabinet", location.Cabinet))
+	}
+	if len(location.Shelf) > 0 {
+		parts = append(parts, p.Text("location.shelf", location.Shelf))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,17 +1,16 @@
 package helper
 
 import (
-	"fmt"
-
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 )
 
-func BuildToolReturningReportMessage(rets []types.ToolReturning) string {
-	var message string
+func BuildToolReturningReportMessage(p i18n.Printer, mode format.Mode, rets []types.ToolReturning) string {
+	b := format.New(mode)
 	for _, ret := range rets {
-		message = fmt.Sprintf(
-			"%s[%d] %s - %s, %d buah %s (dikonfirmasi oleh: %s)\n",
-			message, ret.ID, TranslateDateToBahasa(ret.ConfirmedAt.Time), ret.Borrow.User.Name, ret.Borrow.Amount, ret.Borrow.Tool.Name, ret.ConfirmedBy.String)
+		b.Item(p.Text("report.line",
+			ret.ID, p.Date(ret.ConfirmedAt.Time), ret.Borrow.User.Name, p.Plural("unit.pieces", ret.Borrow.Amount, ret.Borrow.Amount), ret.Borrow.Tool.Name, ret.ConfirmerName()))
 	}
-	return message
+	return b.String()
 }
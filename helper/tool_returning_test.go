@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fannyhasbi/lab-tools-lending/format"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -51,7 +53,7 @@ func TestCanBuildToolReturningReportMessage(t *testing.T) {
 		},
 	}
 
-	r := BuildToolReturningReportMessage(rets)
+	r := BuildToolReturningReportMessage(i18n.NewPrinter(types.LanguageIndonesian), format.MarkdownV2, rets)
 
 	// todo: make a better assertion
 	assert.Contains(t, r, rets[0].Borrow.User.Name)
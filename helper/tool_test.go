@@ -3,8 +3,10 @@ package helper
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -276,6 +278,22 @@ func TestGetToolValueByField(t *testing.T) {
 		r := GetToolValueByField(tool, string(types.ToolFieldAdditionalInfo))
 		assert.Equal(t, tool.AdditionalInformation, r)
 	})
+	t.Run("tags", func(t *testing.T) {
+		tagged := tool
+		tagged.Tags = []string{"digital", "portable"}
+		r := GetToolValueByField(tagged, string(types.ToolFieldTags))
+		assert.Equal(t, "digital, portable", r)
+	})
+	t.Run("empty category", func(t *testing.T) {
+		r := GetToolValueByField(tool, string(types.ToolFieldCategory))
+		assert.Equal(t, "", r)
+	})
+	t.Run("kind", func(t *testing.T) {
+		consumable := tool
+		consumable.Kind = types.ToolKindConsumable
+		assert.Equal(t, "habis_pakai", GetToolValueByField(consumable, string(types.ToolFieldKind)))
+		assert.Equal(t, "aset", GetToolValueByField(tool, string(types.ToolFieldKind)))
+	})
 	t.Run("no case", func(t *testing.T) {
 		r := GetToolValueByField(tool, "testnocase")
 		assert.Equal(t, "", r)
@@ -342,7 +360,7 @@ func TestCanChangeToolValueByField(t *testing.T) {
 	})
 	t.Run("weight is not a number", func(t *testing.T) {
 		r, err := ChangeToolValueByField(tool, string(types.ToolFieldWeight), "testnotanumber")
-		assert.Error(t, err)
+		assert.Equal(t, ErrToolWeightNotNumber, err)
 		assert.Equal(t, tool, r)
 	})
 	t.Run("Stock negative", func(t *testing.T) {
@@ -353,7 +371,95 @@ func TestCanChangeToolValueByField(t *testing.T) {
 	})
 	t.Run("stock is not a number", func(t *testing.T) {
 		r, err := ChangeToolValueByField(tool, string(types.ToolFieldStock), "testnotanumber")
-		assert.Error(t, err)
+		assert.Equal(t, ErrToolStockNotNumber, err)
+		assert.Equal(t, tool, r)
+	})
+	t.Run("category", func(t *testing.T) {
+		newTool := tool
+		newTool.CategoryID = 4
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldCategory), "4")
+		assert.NoError(t, err)
+		assert.Equal(t, newTool, r)
+	})
+	t.Run("clear category", func(t *testing.T) {
+		categorized := tool
+		categorized.CategoryID = 4
+		r, err := ChangeToolValueByField(categorized, string(types.ToolFieldCategory), types.ToolFieldEmptyValue)
+		assert.NoError(t, err)
+		assert.Equal(t, tool, r)
+	})
+	t.Run("category is not an id", func(t *testing.T) {
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldCategory), "Elektronika")
+		assert.Equal(t, ErrToolCategoryInvalid, err)
+		assert.Equal(t, tool, r)
+	})
+	t.Run("tags", func(t *testing.T) {
+		newTool := tool
+		newTool.Tags = []string{"digital", "alat ukur"}
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldTags), "Digital,  alat   ukur, digital")
+		assert.NoError(t, err)
+		assert.Equal(t, newTool, r)
+	})
+	t.Run("tag is too long", func(t *testing.T) {
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldTags), "digital, "+strings.Repeat("a", types.ToolTagMaxLength+1))
+		assert.Equal(t, ErrToolTagTooLong, err)
+		assert.Equal(t, tool, r)
+	})
+	t.Run("location", func(t *testing.T) {
+		newTool := tool
+		newTool.Location.Cabinet = "A2"
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldCabinet), " A2 ")
+		assert.NoError(t, err)
+		assert.Equal(t, newTool, r)
+	})
+	t.Run("kind", func(t *testing.T) {
+		newTool := tool
+		newTool.Kind = types.ToolKindConsumable
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldKind), "Habis_Pakai")
+		assert.NoError(t, err)
+		assert.Equal(t, newTool, r)
+	})
+	t.Run("unknown kind", func(t *testing.T) {
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldKind), "pinjam")
+		assert.Equal(t, ErrToolKindInvalid, err)
+		assert.Equal(t, tool, r)
+	})
+	t.Run("minimum stock", func(t *testing.T) {
+		newTool := tool
+		newTool.MinStock = 10
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldMinStock), "10")
+		assert.NoError(t, err)
+		assert.Equal(t, newTool, r)
+	})
+	t.Run("minimum stock negative", func(t *testing.T) {
+		r, err := ChangeToolValueByField(tool, string(types.ToolFieldMinStock), "-1")
+		assert.Equal(t, ErrToolMinStockInvalid, err)
 		assert.Equal(t, tool, r)
 	})
 }
+
+func TestParseToolCategoryPath(t *testing.T) {
+	assert.Equal(t, []string{"Elektronika", "Alat Ukur"}, ParseToolCategoryPath(" Elektronika //  Alat  Ukur "))
+	assert.Empty(t, ParseToolCategoryPath(types.ToolFieldEmptyValue))
+}
+
+func TestBuildToolCategoryPath(t *testing.T) {
+	categories := []types.ToolCategory{
+		{ID: 1, Name: "Elektronika"},
+		{ID: 2, ParentID: 1, Name: "Alat Ukur"},
+	}
+
+	assert.Equal(t, "Elektronika / Alat Ukur", BuildToolCategoryPath(categories))
+}
+
+func TestBuildToolLocation(t *testing.T) {
+	p := i18n.NewPrinter(types.LanguageIndonesian)
+
+	t.Run("full location", func(t *testing.T) {
+		r := BuildToolLocation(p, types.ToolLocation{Room: "Lab 1", Cabinet: "A", Shelf: "2"})
+		assert.Equal(t, "ruang Lab 1, lemari A, rak 2", r)
+	})
+	t.Run("unknown location", func(t *testing.T) {
+		assert.Equal(t, "", BuildToolLocation(p, types.ToolLocation{}))
+	})
+}
@@ -0,0 +1,190 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+var ErrUserBatchNotNumber = errors.New("batch is not a number")
+
+// GetRegistrationFromChatSessionDetail collects the answers of the
+// registration form, one detail per question.
+func GetRegistrationFromChatSessionDetail(details []types.ChatSessionDetail) types.QuestionRegistration {
+	var reg types.QuestionRegistration
+
+	for _, detail := range details {
+		dataParsed, err := gabs.ParseJSON([]byte(detail.Data))
+		if err != nil {
+			return reg
+		}
+
+		switch detail.Topic {
+		case types.Topic["register_name"]:
+			reg.Name, _ = dataParsed.Path("name").Data().(string)
+		case types.Topic["register_nim"]:
+			reg.NIM, _ = dataParsed.Path("nim").Data().(string)
+		case types.Topic["register_batch"]:
+			batch, _ := dataParsed.Path("batch").Data().(float64)
+			reg.Batch = int(batch)
+		case types.Topic["register_address"]:
+			reg.Address, _ = dataParsed.Path("address").Data().(string)
+		case types.Topic["register_phone"]:
+			reg.Phone, _ = dataParsed.Path("phone").Data().(string)
+		}
+	}
+
+	return reg
+}
+
+// GetProfileFieldFromChatSessionDetail returns the field chosen in the profile
+// flow.
+func GetProfileFieldFromChatSessionDetail(details []types.ChatSessionDetail) string {
+	detail, found := GetChatSessionDetailByTopic(details, types.Topic["profile_init"])
+	if !found {
+		return ""
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(detail.Data))
+	if err != nil {
+		return ""
+	}
+
+	field, _ := dataParsed.Path("field").Data().(string)
+	return field
+}
+
+func userFields() []types.UserField {
+	return []types.UserField{
+		types.UserFieldName,
+		types.UserFieldNIM,
+		types.UserFieldBatch,
+		types.UserFieldAddress,
+	}
+}
+
+func IsUserFieldExists(f string) bool {
+	for _, field := range userFields() {
+		if types.UserField(f) == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUserIdentityField tells whether the field identifies the student, admins
+// are notified when it changes.
+func IsUserIdentityField(f string) bool {
+	field := types.UserField(f)
+	return field == types.UserFieldName || field == types.UserFieldNIM
+}
+
+func GetUserValueByField(user types.User, f string) string {
+	switch types.UserField(f) {
+	case types.UserFieldName:
+		return user.Name
+	case types.UserFieldNIM:
+		return user.NIM
+	case types.UserFieldBatch:
+		return strconv.Itoa(int(user.Batch))
+	case types.UserFieldAddress:
+		return user.Address
+	default:
+		return ""
+	}
+}
+
+func ChangeUserValueByField(user types.User, field, newValue string) (types.User, error) {
+	updatedUser := user
+
+	switch types.UserField(field) {
+	case types.UserFieldName:
+		updatedUser.Name = newValue
+	case types.UserFieldNIM:
+		updatedUser.NIM = newValue
+	case types.UserFieldBatch:
+		batch, err := strconv.ParseUint(newValue, 10, 16)
+		if err != nil {
+			return updatedUser, ErrUserBatchNotNumber
+		}
+		updatedUser.Batch = uint16(batch)
+	case types.UserFieldAddress:
+		updatedUser.Address = newValue
+	}
+
+	return updatedUser, nil
+}
+
+// RegistrationFromUser turns the stored user back into a registration, so a
+// changed profile is validated like the registration form.
+func RegistrationFromUser(user types.User) types.QuestionRegistration {
+	return types.QuestionRegistration{
+		Name:    user.Name,
+		NIM:     user.NIM,
+		Batch:   int(user.Batch),
+		Address: user.Address,
+	}
+}
+
+func BuildRegistrationListMessage(users []types.User) string {
+	var message string
+	for _, user := range users {
+		message = fmt.Sprintf("%s[%d] %s - %s\n", message, user.ID, user.Name, user.NIM)
+	}
+	return message
+}
+
+// GetUserCommandOrder reads "/pengguna cari [kata kunci]" and
+// "/pengguna [id] [tangguhkan [alasan]|pulihkan|hapus [ya]]".
+func GetUserCommandOrder(s string) (types.UserCommandOrder, bool) {
+	ss := strings.Fields(s)
+	if len(ss) < 2 {
+		return types.UserCommandOrder{}, false
+	}
+
+	if strings.ToLower(ss[1]) == types.UserCommandSearch {
+		return types.UserCommandOrder{Type: types.UserCommandSearch, Text: strings.Join(ss[2:], " ")}, true
+	}
+
+	id, err := strconv.ParseInt(ss[1], 10, 64)
+	if err != nil || id < 1 {
+		return types.UserCommandOrder{}, false
+	}
+
+	order := types.UserCommandOrder{ID: id}
+	if len(ss) == 2 {
+		return order, true
+	}
+
+	order.Type = strings.ToLower(ss[2])
+	order.Text = strings.Join(ss[3:], " ")
+	switch order.Type {
+	case types.UserCommandSuspend:
+		return order, true
+	case types.UserCommandUnsuspend:
+		return order, len(ss) == 3
+	case types.UserCommandDelete:
+		return order, len(ss) <= 4
+	}
+
+	return types.UserCommandOrder{}, false
+}
+
+// BuildUserListMessage lists the students found by a search, marking the
+// suspended ones.
+func BuildUserListMessage(p i18n.Printer, users []types.User) string {
+	var message string
+	for _, user := range users {
+		message = fmt.Sprintf("%s[%d] %s - %s (%d)", message, user.ID, user.Name, user.NIM, user.Batch)
+		if user.IsSuspended() {
+			message = fmt.Sprintf("%s - %s", message, p.Text("user.suspended_mark"))
+		}
+		message += "\n"
+	}
+	return message
+}
@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRegistrationFromChatSessionDetail(t *testing.T) {
+	reg := types.QuestionRegistration{
+		Name:    "Fanny Hasbi",
+		NIM:     "21120117130000",
+		Batch:   2017,
+		Address: "Jl. Prof. Soedarto, Tembalang",
+		Phone:   "+6281234567890",
+	}
+
+	t.Run("found", func(t *testing.T) {
+		gen := NewSessionDataGenerator
+		details := []types.ChatSessionDetail{
+			{
+				Topic: types.Topic["register_phone"],
+				Data:  gen().RegisterPhone(reg.Phone),
+			},
+			{
+				Topic: types.Topic["register_address"],
+				Data:  gen().RegisterAddress(reg.Address),
+			},
+			{
+				Topic: types.Topic["register_batch"],
+				Data:  gen().RegisterBatch(reg.Batch),
+			},
+			{
+				Topic: types.Topic["register_nim"],
+				Data:  gen().RegisterNIM(reg.NIM),
+			},
+			{
+				Topic: types.Topic["register_name"],
+				Data:  gen().RegisterName(reg.Name),
+			},
+			{
+				Topic: types.Topic["register_init"],
+				Data:  "{}",
+			},
+		}
+
+		assert.Equal(t, reg, GetRegistrationFromChatSessionDetail(details))
+	})
+
+	t.Run("partially answered", func(t *testing.T) {
+		details := []types.ChatSessionDetail{
+			{
+				Topic: types.Topic["register_name"],
+				Data:  NewSessionDataGenerator().RegisterName(reg.Name),
+			},
+			{
+				Topic: types.Topic["register_init"],
+				Data:  "{}",
+			},
+		}
+
+		assert.Equal(t, types.QuestionRegistration{Name: reg.Name}, GetRegistrationFromChatSessionDetail(details))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		details := []types.ChatSessionDetail{
+			{
+				Topic: types.Topic["register_init"],
+				Data:  "{}",
+			},
+		}
+
+		assert.Equal(t, types.QuestionRegistration{}, GetRegistrationFromChatSessionDetail(details))
+	})
+}
+
+func TestGetProfileFieldFromChatSessionDetail(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		details := []types.ChatSessionDetail{
+			{
+				Topic: types.Topic["profile_init"],
+				Data:  NewSessionDataGenerator().ProfileInit(string(types.UserFieldNIM)),
+			},
+		}
+
+		assert.Equal(t, string(types.UserFieldNIM), GetProfileFieldFromChatSessionDetail(details))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, "", GetProfileFieldFromChatSessionDetail([]types.ChatSessionDetail{}))
+	})
+}
+
+func TestIsUserFieldExists(t *testing.T) {
+	for _, field := range userFields() {
+		assert.True(t, IsUserFieldExists(string(field)))
+	}
+	assert.False(t, IsUserFieldExists("bahasa"))
+}
+
+func TestIsUserIdentityField(t *testing.T) {
+	assert.True(t, IsUserIdentityField(string(types.UserFieldName)))
+	assert.True(t, IsUserIdentityField(string(types.UserFieldNIM)))
+	assert.False(t, IsUserIdentityField(string(types.UserFieldBatch)))
+	assert.False(t, IsUserIdentityField(string(types.UserFieldAddress)))
+}
+
+func TestChangeUserValueByField(t *testing.T) {
+	user := types.User{
+		ID:      1,
+		Name:    "Fanny Hasbi",
+		NIM:     "21120117130000",
+		Batch:   2017,
+		Address: "Semarang",
+	}
+
+	t.Run("batch", func(t *testing.T) {
+		r, err := ChangeUserValueByField(user, string(types.UserFieldBatch), "2018")
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(2018), r.Batch)
+		assert.Equal(t, "2018", GetUserValueByField(r, string(types.UserFieldBatch)))
+		assert.Equal(t, uint16(2017), user.Batch)
+	})
+
+	t.Run("batch not a number", func(t *testing.T) {
+		_, err := ChangeUserValueByField(user, string(types.UserFieldBatch), "dua ribu")
+		assert.Equal(t, ErrUserBatchNotNumber, err)
+	})
+
+	t.Run("text fields", func(t *testing.T) {
+		r, err := ChangeUserValueByField(user, string(types.UserFieldAddress), "Tembalang")
+		assert.NoError(t, err)
+		assert.Equal(t, "Tembalang", GetUserValueByField(r, string(types.UserFieldAddress)))
+
+		r, err = ChangeUserValueByField(user, string(types.UserFieldNIM), "21120117140000")
+		assert.NoError(t, err)
+		assert.Equal(t, "21120117140000", GetUserValueByField(r, string(types.UserFieldNIM)))
+		assert.Equal(t, user.Name, GetUserValueByField(r, string(types.UserFieldName)))
+	})
+
+	t.Run("registration", func(t *testing.T) {
+		reg := RegistrationFromUser(user)
+		assert.Equal(t, types.QuestionRegistration{Name: user.Name, NIM: user.NIM, Batch: 2017, Address: user.Address}, reg)
+	})
+}
+
+func TestBuildRegistrationListMessage(t *testing.T) {
+	users := []types.User{
+		{ID: 123, Name: "Fanny Hasbi", NIM: "21120117130000"},
+		{ID: 456, Name: "Budi", NIM: "21120118130001"},
+	}
+
+	expected := fmt.Sprintf("[%d] %s - %s\n[%d] %s - %s\n", 123, "Fanny Hasbi", "21120117130000", 456, "Budi", "21120118130001")
+	assert.Equal(t, expected, BuildRegistrationListMessage(users))
+}
+
+func TestGetUserCommandOrder(t *testing.T) {
+	t.Run("search", func(t *testing.T) {
+		r, ok := GetUserCommandOrder(fmt.Sprintf("/%s %s Fanny Hasbi", types.CommandUser, types.UserCommandSearch))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.UserCommandOrder{Type: types.UserCommandSearch, Text: "Fanny Hasbi"}, r)
+	})
+
+	t.Run("detail", func(t *testing.T) {
+		r, ok := GetUserCommandOrder(fmt.Sprintf("/%s 123", types.CommandUser))
+
+		assert.True(t, ok)
+		assert.Equal(t, types.UserCommandOrder{ID: 123}, r)
+	})
+
+	t.Run("suspend with reason", func(t *testing.T) {
+		r, ok := GetUserCommandOrder(fmt.Sprintf("/%s 123 %s Belum mengembalikan osiloskop", types.CommandUser, types.UserCommandSuspend))
+
+		expected := types.UserCommandOrder{ID: 123, Type: types.UserCommandSuspend, Text: "Belum mengembalikan osiloskop"}
+
+		assert.True(t, ok)
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("delete confirmed", func(t *testing.T) {
+		r, ok := GetUserCommandOrder(fmt.Sprintf("/%s 123 %s yes", types.CommandUser, types.UserCommandDelete))
+
+		assert.True(t, ok)
+		assert.Equal(t, "yes", r.Text)
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		_, ok := GetUserCommandOrder(fmt.Sprintf("/%s 123 ubah", types.CommandUser))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("without user", func(t *testing.T) {
+		_, ok := GetUserCommandOrder(fmt.Sprintf("/%s", types.CommandUser))
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,654 @@
+package i18n
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+func text(indonesian, english string) Message {
+	return Message{
+		types.LanguageIndonesian: {Other: indonesian},
+		types.LanguageEnglish:    {Other: english},
+	}
+}
+
+func plural(indonesian, englishOne, englishOther string) Message {
+	return Message{
+		types.LanguageIndonesian: {Other: indonesian},
+		types.LanguageEnglish:    {One: englishOne, Other: englishOther},
+	}
+}
+
+var messages = map[string]Message{
+	"error":   text("Maaf, sedang terjadi kesalahan. Silahkan coba beberapa saat lagi.", "Sorry, something went wrong. Please try again in a moment."),
+	"unknown": text("Maaf, perintah tidak dikenali.", "Sorry, the command is not recognized."),
+
+	"button.continue":     text("Lanjutkan", "Continue"),
+	"button.cancel":       text("Batalkan", "Cancel"),
+	"button.sure":         text("Yakin", "Yes, I'm sure"),
+	"button.back":         text("Kembali", "Back"),
+	"button.edit_answer":  text("Ubah jawaban", "Change answer"),
+	"button.view_photo":   text("Lihat Foto", "View Photos"),
+	"button.edit_data":    text("Ubah Data", "Edit Data"),
+	"button.delete":       text("Hapus", "Delete"),
+	"button.borrow":       text("Pinjam", "Borrow"),
+	"button.request":      text("Minta", "Request"),
+	"button.respond":      text("Tanggapi", "Respond"),
+	"button.approve":      text("Setujui", "Approve"),
+	"button.reject":       text("Tolak", "Reject"),
+	"button.check_tool":   text("Cek Barang", "Check Tool"),
+	"button.previous":     text("« Sebelumnya", "« Previous"),
+	"button.next":         text("Berikutnya »", "Next »"),
+	"button.save_changes": text("Simpan Perubahan", "Save Changes"),
+	"button.categories":   text("Jelajahi Kategori", "Browse Categories"),
+	"button.profile":      text("Lihat Profil", "View Profile"),
+	"button.maintenance":  text("Perawatan", "Maintenance"),
+	"button.share_phone":  text("Bagikan Nomor Telepon", "Share Phone Number"),
+	"button.lab":          text("Ganti Laboratorium", "Change Laboratory"),
+	"button.skip":         text("Lewati", "Skip"),
+
+	"unit.days":      plural("%d hari", "%d day", "%d days"),
+	"unit.grams":     text("%.2f gram", "%.2f grams"),
+	"unit.pieces":    plural("%d buah", "%d piece", "%d pieces"),
+	"unit.requests":  plural("%d pengajuan", "%d request", "%d requests"),
+	"unit.times":     plural("%d kali", "%d time", "%d times"),
+	"unit.borrowers": plural("%d peminjam", "%d borrower", "%d borrowers"),
+
+	"month.1":  text("Januari", "January"),
+	"month.2":  text("Februari", "February"),
+	"month.3":  text("Maret", "March"),
+	"month.4":  text("April", "April"),
+	"month.5":  text("Mei", "May"),
+	"month.6":  text("Juni", "June"),
+	"month.7":  text("Juli", "July"),
+	"month.8":  text("Agustus", "August"),
+	"month.9":  text("September", "September"),
+	"month.10": text("Oktober", "October"),
+	"month.11": text("November", "November"),
+	"month.12": text("Desember", "December"),
+
+	"start.welcome": text(
+		"Selamat Datang di Layanan Chatbot Peminjaman Barang Laboratorium Teknik Komputer!\n\nSilahkan lakukan registrasi dengan mengirim perintah \"/%s\"",
+		"Welcome to the Computer Engineering Laboratory Tool Lending Chatbot!\n\nPlease register by sending the \"/%s\" command",
+	),
+	"help.user": text(
+		`/%s - Mendaftarkan diri agar dapat menggunakan sistem
+/%s - Cek ketersediaan dan cari barang
+/%s - Mulai pengajuan peminjaman barang
+/%s - Mulai pengajuan Pengembalian barang
+/%s - Melihat riwayat peminjaman
+/%s - Melihat dan mengubah data diri
+/%s - Memilih laboratorium tempat meminjam
+/%s - Mengganti bahasa
+/%s - Menampilkan panduan penggunaan bot`,
+		`/%s - Register to use the system
+/%s - Check the availability of tools and search for them
+/%s - Request to borrow a tool
+/%s - Request to return a tool
+/%s - View your borrowing history
+/%s - View and change your personal data
+/%s - Choose the laboratory to borrow from
+/%s - Change the language
+/%s - Show how to use the bot`,
+	),
+	"help.admin": text(
+		`/%s - Cek ketersediaan barang
+/%s - Menanggapi pengajuan peminjaman dan pengembalian barang
+/%s - Menambah dan mengubah data barang serta kategori
+/%s - Melihat laporan bulanan
+/%s - Melihat statistik penggunaan laboratorium
+/%s - Mencatat perawatan dan jadwal kalibrasi barang
+/%s - Mencari, menangguhkan, dan menghapus mahasiswa
+/%s - Mengundang dan mencabut pengurus
+/%s - Mengatur peran dan izin pengurus
+/%s - Melihat jejak audit tindakan pengurus
+/%s - Mengganti bahasa
+/%s - Menampilkan panduan penggunaan bot`,
+		`/%s - Check the availability of tools
+/%s - Respond to borrowing and returning requests
+/%s - Add and edit tools
+/%s - View the monthly reports
+/%s - View the laboratory usage statistics
+/%s - Record maintenance and calibration schedules
+/%s - Search, suspend and delete students
+/%s - Invite and revoke admins
+/%s - Manage the roles and permissions of admins
+/%s - View the audit trail of admin actions
+/%s - Change the language
+/%s - Show how to use the bot`,
+	),
+
+	"lab.choose":         text("Pilih laboratorium tempat kamu meminjam barang.\nLaboratorium saat ini: %s", "Choose the laboratory you borrow tools from.\nCurrent laboratory: %s"),
+	"lab.none":           text("belum dipilih", "not chosen yet"),
+	"lab.not_found":      text("Laboratorium tidak ditemukan.", "The laboratory was not found."),
+	"lab.chosen":         text("Kamu sekarang meminjam barang dari %s.", "You now borrow tools from %s."),
+	"lab.group":          text("Grup ini adalah grup pengurus %s (ID %d).", "This group is the admin group of %s (ID %d)."),
+	"lab.usage":          text("Kirim \"/%s [nama laboratorium]\" untuk menjadikan grup ini grup pengurus laboratorium.", "Send \"/%s [laboratory name]\" to make this group the admin group of a laboratory."),
+	"lab.created":        text("Grup ini sekarang menjadi grup pengurus %s. Kirim \"/%s\" untuk menjadi pengurus.", "This group is now the admin group of %s. Send \"/%s\" to become an admin."),
+	"language.choose":    text("Silahkan pilih bahasa.", "Please choose a language."),
+	"language.changed":   text("Bahasa berhasil diubah ke Bahasa Indonesia.", "The language has been changed to English."),
+	"language.name.id":   text("Bahasa Indonesia", "Bahasa Indonesia"),
+	"language.name.en":   text("English", "English"),
+	"language.not_found": text("Bahasa tidak tersedia.", "The language is not available."),
+
+	"conversation.no_previous_question": text("Tidak ada pertanyaan sebelumnya.", "There is no previous question."),
+	"conversation.no_editable_answer":   text("Belum ada jawaban yang bisa diubah.", "There is no answer to change yet."),
+	"conversation.choose_answer":        text("Pilih jawaban yang ingin diubah", "Choose the answer you want to change"),
+	"conversation.answer_not_editable":  text("Jawaban tersebut tidak dapat diubah.", "That answer cannot be changed."),
+
+	"label.nim":          text("NIM", "NIM"),
+	"label.batch":        text("Angkatan", "Batch"),
+	"label.address":      text("Alamat", "Address"),
+	"label.phone":        text("Nomor telepon", "Phone number"),
+	"label.amount":       text("Jumlah", "Amount"),
+	"label.duration":     text("Durasi", "Duration"),
+	"label.reason":       text("Alasan", "Reason"),
+	"label.return_info":  text("Keterangan", "Description"),
+	"label.name":         text("Nama", "Name"),
+	"label.brand":        text("Brand/Merk", "Brand"),
+	"label.product_type": text("Tipe Produk", "Product Type"),
+	"label.weight":       text("Berat", "Weight"),
+	"label.stock":        text("Stok", "Stock"),
+	"label.description":  text("Deskripsi", "Description"),
+	"label.maintenance":  text("Jenis perawatan", "Maintenance type"),
+	"label.date":         text("Tanggal", "Date"),
+	"label.technician":   text("Teknisi", "Technician"),
+	"label.notes":        text("Catatan", "Notes"),
+
+	"summary.confirm": text("Pastikan data sudah benar kemudian tekan \"Lanjutkan\".", "Make sure the data is correct then press \"Continue\"."),
+
+	"field.name":              text("Nama", "Name"),
+	"field.nim":               text("NIM", "NIM"),
+	"field.batch":             text("Angkatan", "Batch"),
+	"field.address":           text("Alamat", "Address"),
+	"field.phone":             text("Telepon", "Phone"),
+	"field.tool":              text("Barang", "Tool"),
+	"field.tool_name":         text("Nama alat", "Tool name"),
+	"field.amount":            text("Jumlah", "Amount"),
+	"field.return_date":       text("Tanggal pengembalian", "Return date"),
+	"field.borrowed_since":    text("Dipinjam sejak", "Borrowed since"),
+	"field.duration":          text("Durasi peminjaman", "Duration"),
+	"field.borrower":          text("Nama peminjam", "Borrower"),
+	"field.borrower_address":  text("Alamat peminjam", "Borrower address"),
+	"field.requester":         text("Nama pemohon", "Requester"),
+	"field.requester_address": text("Alamat pemohon", "Requester address"),
+	"field.registered_at":     text("Terdaftar pada", "Registered on"),
+	"field.nim_program":       text("Fakultas/Program studi", "Faculty/Study program"),
+	"field.requested_at":      text("Diajukan pada", "Requested at"),
+	"field.reason":            text("Alasan", "Reason"),
+	"field.description":       text("Keterangan", "Description"),
+
+	"user_field.nama":     text("Nama", "Name"),
+	"user_field.nim":      text("NIM", "NIM"),
+	"user_field.angkatan": text("Angkatan", "Batch"),
+	"user_field.alamat":   text("Alamat", "Address"),
+
+	"tool_field.nama":         text("Nama", "Name"),
+	"tool_field.brand":        text("Brand", "Brand"),
+	"tool_field.tipe":         text("Tipe", "Type"),
+	"tool_field.berat":        text("Berat", "Weight"),
+	"tool_field.stok":         text("Stok", "Stock"),
+	"tool_field.foto":         text("Foto", "Photos"),
+	"tool_field.keterangan":   text("Keterangan", "Description"),
+	"tool_field.kategori":     text("Kategori", "Category"),
+	"tool_field.tag":          text("Tag", "Tags"),
+	"tool_field.lokasi":       text("Lokasi", "Location"),
+	"tool_field.ruang":        text("Ruang", "Room"),
+	"tool_field.lemari":       text("Lemari", "Cabinet"),
+	"tool_field.rak":          text("Rak", "Shelf"),
+	"tool_field.status":       text("Status", "Status"),
+	"tool_field.jenis":        text("Jenis", "Kind"),
+	"tool_field.lab":          text("Laboratorium", "Laboratory"),
+	"tool_field.stok_minimum": text("Stok minimum", "Minimum stock"),
+
+	"tool_kind.ASSET":      text("Aset (dikembalikan)", "Asset (returned)"),
+	"tool_kind.CONSUMABLE": text("Habis pakai", "Consumable"),
+
+	"location.room":    text("ruang %s", "room %s"),
+	"location.cabinet": text("lemari %s", "cabinet %s"),
+	"location.shelf":   text("rak %s", "shelf %s"),
+
+	"tool.not_available": text("Maaf, nomor alat yang Anda pilih tidak tersedia.", "Sorry, the tool number you chose is not available."),
+	"tool.id_not_found":  text("ID tidak ditemukan.", "ID not found."),
+	"tool.out_of_stock":  text(" (stok kosong)", " (out of stock)"),
+	"check.empty":        text("Tidak ada barang yang tersedia.", "No tools are available."),
+	"check.list": text(
+		"Berikut ini daftar alat yang masih tersedia.\nuntuk melihat detail alat, ketik perintah \"/%s [id]\"\nuntuk mencari alat, ketik perintah \"/%s [kata kunci]\"\n\n",
+		"Here are the tools that are still available.\nTo see the details of a tool, type \"/%s [id]\"\nTo search for a tool, type \"/%s [keyword]\"\n\n",
+	),
+	"check.search_title": text("Hasil pencarian \"%s\"", "Search results for \"%s\""),
+	"check.search_hint":  text("Pilih alat untuk melihat detailnya.", "Choose a tool to see its details."),
+	"check.search_empty": text("Tidak ada alat yang cocok dengan \"%s\".", "No tools match \"%s\"."),
+	"check.no_photo":     text("Foto tidak tersedia untuk barang ini.", "No photos are available for this tool."),
+
+	"check.category_menu":      text("Silahkan pilih kategori alat.", "Please choose a tool category."),
+	"check.category_empty":     text("Belum ada kategori alat.", "There are no tool categories yet."),
+	"check.category_not_found": text("Kategori tidak ditemukan.", "Category not found."),
+	"check.category_no_tools":  text("Belum ada alat yang tersedia pada kategori ini.", "No tools are available in this category yet."),
+	"check.tag_title":          text("Alat dengan tag \"%s\"", "Tools tagged \"%s\""),
+	"check.tag_empty":          text("Tidak ada alat yang tersedia dengan tag \"%s\".", "No available tools are tagged \"%s\"."),
+
+	"inline.description": text("%s %s · stok %d", "%s %s · %d in stock"),
+	"inline.empty":       text("Tidak ada alat yang cocok, buka bot", "No tools match, open the bot"),
+
+	"register.recommend":           text("Silahkan registrasi dengan mengetik `/%s` untuk dapat menggunakan sistem ini secara penuh.", "Please register by typing `/%s` to use this system fully."),
+	"register.not_registered":      text("Maaf, Anda belum terdaftar kedalam sistem. Silahkan registrasi dengan cara ketik `/%s`.", "Sorry, you are not registered yet. Please register by typing `/%s`."),
+	"register.already":             text("Tidak bisa melakukan registrasi, Anda sudah terdaftar ke dalam sistem pada %s", "You cannot register, you have been registered since %s"),
+	"register.admin":               text("Pengurus tidak bisa melakukan registrasi.", "Admins cannot register."),
+	"register.ask_name":            text("Silahkan tulis nama lengkap Anda.", "Please type your full name."),
+	"register.ask_nim":             text("Berapa Nomor Induk Mahasiswa (NIM) Anda?\n\nContoh: 21120116130000", "What is your student ID number (NIM)?\n\nExample: 21120116130000"),
+	"register.ask_batch":           text("Anda mahasiswa angkatan tahun berapa?\n\nContoh: 2016", "Which batch year are you in?\n\nExample: 2016"),
+	"register.ask_address":         text("Silahkan tulis alamat lengkap tempat tinggal Anda sekarang.", "Please type your full current address."),
+	"register.ask_phone":           text("Bagikan nomor telepon Anda dengan tombol di bawah agar pengurus dapat menghubungi Anda, atau pilih \"Lewati\" bila tidak ingin membagikannya.", "Share your phone number with the button below so the admins can contact you, or choose \"Skip\" if you prefer not to."),
+	"register.summary":             text("Data registrasi Anda sudah lengkap.", "Your registration details are complete."),
+	"register.edit_field":          text("Ubah %s", "Change %s"),
+	"register.confirm":             text("Apakah Anda yakin data ini sudah benar?", "Are you sure this data is correct?"),
+	"register.complete":            text("Selamat! Anda telah terdaftar dan dapat menggunakan sistem ini.\n\nSilahkan ketik `/%s` untuk bantuan.", "Congratulations! You are registered and can use this system.\n\nType `/%s` for help."),
+	"register.cancelled":           text("Registrasi dibatalkan.", "Registration cancelled."),
+	"register.invalid_batch":       text("data tahun angkatan salah", "the batch year is not valid"),
+	"register.batch_out_of_range":  text("data angkatan melebihi batas", "the batch year is out of range"),
+	"register.name_too_short":      plural("data nama minimal %d karakter", "the name must be at least %d character", "the name must be at least %d characters"),
+	"register.invalid_nim":         text("NIM tidak valid", "the NIM is not valid"),
+	"register.waiting_approval":    text("Registrasi Anda telah tersimpan dan sedang menunggu persetujuan pengurus. Anda akan diberi kabar setelah registrasi disetujui.", "Your registration has been saved and is waiting for the approval of an admin. You will be notified once it is approved."),
+	"register.rejected":            text("Registrasi Anda ditolak oleh pengurus sehingga Anda belum dapat meminjam barang. Silahkan hubungi pengurus laboratorium.", "Your registration has been rejected by an admin, so you cannot borrow tools. Please contact the laboratory admins."),
+	"register.notify_admin":        text("Registrasi baru dari %s (NIM %s, angkatan %d) menunggu persetujuan.", "A new registration from %s (NIM %s, batch %d) is waiting for approval."),
+	"register.nim_batch_mismatch":  text("tahun angkatan pada NIM tidak sesuai dengan data angkatan", "the year in the NIM does not match the batch"),
+	"register.nim_taken":           text("NIM sudah digunakan oleh akun lain, hubungi pengurus bila NIM tersebut milik Anda", "the NIM is used by another account, contact an admin if it is yours"),
+	"register.nim_review":          text("NIM tersebut sudah terdaftar pada akun lain. Registrasi Anda akan diperiksa oleh pengurus terlebih dahulu, Anda akan diberi kabar setelah diperiksa.", "That NIM is already registered to another account. An admin will review your registration first, you will be notified once it is reviewed."),
+	"register.nim_review_pending":  text("Registrasi Anda masih diperiksa oleh pengurus karena NIM sudah terdaftar pada akun lain.", "Your registration is still being reviewed by an admin because the NIM is registered to another account."),
+	"register.nim_collision_admin": text("%s mendaftar dengan NIM %s yang sudah digunakan oleh %s (terdaftar %s).", "%s registered with the NIM %s, which is already used by %s (registered on %s)."),
+	"register.nim_approved":        text("Registrasi Anda telah disetujui oleh pengurus dan Anda dapat menggunakan sistem ini.\n\nSilahkan ketik `/%s` untuk bantuan.", "Your registration has been approved by an admin and you can use this system.\n\nType `/%s` for help."),
+	"register.nim_moved":           text("NIM %s telah dipindahkan oleh pengurus ke akun lain. Silahkan perbarui NIM Anda dengan perintah \"/%s\", lalu tunggu persetujuan pengurus sebelum meminjam kembali.", "The NIM %s has been moved to another account by an admin. Please update your NIM with \"/%s\", then wait for the approval of an admin before borrowing again."),
+	"register.nim_rejected":        text("Registrasi dengan NIM %s ditolak oleh pengurus karena NIM tersebut milik akun lain.", "Your registration with the NIM %s has been rejected by an admin because the NIM belongs to another account."),
+	"register.phone_not_own":       text("nomor telepon yang dibagikan harus milik akun Telegram Anda sendiri", "the shared phone number must belong to your own Telegram account"),
+	"register.phone_use_button":    text("gunakan tombol di bawah untuk membagikan nomor telepon atau melewatinya", "use the buttons below to share your phone number or skip it"),
+	"register.address_too_short":   plural("data alamat minimal %d karakter", "the address must be at least %d character", "the address must be at least %d characters"),
+
+	"borrow.admin":                 text("Pengurus tidak dapat melakukan peminjaman barang.", "Admins cannot borrow tools."),
+	"borrow.mechanism.title":       text("Mekanisme Peminjaman", "How to Borrow"),
+	"borrow.mechanism.step_check":  text("Cek ketersediaan alat dengan mengetik /%s", "Check the availability of tools by typing /%s"),
+	"borrow.mechanism.step_borrow": text("Ketik perintah \"/%s [id]\", dimana id adalah nomor unik alat yang akan dipinjam", "Type \"/%s [id]\", where id is the unique number of the tool you want to borrow"),
+	"borrow.mechanism.example":     text("Contoh: ", "Example: "),
+	"borrow.suspended":             text("Maaf, hak peminjaman Anda sedang ditangguhkan oleh pengurus.\nAlasan: %s", "Sorry, your borrowing rights have been suspended by an admin.\nReason: %s"),
+	"borrow.out_of_stock":          text("Stok barang sudah habis. Tidak dapat melakukan pengajuan peminjaman.", "The tool is out of stock. You cannot request to borrow it."),
+	"borrow.already_requested":     text("Maaf, Anda sudah mengajukan peminjaman barang yang sama, silahkan tunggu hingga pengurus menanggapi pengajuan tersebut.", "Sorry, you have already requested to borrow the same tool, please wait until an admin responds to the request."),
+	"borrow.already_borrowed": text(
+		"Maaf, Anda sedang meminjam barang yang sama sehingga tidak dapat mengajukan peminjaman.\nUntuk melakukan pengembalian silahkan ketik \"/%s\"",
+		"Sorry, you are currently borrowing the same tool so you cannot request it again.\nTo return it, type \"/%s\"",
+	),
+	"borrow.ask_amount":         text("Berapa jumlah yang ingin dipinjam?\n\nJika tidak ada dalam pilihan, maka sebutkan dalam angka (min. 1).", "How many do you want to borrow?\n\nIf it is not in the options, write it as a number (min. 1)."),
+	"borrow.invalid_amount":     text("Mohon sebutkan jumlah barang dalam angka. Minimal 1.", "Please write the amount as a number. Minimum 1."),
+	"borrow.exceeds_stock":      text("Tidak bisa meminjam barang melebihi stok yang ada. Stok saat ini %d", "You cannot borrow more than the available stock. The current stock is %d"),
+	"borrow.ask_duration":       text("Berapa lama waktu peminjaman?\n\nJika tidak ada dalam pilihan, maka sebutkan jumlah hari. Minimal durasi peminjaman adalah %s.", "How long do you want to borrow it?\n\nIf it is not in the options, write the number of days. The minimum duration is %s."),
+	"borrow.duration.one_week":  text("1 Minggu", "1 Week"),
+	"borrow.duration.two_week":  text("2 Minggu", "2 Weeks"),
+	"borrow.duration.one_month": text("1 Bulan", "1 Month"),
+	"borrow.duration.two_month": text("2 Bulan", "2 Months"),
+	"borrow.invalid_duration":   text("Mohon sebutkan jumlah hari.", "Please write the number of days."),
+	"borrow.duration_too_short": text("Minimal durasi peminjaman adalah %s", "The minimum duration is %s"),
+	"borrow.ask_reason":         text("Apa alasan Anda meminjam barang ini?", "Why do you want to borrow this tool?"),
+	"borrow.summary.title":      text("Ringkasan Pengajuan Peminjaman", "Borrowing Request Summary"),
+	"borrow.summary.confirm":    text("Pastikan data sudah benar. Tekan \"Lanjutkan\" untuk mengajukan ke pengurus.", "Make sure the data is correct. Press \"Continue\" to send the request to the admins."),
+	"borrow.cancelled":          text("Pengajuan dibatalkan", "Request cancelled"),
+	"borrow.requested":          text("Pengajuan peminjaman berhasil, silahkan tunggu hingga pengurus menanggapi pengajuan.", "Your borrowing request has been sent, please wait until an admin responds to it."),
+	"consume.ask_amount":        text("Berapa jumlah yang dibutuhkan?\n\nJika tidak ada dalam pilihan, maka sebutkan dalam angka (min. 1).", "How many do you need?\n\nIf it is not in the options, write it as a number (min. 1)."),
+	"consume.exceeds_stock":     text("Tidak bisa meminta barang melebihi stok yang ada. Stok saat ini %d", "You cannot request more than the available stock. The current stock is %d"),
+	"consume.ask_reason":        text("Untuk keperluan apa barang ini digunakan?", "What will you use it for?"),
+	"consume.summary.title":     text("Ringkasan Permintaan Barang Habis Pakai", "Consumable Request Summary"),
+	"consume.summary.notice":    text("Barang habis pakai tidak perlu dikembalikan.", "Consumables do not need to be returned."),
+	"consume.requested":         text("Permintaan barang berhasil diajukan, silahkan tunggu hingga pengurus menanggapi pengajuan.", "Your request has been sent, please wait until an admin responds to it."),
+	"consume.notify_admin": text(
+		"Seseorang baru saja meminta barang habis pakai\n\nNama Pemohon: %s\nBarang: %s\nJumlah: %s",
+		"Someone has just requested a consumable\n\nRequester: %s\nTool: %s\nAmount: %s",
+	),
+	"borrow.notify_admin": text(
+		"Seseorang baru saja mengajukan peminjaman barang\n\nNama Pemohon: %s\nBarang: %s",
+		"Someone has just requested to borrow a tool\n\nRequester: %s\nTool: %s",
+	),
+
+	"return.admin":            text("Pengurus tidak dapat melakukan pengembalian barang.", "Admins cannot return tools."),
+	"return.nothing_borrowed": text("Saat ini tidak ada alat yang sedang Anda pinjam.", "You are not borrowing any tools at the moment."),
+	"return.borrowed_list": text(
+		"Berikut ini daftar alat yang sedang Anda pinjam.\n\n%s\nUntuk mengajukan pengembalian ketik perintah\n\"/%s [id_peminjaman]\"\n\n",
+		"Here are the tools you are currently borrowing.\n\n%s\nTo request a return, type\n\"/%s [borrow_id]\"\n\n",
+	),
+	"return.borrow_not_found":  text("ID peminjaman tidak ditemukan.", "Borrow ID not found."),
+	"return.already_requested": text("Maaf, Anda sudah mengajukan pengembalian barang yang sama. Silahkan tunggu hingga pengurus menanggapi pengajuan tersebut.", "Sorry, you have already requested to return the same tool. Please wait until an admin responds to the request."),
+	"return.ask_info":          text("Tulis keterangan pengembalian. Dapat berupa kondisi barang, alasan pengembalian, dsb.", "Describe the return, e.g. the condition of the tool, the reason for returning it, etc."),
+	"return.summary.title":     text("Ringkasan Pengajuan Pengembalian", "Returning Request Summary"),
+	"return.cancelled":         text("Pengajuan pengembalian dibatalkan.", "Return request cancelled."),
+	"return.requested":         text("Pengajuan pengembalian berhasil, silahkan tunggu hingga pengurus menanggapi pengajuan tersebut.", "Your return request has been sent, please wait until an admin responds to it."),
+	"return.notify_admin": text(
+		"Seseorang baru saja mengajukan pengembalian barang\n\nNama Pemohon: %s\nBarang: %s",
+		"Someone has just requested to return a tool\n\nRequester: %s\nTool: %s",
+	),
+
+	"admin.already":            text("Anda sudah terdaftar menjadi pengurus sebelumnya.", "You are already an admin."),
+	"admin.success":            text("%s berhasil menjadi pengurus.", "%s is now an admin."),
+	"admin.usage":              text("Ketik \"/%s [kode undangan]\" dengan kode dari pengurus, atau \"/%s\" untuk meminta persetujuan pengurus.", "Type \"/%s [invitation code]\" with a code from an admin, or \"/%s\" to ask the admins for approval."),
+	"admin.invitation_created": text("Kode undangan pengurus %s: %s\nKode hanya dapat digunakan oleh satu orang dalam %d jam.\n\nCalon pengurus dapat bergabung dengan mengetik \"/%s %s\" di grup pengurus laboratorium.", "Admin invitation code for %s: %s\nThe code can be used by one person within %d hours.\n\nThe new admin can join by typing \"/%s %s\" in the admin group of the laboratory."),
+	"admin.invitation_sent":    text("Kode undangan pengurus telah dikirim kepada Anda secara pribadi.", "The admin invitation code has been sent to you privately."),
+	"admin.invitation_unsent":  text("Kode undangan tidak dapat dikirim secara pribadi. Mulai percakapan pribadi dengan bot terlebih dahulu, lalu coba lagi.", "The invitation code could not be sent privately. Start a private chat with the bot first, then try again."),
+	"admin.invitation_invalid": text("Kode undangan tidak valid, sudah digunakan, atau sudah kedaluwarsa.", "The invitation code is not valid, has been used or has expired."),
+	"admin.request_sent":       text("%s meminta untuk menjadi pengurus. Pengurus lain dapat menyetujui atau menolak permintaan ini.", "%s asks to become an admin. Another admin can approve or reject this request."),
+	"admin.request_pending":    text("Permintaan Anda menjadi pengurus masih menunggu persetujuan.", "Your request to become an admin is still waiting for approval."),
+	"admin.request_rejected":   text("Permintaan %s menjadi pengurus ditolak.", "The request of %s to become an admin has been rejected."),
+	"admin.list": text(
+		"Daftar Pengurus\n%s\nPermintaan Menjadi Pengurus\n%s\nKetik `/%s %s` untuk membuat kode undangan atau `/%s %s @username` untuk mencabut pengurus.",
+		"Admins\n%s\nRequests to Become an Admin\n%s\nType `/%s %s` to create an invitation code or `/%s %s @username` to revoke an admin.",
+	),
+	"admin.revoke_not_found": text("Pengurus tidak ditemukan.", "Admin not found."),
+	"admin.revoke_self":      text("Anda tidak dapat mencabut diri sendiri sebagai pengurus.", "You cannot revoke yourself as an admin."),
+	"admin.revoked":          text("%s tidak lagi menjadi pengurus.", "%s is no longer an admin."),
+
+	"role.kepala":   text("Kepala Laboratorium", "Head of Laboratory"),
+	"role.asisten":  text("Asisten Laboratorium", "Laboratory Assistant"),
+	"role.dosen":    text("Dosen", "Lecturer"),
+	"role.pengamat": text("Pengamat", "Viewer"),
+	"role.list": text(
+		"Daftar Peran\n%s\nPengurus\n%s\nKetik `/%s [@username/id] [peran]` untuk mengganti peran pengurus.\ncontoh: `/%s @budi dosen`",
+		"Roles\n%s\nAdmins\n%s\nType `/%s [@username/id] [role]` to change the role of an admin.\nexample: `/%s @budi dosen`",
+	),
+	"role.not_found": text("Peran tidak tersedia. Pilihan peran: %s", "The role is not available. The roles are: %s"),
+	"role.self":      text("Anda tidak dapat mengganti peran Anda sendiri.", "You cannot change your own role."),
+	"role.changed":   text("Peran %s diganti menjadi %s.", "The role of %s has been changed to %s."),
+
+	"permission.borrow.approve": text("Menanggapi peminjaman dan pengembalian", "Respond to borrowing and returning requests"),
+	"permission.user.approve":   text("Menanggapi registrasi mahasiswa", "Respond to student registrations"),
+	"permission.user.manage":    text("Menangguhkan dan menghapus mahasiswa", "Suspend and delete students"),
+	"permission.tool.view":      text("Melihat barang yang tidak tersedia", "View the tools which are not available"),
+	"permission.tool.manage":    text("Mengelola barang dan perawatannya", "Manage the tools and their maintenance"),
+	"permission.report.view":    text("Melihat laporan dan statistik", "View the reports and statistics"),
+	"permission.admin.manage":   text("Mengundang, mencabut, dan mengganti peran pengurus", "Invite and revoke admins and change their roles"),
+	"permission.audit.view":     text("Melihat jejak audit pengurus", "View the audit trail of the admins"),
+	"permission.denied":         text("Maaf, peran Anda (%s) tidak memiliki izin untuk perintah ini.", "Sorry, your role (%s) does not have the permission for this command."),
+
+	"user.overview.title":          text("Pengguna", "Users"),
+	"user.overview.suspended":      text("Mahasiswa yang ditangguhkan", "Suspended students"),
+	"user.overview.none_suspended": text("Tidak ada mahasiswa yang ditangguhkan.", "No student is suspended."),
+	"user.overview.how_to":         text("Cari mahasiswa berdasarkan nama, NIM, atau angkatan: \"/%s %s [kata kunci]\"\nLihat detail mahasiswa: \"/%s [id]\"", "To search students by name, NIM or batch: \"/%s %s [keyword]\"\nTo view a student: \"/%s [id]\""),
+	"user.search_how_to":           text("Untuk mencari mahasiswa silahkan kirim perintah\n\"/%s %s [nama/NIM/angkatan]\"", "To search students, send the command\n\"/%s %s [name/NIM/batch]\""),
+	"user.search_result":           text("Hasil pencarian \"%s\"\n%s\nKetik \"/%s [id]\" untuk melihat detail mahasiswa.", "Search results for \"%s\"\n%s\nType \"/%s [id]\" to view a student."),
+	"user.search_empty":            text("Tidak ada mahasiswa yang cocok dengan \"%s\".", "No student matches \"%s\"."),
+	"user.not_found":               text("Mahasiswa tidak ditemukan.", "Student not found."),
+	"user.suspended_mark":          text("ditangguhkan", "suspended"),
+	"user.status.PENDING":          text("Menunggu persetujuan", "Waiting for approval"),
+	"user.status.APPROVED":         text("Disetujui", "Approved"),
+	"user.status.REJECTED":         text("Ditolak", "Rejected"),
+	"user.detail.status":           text("Status registrasi", "Registration status"),
+	"user.detail.suspended_at":     text("Ditangguhkan sejak", "Suspended since"),
+	"user.detail.borrows":          text("Jumlah peminjaman", "Borrows"),
+	"user.detail.late":             text("Terlambat dikembalikan", "Returned late"),
+	"user.detail.latest":           text("Peminjaman terakhir", "Latest borrows"),
+	"user.detail.no_borrows":       text("Belum pernah meminjam barang.", "Has not borrowed any tool yet."),
+	"user.button.suspend":          text("Tangguhkan Peminjaman", "Suspend Borrowing"),
+	"user.button.unsuspend":        text("Pulihkan Peminjaman", "Restore Borrowing"),
+	"user.suspend_how_to":          text("Untuk menangguhkan peminjaman %s silahkan kirim perintah\n\"/%s %d %s [alasan]\"", "To suspend the borrowing of %s, send the command\n\"/%s %d %s [reason]\""),
+	"user.suspended":               text("Peminjaman %s ditangguhkan.", "%s can no longer borrow tools."),
+	"user.unsuspended":             text("%s dapat meminjam barang kembali.", "%s can borrow tools again."),
+	"user.not_suspended":           text("%s tidak sedang ditangguhkan.", "%s is not suspended."),
+	"user.notify_suspended":        text("Hak peminjaman Anda ditangguhkan oleh pengurus.\nAlasan: %s", "Your borrowing rights have been suspended by an admin.\nReason: %s"),
+	"user.notify_unsuspended":      text("Hak peminjaman Anda telah dipulihkan. Anda dapat meminjam barang kembali.", "Your borrowing rights have been restored. You can borrow tools again."),
+	"user.delete_confirm":          text("Hapus %s? NIM, alamat dan nomor telepon akan dihapus, sedangkan riwayat peminjamannya tetap tersimpan untuk laporan.", "Delete %s? The NIM, address and phone number will be removed, while the borrowing history is kept for the reports."),
+	"user.delete_active":           text("%s masih memiliki peminjaman yang belum selesai sehingga tidak dapat dihapus.", "%s still has unfinished borrows and cannot be deleted."),
+	"user.delete_admin":            text("%s adalah pengurus. Cabut terlebih dahulu melalui \"/%s %s\".", "%s is an admin. Revoke the admin first with \"/%s %s\"."),
+	"user.deleted":                 text("%s berhasil dihapus.", "%s has been deleted."),
+	"user.delete_cancelled":        text("Penghapusan %s dibatalkan.", "Deleting %s has been cancelled."),
+
+	"audit.title":  text("Jejak Audit", "Audit Trail"),
+	"audit.empty":  text("Belum ada tindakan pengurus yang tercatat.", "No admin action has been recorded yet."),
+	"audit.failed": text("Perubahan sudah tersimpan, tetapi tidak dapat dicatat di jejak audit. Silahkan laporkan kepada pengelola bot.", "The change has been saved but could not be written to the audit trail. Please report it to the maintainer of the bot."),
+	"audit.usage": text(
+		"Ketik \"/%s [entitas] [id]\" untuk melihat riwayat sebuah data atau \"/%s %s [@username/id]\" untuk melihat tindakan seorang pengurus.\nEntitas: %s",
+		"Type \"/%s [entity] [id]\" to view the history of a record or \"/%s %s [@username/id]\" to view the actions of an admin.\nEntities: %s",
+	),
+	"audit.line":                  text("[%d] %s - %s %s %s #%d", "[%d] %s - %s %s %s #%d"),
+	"audit.action.create":         text("membuat", "created"),
+	"audit.action.update":         text("mengubah", "updated"),
+	"audit.action.delete":         text("menghapus", "deleted"),
+	"audit.action.approve":        text("menyetujui", "approved"),
+	"audit.action.reject":         text("menolak", "rejected"),
+	"audit.action.suspend":        text("menangguhkan", "suspended"),
+	"audit.action.resume":         text("memulihkan", "lifted the suspension of"),
+	"audit.action.revoke":         text("mencabut", "revoked"),
+	"audit.action.role":           text("mengganti peran", "changed the role of"),
+	"audit.action.claim":          text("mengambil alih", "claimed"),
+	"audit.entity.tool":           text("barang", "tool"),
+	"audit.entity.category":       text("kategori", "category"),
+	"audit.entity.maintenance":    text("catatan perawatan", "maintenance record"),
+	"audit.entity.borrow":         text("peminjaman", "borrow"),
+	"audit.entity.tool_returning": text("pengembalian", "return"),
+	"audit.entity.user":           text("pengguna", "user"),
+	"audit.entity.nim_collision":  text("registrasi NIM ganda", "duplicate NIM registration"),
+	"audit.entity.admin":          text("pengurus", "admin"),
+	"audit.entity.admin_request":  text("permintaan pengurus", "admin request"),
+	"audit.entity.invitation":     text("undangan", "invitation"),
+	"audit.entity.lab":            text("laboratorium", "laboratory"),
+
+	"respond.invalid_option": text("Maaf, perintah tidak dikenali. Pilihan yang tersedia adalah \"yes\" dan \"no\"", "Sorry, the command is not recognized. The available options are \"yes\" and \"no\""),
+	"respond.list": text(
+		"Daftar Pengajuan Peminjaman\n%s\nDaftar Pengajuan Pengembalian\n%s\nDaftar Registrasi Baru\n%s\nDaftar Registrasi NIM Ganda\n%s\n\nUntuk menanggapi pengajuan ketik perintah \"/%s [pinjam/kembali/registrasi/nim] [id]\"\ncontoh: \"/%s pinjam 173\"",
+		"Borrowing Requests\n%s\nReturning Requests\n%s\nNew Registrations\n%s\nRegistrations with a Duplicate NIM\n%s\n\nTo respond to a request, type \"/%s [pinjam/kembali/registrasi/nim] [id]\"\nexample: \"/%s pinjam 173\"",
+	),
+	"respond.none":                text("- tidak ada\n", "- none\n"),
+	"respond.not_found":           text("Gagal menanggapi, ID tidak ditemukan.", "Failed to respond, ID not found."),
+	"respond.exceeds_stock":       text("Jumlah yang dipinjam melebihi stok yang ada. Stok saat ini %d", "The borrowed amount exceeds the available stock. The current stock is %d"),
+	"respond.ask_description":     text("Tuliskan keterangan tambahan.", "Write an additional description."),
+	"respond.borrow_detail.title": text("Pengajuan Peminjaman #%d", "Borrowing Request #%d"),
+	"respond.borrow_approved_user": text(
+		"Pengajuan peminjaman \"%s\" telah disetujui oleh pengurus.\nBatas akhir peminjaman: %s (%s)\n\nKeterangan:\n%s",
+		"Your request to borrow \"%s\" has been approved by an admin.\nReturn deadline: %s (%s)\n\nDescription:\n%s",
+	),
+	"respond.consume_approved_user":     text("Permintaan %s \"%s\" telah disetujui oleh pengurus dan tidak perlu dikembalikan.\n\nKeterangan:\n%s", "Your request for %s of \"%s\" has been approved by an admin and does not need to be returned.\n\nDescription:\n%s"),
+	"respond.consume_approved":          text("Permintaan barang habis pakai berhasil disetujui.", "The consumable request has been approved."),
+	"respond.low_stock":                 text("Stok %s tinggal %d, sudah mencapai batas minimum %d.", "%s has only %d left, which has reached the minimum of %d."),
+	"respond.borrow_approved":           text("Pengajuan peminjaman berhasil disetujui.", "The borrowing request has been approved."),
+	"respond.borrow_rejected_user":      text("Pengajuan peminjaman \"%s\" telah ditolak oleh pengurus.\n\nKeterangan:\n%s", "Your request to borrow \"%s\" has been rejected by an admin.\n\nDescription:\n%s"),
+	"respond.borrow_rejected":           text("Pengajuan peminjaman berhasil ditolak.", "The borrowing request has been rejected."),
+	"respond.return_detail.title":       text("Pengajuan Pengembalian #%d", "Returning Request #%d"),
+	"respond.return_approved_user":      text("Pengajuan pengembalian \"%s\" telah disetujui oleh pengurus.\n\nKeterangan:\n%s", "Your request to return \"%s\" has been approved by an admin.\n\nDescription:\n%s"),
+	"respond.return_approved":           text("Pengajuan pengembalian berhasil disetujui.", "The returning request has been approved."),
+	"respond.return_rejected_user":      text("Pengajuan pengembalian \"%s\" telah ditolak oleh pengurus.\n\nKeterangan:\n%s", "Your request to return \"%s\" has been rejected by an admin.\n\nDescription:\n%s"),
+	"respond.registration_detail.title": text("Registrasi Baru #%d", "New Registration #%d"),
+	"respond.registration_approved":     text("Registrasi %s berhasil disetujui.", "The registration of %s has been approved."),
+	"respond.registration_rejected":     text("Registrasi %s berhasil ditolak.", "The registration of %s has been rejected."),
+	"respond.nim_detail.title":          text("Registrasi NIM Ganda #%d", "Duplicate NIM Registration #%d"),
+	"respond.nim_detail.registrant":     text("Akun baru", "New account"),
+	"respond.nim_detail.existing":       text("Akun yang sudah terdaftar", "Registered account"),
+	"respond.nim_detail.hint":           text("Setujui untuk memindahkan NIM ke akun baru, NIM akun lama akan dikosongkan. Tolak bila NIM tetap milik akun lama.", "Approve to move the NIM to the new account, the NIM of the old account is cleared. Reject when the NIM stays with the old account."),
+	"respond.nim_approved":              text("NIM berhasil dipindahkan ke akun baru.", "The NIM has been moved to the new account."),
+	"respond.nim_rejected":              text("Registrasi dengan NIM ganda berhasil ditolak.", "The registration with a duplicate NIM has been rejected."),
+	"respond.return_rejected":           text("Pengajuan pengembalian berhasil ditolak", "The returning request has been rejected"),
+
+	"manage.menu":           text("Silahkan pilih menu pengelolaan.", "Please choose a management menu."),
+	"manage.menu.add":       text("Tambah Barang", "Add Tool"),
+	"manage.menu.edit":      text("Edit Barang", "Edit Tool"),
+	"manage.menu.category":  text("Tambah Kategori", "Add Category"),
+	"manage.edit_how_to":    text("Untuk melakukan pengubahan data silahkan kirim perintah\n\"/%s %s [id_barang]\"\n\nContoh: \"/%s %s 5\"", "To edit a tool, send the command\n\"/%s %s [tool_id]\"\n\nExample: \"/%s %s 5\""),
+	"manage.delete_how_to":  text("Untuk melakukan penghapusan barang silahkan kirim perintah\n\"/%s %s [id_barang]\"\n\nContoh: \"/%s %s 5\"", "To delete a tool, send the command\n\"/%s %s [tool_id]\"\n\nExample: \"/%s %s 5\""),
+	"manage.tool_not_found": text("ID barang tidak ditemukan.", "Tool ID not found."),
+	"manage.ask_photo":      text("Silahkan upload foto barang. (minimal 1, maksimal 10)", "Please upload the photos of the tool. (minimum 1, maximum 10)"),
+	"manage.photo_only":     text("File selain foto tidak akan disimpan. Mohon upload foto barang.", "Files other than photos will not be saved. Please upload the photos of the tool."),
+
+	"manage.add.ask_name":       text("Memulai Sesi Penambahan Barang\n\nTulis nama barang", "Adding a New Tool\n\nWrite the name of the tool"),
+	"manage.add.ask_brand":      text("Tuliskan merk/brand", "Write the brand"),
+	"manage.add.ask_type":       text("Tuliskan tipe barang/alat", "Write the type of the tool"),
+	"manage.add.ask_weight":     text("Berapa berat alat tersebut? (dalam gram)", "How heavy is the tool? (in grams)"),
+	"manage.add.invalid_weight": text("Mohon sebutkan berat dalam angka. Minimal 0.1 gram.", "Please write the weight as a number. Minimum 0.1 grams."),
+	"manage.add.ask_stock":      text("Berapa banyak stok yang tersedia untuk dipinjamkan? Minimal 1", "How many are available to be borrowed? Minimum 1"),
+	"manage.add.invalid_stock":  text("Mohon sebutkan jumlah stok dalam angka. Minimal 1.", "Please write the stock as a number. Minimum 1."),
+	"manage.add.ask_info":       text("Tuliskan deskripsi lengkap mengenai alat ini", "Write a complete description of the tool"),
+	"manage.add.summary.title":  text("Ringkasan Barang Baru", "New Tool Summary"),
+	"manage.add.cancelled":      text("Penambahan barang dibatalkan.", "Adding the tool has been cancelled."),
+	"manage.add.success":        text("Barang berhasil ditambah dengan ID %d", "The tool has been added with ID %d"),
+
+	"manage.edit.ask_field":            text("Memulai Sesi Pengubahan Barang\n\nSilahkan pilih kolom data yang ingin diubah", "Editing a Tool\n\nPlease choose the field you want to change"),
+	"manage.edit.field_not_available":  text("Kolom data tidak tersedia. Silahkan pilih kolom data yang akan diubah melalui pilihan menu.", "The field is not available. Please choose the field to change from the menu."),
+	"manage.edit.ask_value":            text("Data sebelumnya:\n%s\n\nSilahkan tulis data baru", "Previous value:\n%s\n\nPlease write the new value"),
+	"manage.edit.weight_not_number":    text("mohon sebutkan berat dalam angka", "please write the weight as a number"),
+	"manage.edit.stock_not_number":     text("mohon sebutkan jumlah stok dalam angka", "please write the stock as a number"),
+	"manage.edit.failed":               text("Terjadi kesalahan. Barang dengan ID %d gagal diubah.", "Something went wrong. The tool with ID %d could not be changed."),
+	"manage.edit.success":              text("Barang dengan ID %d berhasil diubah", "The tool with ID %d has been changed"),
+	"manage.edit.category_hint":        text("Tulis jalur kategori, misalnya \"Elektronika/Alat Ukur\", atau \"-\" untuk mengosongkan. Kategori baru dapat dibuat dengan perintah \"/%s %s [jalur]\".", "Write the category path, e.g. \"Electronics/Measuring\", or \"-\" to clear it. New categories can be created with \"/%s %s [path]\"."),
+	"manage.edit.category_not_found":   text("Kategori tidak ditemukan. Buat kategori terlebih dahulu dengan perintah \"/%s %s [jalur]\".", "Category not found. Create the category first with \"/%s %s [path]\"."),
+	"manage.edit.tags_hint":            text("Pisahkan tag dengan koma, atau tulis \"%s\" untuk mengosongkan.", "Separate the tags with commas, or write \"%s\" to clear them."),
+	"manage.edit.tag_too_long":         text("mohon singkat setiap tag menjadi paling banyak %d karakter", "please shorten each tag to at most %d characters"),
+	"manage.edit.kind_hint":            text("Pilih jenis barang. Barang habis pakai tidak perlu dikembalikan setelah diminta.", "Choose the kind of the tool. Consumables do not need to be returned after being requested."),
+	"manage.edit.kind_invalid":         text("mohon pilih jenis barang yang tersedia", "please choose one of the kinds"),
+	"manage.edit.min_stock_hint":       text("Pengurus diingatkan saat stok barang habis pakai mencapai jumlah ini. Tulis 0 untuk menonaktifkan.", "Admins are warned when the stock of a consumable reaches this number. Write 0 to turn it off."),
+	"manage.edit.min_stock_not_number": text("mohon sebutkan stok minimum dalam angka", "please write the minimum stock as a number"),
+	"manage.edit.optional_hint":        text("Tulis \"%s\" untuk mengosongkan.", "Write \"%s\" to clear it."),
+
+	"manage.category.how_to": text("Untuk menambah kategori silahkan kirim perintah\n\"/%s %s [jalur kategori]\"\n\nSubkategori dipisahkan dengan \"/\", contoh: \"/%s %s Elektronika/Alat Ukur\"", "To add a category, send the command\n\"/%s %s [category path]\"\n\nSubcategories are separated by \"/\", e.g. \"/%s %s Electronics/Measuring\""),
+	"manage.category.saved":  text("Kategori \"%s\" berhasil disimpan.", "The category \"%s\" has been saved."),
+
+	"manage.delete.ask_confirmation": text("Apakah Anda yakin ingin menghapus %s?", "Are you sure you want to delete %s?"),
+	"manage.delete.cancelled":        text("Penghapusan barang dibatalkan.", "Deleting the tool has been cancelled."),
+	"manage.delete.success":          text("Barang dengan ID %d berhasil dihapus.", "The tool with ID %d has been deleted."),
+
+	"manage.photo.uploaded":  text("Foto berhasil diunggah.", "The photos have been uploaded."),
+	"manage.photo.cancelled": text("Perubahan foto barang dibatalkan.", "Changing the photos has been cancelled."),
+	"manage.photo.success":   text("Foto barang berhasil diubah.", "The photos of the tool have been changed."),
+
+	"report.menu":                text("Silahkan pilih menu laporan.", "Please choose a report."),
+	"report.menu.borrow":         text("Peminjaman", "Borrowing"),
+	"report.menu.tool_returning": text("Pengembalian", "Returning"),
+	"report.menu.consumption":    text("Pemakaian Barang Habis Pakai", "Consumable Usage"),
+	"report.this_month":          text("Laporan Bulan Ini", "This Month's Report"),
+	"report.last_month":          text("Laporan Bulan Kemarin", "Last Month's Report"),
+	"report.this_semester":       text("Laporan Semester Ini", "This Semester's Report"),
+	"report.this_year":           text("Laporan Tahun Ini", "This Year's Report"),
+	"report.period_hint": text(
+		"\nLaporan juga dapat dilihat per semester, per tahun, atau untuk rentang tanggal tertentu\n\"/%s %s 2021-s1\"\n\"/%s %s 2021\"\n\"/%s %s 2021-01-01..2021-06-30\"\n",
+		"\nThe report can also be viewed per semester, per year, or for a range of dates\n\"/%s %s 2021-s1\"\n\"/%s %s 2021\"\n\"/%s %s 2021-01-01..2021-06-30\"\n",
+	),
+	"report.period.month":    text("Bulan %s Tahun %d", "%s %d"),
+	"report.period.semester": text("Semester %d Tahun %d", "Semester %d of %d"),
+	"report.period.year":     text("Tahun %d", "%d"),
+	"report.period.range":    text("%s s.d. %s", "%s to %s"),
+	"report.borrow_how_to": text(
+		"\nLaporan Peminjaman Bulanan dapat dilihat dengan perintah\n\"/%s %s [tahun]-[bulan]\"\n\nContoh, laporan peminjaman pada bulan Agustus tahun 2021\n\"/%s %s 2021-8\"\n",
+		"\nThe monthly borrowing report can be viewed with the command\n\"/%s %s [year]-[month]\"\n\nFor example, the borrowing report for August 2021\n\"/%s %s 2021-8\"\n",
+	),
+	"report.tool_returning_how_to": text(
+		"\nLaporan Pengembalian Bulanan dapat dilihat dengan perintah\n\"/%s %s [tahun]-[bulan]\"\n\nContoh, laporan pengembalian pada bulan Agustus tahun 2021\n\"/%s %s 2021-8\"\n",
+		"\nThe monthly returning report can be viewed with the command\n\"/%s %s [year]-[month]\"\n\nFor example, the returning report for August 2021\n\"/%s %s 2021-8\"\n",
+	),
+	"report.invalid_time":         text("\nMohon isi periode laporan dengan format dan nilai yang sesuai.\nContoh: \"/%s %s 2021-8\"", "\nPlease write the report period with a valid format and value.\nExample: \"/%s %s 2021-8\""),
+	"report.borrow_empty":         text("Tidak ada data peminjaman pada waktu yang dimaksud.", "There is no borrowing data for that period."),
+	"report.borrow_title":         text("Laporan Peminjaman %s", "Borrowing Report for %s"),
+	"report.tool_returning_empty": text("Tidak ada data pengembalian pada waktu yang dimaksud.", "There is no returning data for that period."),
+	"report.tool_returning_title": text("Laporan Pengembalian %s", "Returning Report for %s"),
+	"report.consumption_how_to": text(
+		"\nLaporan Pemakaian Barang Habis Pakai dapat dilihat dengan perintah\n\"/%s %s [tahun]-[bulan]\"\n\nContoh, laporan pemakaian pada bulan Agustus tahun 2021\n\"/%s %s 2021-8\"\n",
+		"\nThe monthly consumable usage report can be viewed with the command\n\"/%s %s [year]-[month]\"\n\nFor example, the usage report for August 2021\n\"/%s %s 2021-8\"\n",
+	),
+	"report.consumption_empty": text("Tidak ada pemakaian barang habis pakai pada waktu yang dimaksud.", "No consumables were used in that period."),
+	"report.consumption_title": text("Laporan Pemakaian %s", "Consumable Usage Report for %s"),
+	"report.consumption_line":  text("[%d] %s - %s dari %s", "[%d] %s - %s from %s"),
+	"report.low_stock_title":   text("Stok menipis", "Low stock"),
+	"report.low_stock_line":    text("[%d] %s - sisa %d, minimum %d", "[%d] %s - %d left, minimum %d"),
+	"report.line":              text("[%d] %s - %s, %s %s (dikonfirmasi oleh: %s)", "[%d] %s - %s, %s %s (confirmed by: %s)"),
+
+	"chart.caption":         text("%s, %s", "%s, %s"),
+	"chart.monthly_borrows": text("Peminjaman per Bulan", "Borrows per Month"),
+	"chart.monthly_overdue": text("Keterlambatan per Bulan", "Overdue Borrows per Month"),
+	"chart.utilization":     text("Utilisasi Alat", "Tool Utilisation"),
+
+	"profile.title":               text("Profil Anda", "Your Profile"),
+	"profile.how_to":              text("Pilih data yang ingin diubah melalui tombol di bawah.", "Choose the data you want to change with the buttons below."),
+	"profile.admin":               text("Pengurus tidak memiliki profil mahasiswa.", "Admins do not have a student profile."),
+	"profile.field_not_available": text("Data tidak tersedia. Silahkan pilih data yang akan diubah melalui pilihan menu.", "The field is not available. Please choose the field to change from the menu."),
+	"profile.ask_value":           text("%s sebelumnya:\n%s\n\nSilahkan tulis data baru", "Previous %s:\n%s\n\nPlease write the new value"),
+	"profile.failed":              text("Terjadi kesalahan. Profil gagal diubah.", "Something went wrong. Your profile could not be changed."),
+	"profile.success":             text("%s berhasil diubah.", "Your %s has been changed."),
+	"profile.notify_admin":        text("%s (NIM %s) mengubah %s dari \"%s\" menjadi \"%s\".", "%s (NIM %s) changed their %s from \"%s\" to \"%s\"."),
+
+	"history.title":           text("Riwayat Peminjaman (halaman %d dari %d)", "Borrowing History (page %d of %d)"),
+	"history.empty":           text("Anda belum pernah mengajukan peminjaman.", "You have not requested to borrow anything yet."),
+	"history.line":            text("[%d] %s - %s, %s", "[%d] %s - %s, %s"),
+	"history.line_consumable": text("[%d] %s - %s", "[%d] %s - %s"),
+	"history.requested_at":    text("Diajukan %s", "Requested on %s"),
+	"history.confirmed_at":    text("Ditanggapi %s oleh %s", "Responded on %s by %s"),
+	"history.admin_notes":     text("Catatan admin: %s", "Admin notes: %s"),
+	"history.status.REQUEST":  text("Menunggu tanggapan", "Waiting for a response"),
+	"history.status.PROGRESS": text("Sedang dipinjam", "Borrowed"),
+	"history.status.RETURNED": text("Sudah dikembalikan", "Returned"),
+	"history.status.CONSUMED": text("Barang habis pakai", "Consumables"),
+	"history.status.REJECT":   text("Ditolak", "Rejected"),
+
+	"statistics.title":              text("Statistik Penggunaan %s", "Usage Statistics for %s"),
+	"statistics.empty":              text("Tidak ada peminjaman pada waktu yang dimaksud.", "There were no borrows in that period."),
+	"statistics.how_to":             text("Statistik periode lain dapat dilihat dengan perintah \"/%s [periode]\", contoh \"/%s 2021-s1\".", "Statistics for another period can be viewed with \"/%s [period]\", e.g. \"/%s 2021-s1\"."),
+	"statistics.invalid_time":       text("Mohon isi periode dengan format dan nilai yang sesuai.\nContoh: \"/%s 2021-8\", \"/%s 2021-s1\", \"/%s 2021\" atau \"/%s 2021-01-01..2021-06-30\"", "Please write the period with a valid format and value.\nExample: \"/%s 2021-8\", \"/%s 2021-s1\", \"/%s 2021\" or \"/%s 2021-01-01..2021-06-30\""),
+	"statistics.borrows":            text("Peminjaman", "Borrows"),
+	"statistics.average_duration":   text("Rata-rata durasi", "Average duration"),
+	"statistics.average_response":   text("Rata-rata waktu tanggapan admin", "Average admin response time"),
+	"statistics.late_returns":       text("Pengembalian terlambat", "Late returns"),
+	"statistics.late_returns_value": text("%d dari %d (%d%%)", "%d of %d (%d%%)"),
+	"statistics.days":               text("%.1f hari", "%.1f days"),
+	"statistics.hours":              text("%.1f jam", "%.1f hours"),
+	"statistics.utilization_title":  text("Utilisasi alat", "Tool utilisation"),
+	"statistics.utilization_line":   text("[%d] %s - %d%% (%.1f dari %.1f hari)", "[%d] %s - %d%% (%.1f of %.1f days)"),
+	"statistics.top_tools_title":    text("Alat paling sering dipinjam", "Most borrowed tools"),
+	"statistics.top_tools_line":     text("[%d] %s - %s, %s", "[%d] %s - %s, %s"),
+	"statistics.batches_title":      text("Penggunaan per angkatan", "Usage per batch"),
+	"statistics.batches_line":       text("Angkatan %d - %s, %s", "Batch %d - %s, %s"),
+	"report.export_caption":         text("%s (%s)", "%s (%s)"),
+	"report.export_hint": text(
+		"\nLaporan juga dapat diunduh sebagai berkas CSV atau XLSX dengan menambahkan formatnya\n\"/%s %s 2021-8 xlsx\"\n",
+		"\nThe report can also be downloaded as a CSV or XLSX file by adding the format\n\"/%s %s 2021-8 xlsx\"\n",
+	),
+	"report.column.id":           text("ID", "ID"),
+	"report.column.borrow_id":    text("ID Peminjaman", "Borrow ID"),
+	"report.column.tool_id":      text("ID Alat", "Tool ID"),
+	"report.column.confirmed_at": text("Tanggal Konfirmasi", "Confirmed At"),
+	"report.column.user":         text("Peminjam", "Borrower"),
+	"report.column.tool":         text("Alat", "Tool"),
+	"report.column.amount":       text("Jumlah", "Amount"),
+	"report.column.duration":     text("Durasi (hari)", "Duration (days)"),
+	"report.column.requests":     text("Permintaan", "Requests"),
+	"report.column.status":       text("Status", "Status"),
+	"report.column.confirmed_by": text("Dikonfirmasi Oleh", "Confirmed By"),
+
+	"maintenance.type.kalibrasi":        text("Kalibrasi", "Calibration"),
+	"maintenance.type.perbaikan":        text("Perbaikan", "Repair"),
+	"maintenance.type.perawatan":        text("Perawatan rutin", "Routine maintenance"),
+	"maintenance.status.ACTIVE":         text("Aktif", "Active"),
+	"maintenance.status.OUT_OF_SERVICE": text("Tidak dapat digunakan", "Out of service"),
+
+	"maintenance.overview.title":          text("Perawatan Barang", "Tool Maintenance"),
+	"maintenance.overview.calibration":    text("Jadwal kalibrasi %d hari ke depan", "Calibrations due in the next %d days"),
+	"maintenance.overview.no_calibration": text("Tidak ada kalibrasi yang jatuh tempo.", "No calibration is due."),
+	"maintenance.overview.out_of_service": text("Barang yang tidak dapat digunakan", "Tools out of service"),
+	"maintenance.overview.all_active":     text("Semua barang dapat digunakan.", "Every tool is in service."),
+	"maintenance.overview.how_to":         text("Riwayat dan pencatatan perawatan suatu barang: \"/%s [id]\"", "To view and record the maintenance of a tool: \"/%s [id]\""),
+	"maintenance.reminder.title":          text("Pengingat Kalibrasi", "Calibration Reminder"),
+	"maintenance.calibration_line":        text("[%d] %s - jatuh tempo %s", "[%d] %s - due %s"),
+	"maintenance.calibration_never":       text("[%d] %s - belum pernah dikalibrasi", "[%d] %s - never calibrated"),
+	"maintenance.history.title":           text("Perawatan %s", "Maintenance of %s"),
+	"maintenance.history.interval":        text("Interval kalibrasi", "Calibration interval"),
+	"maintenance.history.no_interval":     text("Tidak perlu kalibrasi", "No calibration needed"),
+	"maintenance.history.latest":          text("Riwayat terakhir", "Latest records"),
+	"maintenance.history.empty":           text("Belum ada riwayat perawatan.", "There is no maintenance record yet."),
+	"maintenance.history.line":            text("%s - %s oleh %s", "%s - %s by %s"),
+	"maintenance.button.record":           text("Catat Perawatan", "Record Maintenance"),
+	"maintenance.button.interval":         text("Atur Interval Kalibrasi", "Set Calibration Interval"),
+	"maintenance.button.broken":           text("Tandai Tidak Dapat Digunakan", "Mark Out of Service"),
+	"maintenance.button.active":           text("Tandai Dapat Digunakan", "Mark In Service"),
+	"maintenance.button.history":          text("Lihat Riwayat", "View Records"),
+	"maintenance.button.today":            text("Hari ini", "Today"),
+	"maintenance.interval_how_to":         text("Untuk mengatur interval kalibrasi silahkan kirim perintah\n\"/%s %d %s [hari]\"\n\nTulis 0 jika barang tidak perlu dikalibrasi.", "To set the calibration interval, send the command\n\"/%s %d %s [days]\"\n\nWrite 0 if the tool needs no calibration."),
+	"maintenance.interval_saved":          text("Interval kalibrasi %s diubah menjadi %d hari.", "The calibration interval of %s has been set to %d days."),
+	"maintenance.interval_cleared":        text("%s tidak lagi dijadwalkan untuk kalibrasi.", "%s is no longer scheduled for calibration."),
+	"maintenance.marked_broken":           text("%s ditandai tidak dapat digunakan dan tidak dapat dipinjam sampai diaktifkan kembali.", "%s has been marked out of service and cannot be borrowed until it is back in service."),
+	"maintenance.marked_active":           text("%s kembali dapat digunakan dan dipinjam.", "%s is back in service and can be borrowed."),
+	"maintenance.ask_type":                text("Jenis perawatan apa yang dilakukan?", "What kind of maintenance was done?"),
+	"maintenance.invalid_type":            text("Mohon pilih jenis perawatan yang tersedia.", "Please choose one of the maintenance types."),
+	"maintenance.ask_date":                text("Kapan perawatan dilakukan? Tulis tanggal dengan format tahun-bulan-tanggal, contoh: 2021-08-03", "When was the maintenance done? Write the date as year-month-day, e.g. 2021-08-03"),
+	"maintenance.invalid_date":            text("Mohon tulis tanggal dengan format tahun-bulan-tanggal dan tidak melebihi hari ini.", "Please write the date as year-month-day and no later than today."),
+	"maintenance.ask_technician":          text("Siapa teknisi yang melakukan perawatan?", "Who was the technician?"),
+	"maintenance.invalid_technician":      text("Mohon tulis nama teknisi.", "Please write the technician's name."),
+	"maintenance.ask_notes":               text("Tulis catatan perawatan, atau \"%s\" jika tidak ada.", "Write the maintenance notes, or \"%s\" if there are none."),
+	"maintenance.summary.title":           text("Catatan Perawatan %s", "Maintenance Record of %s"),
+	"maintenance.cancelled":               text("Pencatatan perawatan dibatalkan.", "Recording the maintenance has been cancelled."),
+	"maintenance.saved":                   text("Perawatan %s berhasil dicatat.", "The maintenance of %s has been recorded."),
+	"maintenance.saved_still_broken":      text("Perawatan %s berhasil dicatat. Barang masih ditandai tidak dapat digunakan.", "The maintenance of %s has been recorded. The tool is still marked out of service."),
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type (
+	// Forms is the translation of a message in one language. One is used instead
+	// of Other when the count is exactly one, and may be left empty for
+	// languages without a singular form such as Indonesian.
+	Forms struct {
+		One   string
+		Other string
+	}
+
+	// Message is a catalog entry, translated into every supported language.
+	Message map[types.Language]Forms
+)
+
+// Printer renders catalog messages in the language of a user.
+type Printer struct {
+	language types.Language
+}
+
+func NewPrinter(language types.Language) Printer {
+	if _, ok := ParseLanguage(string(language)); !ok {
+		language = types.DefaultLanguage
+	}
+
+	return Printer{
+		language: language,
+	}
+}
+
+// ParseLanguage accepts the code of a supported language, e.g. "en".
+func ParseLanguage(code string) (types.Language, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, language := range types.Languages {
+		if code == string(language) {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// LanguageFromTelegram picks the language for the language_code Telegram sends
+// with every update, e.g. "id" or "en-US". Users of other languages get English.
+func LanguageFromTelegram(code string) types.Language {
+	if len(code) == 0 {
+		return types.DefaultLanguage
+	}
+
+	if language, ok := ParseLanguage(strings.SplitN(code, "-", 2)[0]); ok {
+		return language
+	}
+
+	return types.LanguageEnglish
+}
+
+func (p Printer) Language() types.Language {
+	if len(p.language) == 0 {
+		return types.DefaultLanguage
+	}
+	return p.language
+}
+
+// Text renders the message with the given key, formatting the arguments the
+// way fmt.Sprintf does. The key itself is returned when it is not in the catalog.
+func (p Printer) Text(key string, args ...interface{}) string {
+	return p.format(p.forms(key).Other, key, args)
+}
+
+// Plural renders the message with the given key using the form for count.
+func (p Printer) Plural(key string, count int, args ...interface{}) string {
+	forms := p.forms(key)
+
+	format := forms.Other
+	if count == 1 && len(forms.One) > 0 {
+		format = forms.One
+	}
+
+	return p.format(format, key, args)
+}
+
+func (p Printer) forms(key string) Forms {
+	message, ok := messages[key]
+	if !ok {
+		return Forms{}
+	}
+
+	if forms, ok := message[p.Language()]; ok {
+		return forms
+	}
+
+	return message[types.DefaultLanguage]
+}
+
+func (p Printer) format(format, key string, args []interface{}) string {
+	if len(format) == 0 {
+		return key
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+func (p Printer) Month(month int) string {
+	return p.Text(fmt.Sprintf("month.%d", month))
+}
+
+func (p Printer) Date(date time.Time) string {
+	return fmt.Sprintf("%d %s %d", date.Day(), p.Month(int(date.Month())), date.Year())
+}
+
+// DateString formats a date given as "YYYY-MM-DD", optionally followed by the time.
+func (p Printer) DateString(date string) string {
+	date = strings.Split(date, " ")[0]
+	date = strings.Split(date, "T")[0]
+
+	parts := strings.Split(date, "-")
+	if len(parts) != 3 {
+		return date
+	}
+
+	year, _ := strconv.Atoi(parts[0])
+	month, _ := strconv.Atoi(parts[1])
+	day, _ := strconv.Atoi(parts[2])
+	return fmt.Sprintf("%d %s %d", day, p.Month(month), year)
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEveryMessageIsTranslated(t *testing.T) {
+	for key, message := range messages {
+		for _, language := range types.Languages {
+			forms, ok := message[language]
+			assert.True(t, ok, "%s is not translated into %s", key, language)
+			assert.NotEmpty(t, forms.Other, "%s has no %s text", key, language)
+		}
+
+		id := message[types.LanguageIndonesian].Other
+		en := message[types.LanguageEnglish].Other
+		assert.Equal(t, strings.Count(id, "%"), strings.Count(en, "%"), "%s has different arguments", key)
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	t.Run("supported language", func(t *testing.T) {
+		p := NewPrinter(types.LanguageEnglish)
+		assert.Equal(t, types.LanguageEnglish, p.Language())
+	})
+
+	t.Run("unsupported language falls back to the default", func(t *testing.T) {
+		p := NewPrinter("fr")
+		assert.Equal(t, types.DefaultLanguage, p.Language())
+	})
+
+	t.Run("zero printer uses the default", func(t *testing.T) {
+		var p Printer
+		assert.Equal(t, "Kembali", p.Text("button.back"))
+	})
+}
+
+func TestParseLanguage(t *testing.T) {
+	language, ok := ParseLanguage(" EN ")
+	assert.True(t, ok)
+	assert.Equal(t, types.LanguageEnglish, language)
+
+	_, ok = ParseLanguage("jv")
+	assert.False(t, ok)
+}
+
+func TestLanguageFromTelegram(t *testing.T) {
+	assert.Equal(t, types.DefaultLanguage, LanguageFromTelegram(""))
+	assert.Equal(t, types.LanguageIndonesian, LanguageFromTelegram("id"))
+	assert.Equal(t, types.LanguageEnglish, LanguageFromTelegram("en-US"))
+	assert.Equal(t, types.LanguageEnglish, LanguageFromTelegram("de"))
+}
+
+func TestText(t *testing.T) {
+	t.Run("formats the arguments", func(t *testing.T) {
+		p := NewPrinter(types.LanguageEnglish)
+		assert.Equal(t, "The tool with ID 5 has been changed", p.Text("manage.edit.success", 5))
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		p := NewPrinter(types.LanguageEnglish)
+		assert.Equal(t, "does.not.exist", p.Text("does.not.exist"))
+	})
+}
+
+func TestPlural(t *testing.T) {
+	en := NewPrinter(types.LanguageEnglish)
+	assert.Equal(t, "1 day", en.Plural("unit.days", 1, 1))
+	assert.Equal(t, "7 days", en.Plural("unit.days", 7, 7))
+
+	id := NewPrinter(types.LanguageIndonesian)
+	assert.Equal(t, "1 hari", id.Plural("unit.days", 1, 1))
+	assert.Equal(t, "7 hari", id.Plural("unit.days", 7, 7))
+}
+
+func TestDate(t *testing.T) {
+	date := time.Date(2021, time.August, 3, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "3 Agustus 2021", NewPrinter(types.LanguageIndonesian).Date(date))
+	assert.Equal(t, "3 August 2021", NewPrinter(types.LanguageEnglish).Date(date))
+}
+
+func TestDateString(t *testing.T) {
+	p := NewPrinter(types.LanguageEnglish)
+
+	assert.Equal(t, "3 August 2021", p.DateString("2021-08-03"))
+	assert.Equal(t, "3 August 2021", p.DateString("2021-08-03T10:00:00Z"))
+	assert.Equal(t, "invalid", p.DateString("invalid"))
+}
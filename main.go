@@ -6,6 +6,9 @@ import (
 
 	"github.com/fannyhasbi/lab-tools-lending/config"
 	"github.com/fannyhasbi/lab-tools-lending/handler"
+	"github.com/fannyhasbi/lab-tools-lending/scheduler"
+	"github.com/fannyhasbi/lab-tools-lending/service"
+	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
@@ -31,6 +34,12 @@ func main() {
 
 	e.POST("/", handler.WebhookHandler)
 
+	if err := service.ClaimConfiguredAdminGroup(); err != nil {
+		log.Println("[ERR][main][ClaimConfiguredAdminGroup]", err)
+	}
+
+	go scheduler.Daily(types.CalibrationReminderHour, service.RemindCalibrationDue)
+
 	log.Printf("Server running on port %s\n", config.GetPort())
 	e.Logger.Fatal(e.Start(":" + config.GetPort()))
 }
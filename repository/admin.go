@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+type AdminInvitationRepository interface {
+	Save(invitation *types.AdminInvitation) (types.AdminInvitation, error)
+	Redeem(code string, labID, userID int64) error
+}
+
+type AdminRequestQuery interface {
+	FindByID(id int64) QueryResult
+	FindPendingByUserID(userID, labID int64) QueryResult
+	GetPending(labID int64) QueryResult
+}
+
+type AdminRequestRepository interface {
+	Save(request *types.AdminRequest) (int64, error)
+	Resolve(id int64, status types.AdminRequestStatus, resolvedByUserID int64, resolvedBy string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+type AuditQuery interface {
+	Get(labID int64, limit int) QueryResult
+	GetByEntity(labID int64, entity types.AuditEntity, entityID int64, limit int) QueryResult
+	GetByActor(labID, actorUserID int64, limit int) QueryResult
+}
+
+type AuditRepository interface {
+	Save(entry *types.AuditEntry) (int64, error)
+}
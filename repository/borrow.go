@@ -10,13 +10,15 @@ type BorrowQuery interface {
 	FindByID(id int64) QueryResult
 	FindByUserIDAndStatus(id int64, status types.BorrowStatus) QueryResult
 	FindByUserID(id int64) QueryResult
-	GetByStatus(status types.BorrowStatus) QueryResult
+	GetByStatus(labID int64, status types.BorrowStatus) QueryResult
 	GetByUserIDAndMultipleStatus(id int64, statuses []types.BorrowStatus) QueryResult
-	GetReport(year, month int) QueryResult
+	GetReport(labID int64, from, to time.Time) QueryResult
+	GetConsumptionReport(labID int64, from, to time.Time) QueryResult
+	CountLateByUserID(id int64) QueryResult
 }
 
 type BorrowRepository interface {
 	Save(borrow *types.Borrow) (int64, error)
 	UpdateStatus(id int64, status types.BorrowStatus) error
-	UpdateConfirm(id int64, confirmedAt time.Time, confirmedBy string) error
+	UpdateConfirm(id int64, confirmedAt time.Time, confirmedByUserID int64, confirmedBy, adminNotes string) error
 }
@@ -13,4 +13,6 @@ type ChatSessionRepository interface {
 	Delete(id int64) error
 	SaveDetail(chatSessionDetail *types.ChatSessionDetail) (types.ChatSessionDetail, error)
 	DeleteDetailByChatSessionID(id int64) error
+	DeleteDetailAfter(chatSessionID, id int64) error
+	UpdateDetailData(id int64, data string) error
 }
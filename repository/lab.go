@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+type LabQuery interface {
+	FindByID(id int64) QueryResult
+	FindByAdminGroupID(groupID int64) QueryResult
+	FindWithoutAdminGroup() QueryResult
+	Get() QueryResult
+}
+
+type LabRepository interface {
+	Save(lab *types.Lab) (int64, error)
+	UpdateAdminGroup(id, groupID int64, name string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type MaintenanceQuery interface {
+	GetByToolID(toolID int64, limit int) QueryResult
+	GetCalibrationDue(labID int64, until time.Time) QueryResult
+}
+
+type MaintenanceRepository interface {
+	Save(maintenance *types.Maintenance) (int64, error)
+}
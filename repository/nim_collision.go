@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+type NIMCollisionQuery interface {
+	FindByID(id int64) QueryResult
+	GetPending() QueryResult
+	FindPendingByUserID(userID int64) QueryResult
+}
+
+type NIMCollisionRepository interface {
+	Save(collision *types.NIMCollision) (int64, error)
+	Approve(id, resolvedByUserID int64, resolvedBy string) error
+	Reject(id, resolvedByUserID int64, resolvedBy string) error
+}
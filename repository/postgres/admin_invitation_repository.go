@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type AdminInvitationRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewAdminInvitationRepositoryPostgres(DB *sql.DB) repository.AdminInvitationRepository {
+	return &AdminInvitationRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (ir *AdminInvitationRepositoryPostgres) Save(invitation *types.AdminInvitation) (types.AdminInvitation, error) {
+	row := ir.DB.QueryRow(`INSERT INTO admin_invitations (code, lab_id, created_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, code, lab_id, created_by, created_at, expires_at`, invitation.Code, invitation.LabID, invitation.CreatedBy, invitation.ExpiresAt)
+
+	i := types.AdminInvitation{}
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.LabID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	if err != nil {
+		return types.AdminInvitation{}, err
+	}
+
+	return i, nil
+}
+
+// Redeem uses up the invitation of the lab for the user. It returns
+// sql.ErrNoRows when the code does not exist in the lab, has been used or has
+// expired.
+func (ir *AdminInvitationRepositoryPostgres) Redeem(code string, labID, userID int64) error {
+	var id int64
+	return ir.DB.QueryRow(`UPDATE admin_invitations SET used_by = $1, used_at = NOW()
+		WHERE code = $2 AND lab_id = $3 AND used_by IS NULL AND expires_at > NOW()
+		RETURNING id`, userID, code, labID).Scan(&id)
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveAdminInvitation(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	invitation := types.AdminInvitation{
+		ID:        1,
+		Code:      "AB3KQ7ZX",
+		LabID:     1,
+		CreatedBy: 123,
+		CreatedAt: timeNowString(),
+		ExpiresAt: time.Now().Add(types.AdminInvitationValidity),
+	}
+
+	repository := NewAdminInvitationRepositoryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "code", "lab_id", "created_by", "created_at", "expires_at"}).
+		AddRow(invitation.ID, invitation.Code, invitation.LabID, invitation.CreatedBy, invitation.CreatedAt, invitation.ExpiresAt)
+
+	mock.ExpectQuery("^INSERT INTO admin_invitations (.+) VALUES (.+) RETURNING (.+)").
+		WithArgs(invitation.Code, invitation.LabID, invitation.CreatedBy, invitation.ExpiresAt).
+		WillReturnRows(rows)
+
+	result, err := repository.Save(&invitation)
+	assert.NoError(t, err)
+	assert.Equal(t, invitation, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanRedeemAdminInvitation(t *testing.T) {
+	code := "AB3KQ7ZX"
+	var labID int64 = 1
+	var userID int64 = 456
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewAdminInvitationRepositoryPostgres(db)
+
+		mock.ExpectQuery("^UPDATE admin_invitations SET used_by = (.+), used_at = NOW\\(\\) WHERE code = (.+) AND lab_id = (.+) AND used_by IS NULL AND expires_at > NOW\\(\\) RETURNING id").
+			WithArgs(userID, code, labID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		err := repository.Redeem(code, labID, userID)
+		assert.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("used or expired", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewAdminInvitationRepositoryPostgres(db)
+
+		mock.ExpectQuery("^UPDATE admin_invitations").
+			WithArgs(userID, code, labID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		err := repository.Redeem(code, labID, userID)
+		assert.Equal(t, sql.ErrNoRows, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
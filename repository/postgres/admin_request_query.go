@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type AdminRequestQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewAdminRequestQueryPostgres(DB *sql.DB) repository.AdminRequestQuery {
+	return &AdminRequestQueryPostgres{
+		DB: DB,
+	}
+}
+
+const adminRequestColumns = `id, user_id, lab_id, name, username, language, status, created_at, resolved_at, resolved_by, COALESCE(resolved_by_user_id, 0)`
+
+func scanAdminRequest(scanner interface{ Scan(...interface{}) error }) (types.AdminRequest, error) {
+	r := types.AdminRequest{}
+	err := scanner.Scan(
+		&r.ID,
+		&r.UserID,
+		&r.LabID,
+		&r.Name,
+		&r.Username,
+		&r.Language,
+		&r.Status,
+		&r.CreatedAt,
+		&r.ResolvedAt,
+		&r.ResolvedBy,
+		&r.ResolvedByUserID,
+	)
+	return r, err
+}
+
+func (rq AdminRequestQueryPostgres) FindByID(id int64) repository.QueryResult {
+	row := rq.DB.QueryRow(`
+		SELECT `+adminRequestColumns+`
+		FROM admin_requests
+		WHERE id = $1
+	`, id)
+
+	result := repository.QueryResult{}
+
+	request, err := scanAdminRequest(row)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = request
+	return result
+}
+
+func (rq AdminRequestQueryPostgres) FindPendingByUserID(userID, labID int64) repository.QueryResult {
+	row := rq.DB.QueryRow(`
+		SELECT `+adminRequestColumns+`
+		FROM admin_requests
+		WHERE user_id = $1 AND lab_id = $2 AND status = $3
+		ORDER BY id DESC
+		LIMIT 1
+	`, userID, labID, types.AdminRequestStatusPending)
+
+	result := repository.QueryResult{}
+
+	request, err := scanAdminRequest(row)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = request
+	return result
+}
+
+// GetPending reads the requests to join the lab waiting for an admin, the
+// oldest first.
+func (rq AdminRequestQueryPostgres) GetPending(labID int64) repository.QueryResult {
+	rows, err := rq.DB.Query(`
+		SELECT `+adminRequestColumns+`
+		FROM admin_requests
+		WHERE lab_id = $1 AND status = $2
+		ORDER BY created_at ASC, id ASC
+	`, labID, types.AdminRequestStatusPending)
+
+	requests := []types.AdminRequest{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		request, err := scanAdminRequest(rows)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+
+		requests = append(requests, request)
+	}
+
+	result.Result = requests
+	return result
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanFindAdminRequestByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewAdminRequestQueryPostgres(db)
+
+	request := types.AdminRequest{
+		ID:               1,
+		UserID:           456,
+		LabID:            1,
+		Name:             "Budi Santoso",
+		Username:         "budi",
+		Language:         types.LanguageIndonesian,
+		Status:           types.AdminRequestStatusApproved,
+		CreatedAt:        timeNowString(),
+		ResolvedBy:       sql.NullString{String: "Sari", Valid: true},
+		ResolvedByUserID: 7,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "lab_id", "name", "username", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id"}).
+		AddRow(request.ID, request.UserID, request.LabID, request.Name, request.Username, request.Language, request.Status, request.CreatedAt, nil, request.ResolvedBy.String, request.ResolvedByUserID)
+
+	mock.ExpectQuery("^SELECT (.+) FROM admin_requests WHERE id = (.+)").
+		WithArgs(request.ID).
+		WillReturnRows(rows)
+
+	result := query.FindByID(request.ID)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, request, result.Result.(types.AdminRequest))
+}
+
+func TestCanFindPendingAdminRequestByUserID(t *testing.T) {
+	var userID int64 = 456
+	var labID int64 = 1
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewAdminRequestQueryPostgres(db)
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "lab_id", "name", "username", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id"}).
+			AddRow(1, userID, labID, "Budi Santoso", "budi", types.LanguageIndonesian, types.AdminRequestStatusPending, timeNowString(), nil, nil, 0)
+
+		mock.ExpectQuery("^SELECT (.+) FROM admin_requests WHERE user_id = (.+) AND lab_id = (.+) AND status = (.+)").
+			WithArgs(userID, labID, types.AdminRequestStatusPending).
+			WillReturnRows(rows)
+
+		result := query.FindPendingByUserID(userID, labID)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, int64(1), result.Result.(types.AdminRequest).ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewAdminRequestQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM admin_requests").
+			WithArgs(userID, labID, types.AdminRequestStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result := query.FindPendingByUserID(userID, labID)
+		assert.Equal(t, sql.ErrNoRows, result.Error)
+	})
+}
+
+func TestCanGetPendingAdminRequests(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var labID int64 = 1
+	query := NewAdminRequestQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "lab_id", "name", "username", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id"}).
+		AddRow(1, 456, labID, "Budi Santoso", "budi", types.LanguageIndonesian, types.AdminRequestStatusPending, timeNowString(), nil, nil, 0).
+		AddRow(2, 789, labID, "Sari", "", types.LanguageEnglish, types.AdminRequestStatusPending, timeNowString(), nil, nil, 0)
+
+	mock.ExpectQuery("^SELECT (.+) FROM admin_requests WHERE lab_id = (.+) AND status = (.+) ORDER BY created_at ASC, id ASC").
+		WithArgs(labID, types.AdminRequestStatusPending).
+		WillReturnRows(rows)
+
+	result := query.GetPending(labID)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.AdminRequest)
+		assert.Len(t, r, 2)
+		assert.Equal(t, int64(1), r[0].ID)
+		assert.Equal(t, int64(2), r[1].ID)
+	})
+}
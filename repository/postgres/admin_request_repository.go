@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type AdminRequestRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewAdminRequestRepositoryPostgres(DB *sql.DB) repository.AdminRequestRepository {
+	return &AdminRequestRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (rr *AdminRequestRepositoryPostgres) Save(request *types.AdminRequest) (int64, error) {
+	row := rr.DB.QueryRow(`INSERT INTO admin_requests (user_id, lab_id, name, username, language)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, request.UserID, request.LabID, request.Name, request.Username, request.Language)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
+
+func (rr *AdminRequestRepositoryPostgres) Resolve(id int64, status types.AdminRequestStatus, resolvedByUserID int64, resolvedBy string) error {
+	_, err := rr.DB.Exec(`UPDATE admin_requests SET status = $1, resolved_at = NOW(), resolved_by_user_id = $2, resolved_by = $3
+		WHERE id = $4`, status, resolvedByUserID, resolvedBy, id)
+	return err
+}
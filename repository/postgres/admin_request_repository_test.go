@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveAdminRequest(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 3
+	request := types.AdminRequest{
+		UserID:   456,
+		LabID:    1,
+		Name:     "Budi Santoso",
+		Username: "budi",
+		Language: types.LanguageIndonesian,
+	}
+
+	repository := NewAdminRequestRepositoryPostgres(db)
+
+	mock.ExpectQuery("^INSERT INTO admin_requests .+ VALUES .+ RETURNING id").
+		WithArgs(request.UserID, request.LabID, request.Name, request.Username, request.Language).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+	result, err := repository.Save(&request)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanResolveAdminRequest(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 3
+	var resolvedByUserID int64 = 7
+	resolvedBy := "Sari"
+
+	repository := NewAdminRequestRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE admin_requests SET status = (.+), resolved_at = NOW\\(\\), resolved_by_user_id = (.+), resolved_by = (.+) WHERE id = (.+)").
+		WithArgs(types.AdminRequestStatusApproved, resolvedByUserID, resolvedBy, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.Resolve(id, types.AdminRequestStatusApproved, resolvedByUserID, resolvedBy)
+	assert.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type AuditQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewAuditQueryPostgres(DB *sql.DB) repository.AuditQuery {
+	return &AuditQueryPostgres{
+		DB: DB,
+	}
+}
+
+// auditColumns reads the name of the actor, which is empty once the admin has
+// been deleted.
+const auditColumns = `a.id, a.lab_id, a.actor_user_id, COALESCE(u.name, ''), a.action, a.entity, a.entity_id, a.before, a.after, a.created_at`
+
+func (aq AuditQueryPostgres) get(condition string, args ...interface{}) repository.QueryResult {
+	rows, err := aq.DB.Query(`
+		SELECT `+auditColumns+`
+		FROM audit_log a
+		LEFT JOIN users u
+			ON u.id = a.actor_user_id
+		WHERE `+condition+`
+		ORDER BY a.id DESC
+		LIMIT $1
+	`, args...)
+
+	entries := []types.AuditEntry{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := types.AuditEntry{}
+		err := rows.Scan(
+			&temp.ID,
+			&temp.LabID,
+			&temp.ActorUserID,
+			&temp.ActorName,
+			&temp.Action,
+			&temp.Entity,
+			&temp.EntityID,
+			&temp.Before,
+			&temp.After,
+			&temp.CreatedAt,
+		)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+
+		entries = append(entries, temp)
+	}
+
+	result.Result = entries
+	return result
+}
+
+// Get reads the latest entries of the lab, the latest first.
+func (aq AuditQueryPostgres) Get(labID int64, limit int) repository.QueryResult {
+	return aq.get(`a.lab_id = $2`, limit, labID)
+}
+
+// GetByEntity reads the latest entries of the lab about an entity.
+func (aq AuditQueryPostgres) GetByEntity(labID int64, entity types.AuditEntity, entityID int64, limit int) repository.QueryResult {
+	return aq.get(`a.lab_id = $2 AND a.entity = $3 AND a.entity_id = $4`, limit, labID, entity, entityID)
+}
+
+// GetByActor reads the latest entries of the lab written by an admin.
+func (aq AuditQueryPostgres) GetByActor(labID, actorUserID int64, limit int) repository.QueryResult {
+	return aq.get(`a.lab_id = $2 AND a.actor_user_id = $3`, limit, labID, actorUserID)
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var auditQueryColumns = []string{"id", "lab_id", "actor_user_id", "name", "action", "entity", "entity_id", "before", "after", "created_at"}
+
+func TestCanGetAuditEntries(t *testing.T) {
+	entry := types.AuditEntry{
+		ID:          1,
+		LabID:       1,
+		ActorUserID: 7,
+		ActorName:   "Budi",
+		Action:      types.AuditActionDelete,
+		Entity:      types.AuditEntityTool,
+		EntityID:    5,
+		Before:      sql.NullString{Valid: true, String: `{"name":"Osiloskop"}`},
+		CreatedAt:   timeNowString(),
+	}
+
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(auditQueryColumns).
+			AddRow(entry.ID, entry.LabID, entry.ActorUserID, entry.ActorName, entry.Action, entry.Entity, entry.EntityID, entry.Before, nil, entry.CreatedAt)
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewAuditQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM audit_log a LEFT JOIN users u ON u.id = a.actor_user_id WHERE a.lab_id = (.+) ORDER BY a.id DESC LIMIT (.+)").
+			WithArgs(types.AuditLimit, entry.LabID).
+			WillReturnRows(newRows())
+
+		result := query.Get(entry.LabID, types.AuditLimit)
+		assert.NoError(t, result.Error)
+		assert.NotPanics(t, func() {
+			r := result.Result.([]types.AuditEntry)
+			assert.Equal(t, []types.AuditEntry{entry}, r)
+		})
+	})
+
+	t.Run("by entity", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewAuditQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM audit_log a (.+) WHERE a.lab_id = (.+) AND a.entity = (.+) AND a.entity_id = (.+) ORDER BY a.id DESC").
+			WithArgs(types.AuditLimit, entry.LabID, entry.Entity, entry.EntityID).
+			WillReturnRows(newRows())
+
+		result := query.GetByEntity(entry.LabID, entry.Entity, entry.EntityID, types.AuditLimit)
+		assert.NoError(t, result.Error)
+		assert.Len(t, result.Result, 1)
+	})
+
+	t.Run("by actor", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewAuditQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM audit_log a (.+) WHERE a.lab_id = (.+) AND a.actor_user_id = (.+) ORDER BY a.id DESC").
+			WithArgs(types.AuditLimit, entry.LabID, entry.ActorUserID).
+			WillReturnRows(newRows())
+
+		result := query.GetByActor(entry.LabID, entry.ActorUserID, types.AuditLimit)
+		assert.NoError(t, result.Error)
+		assert.Len(t, result.Result, 1)
+	})
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type AuditRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewAuditRepositoryPostgres(DB *sql.DB) repository.AuditRepository {
+	return &AuditRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (ar *AuditRepositoryPostgres) Save(entry *types.AuditEntry) (int64, error) {
+	row := ar.DB.QueryRow(`INSERT INTO audit_log (lab_id, actor_user_id, action, entity, entity_id, before, after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`, entry.LabID, entry.ActorUserID, entry.Action, entry.Entity, entry.EntityID, entry.Before, entry.After)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveAuditEntry(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 3
+	entry := types.AuditEntry{
+		LabID:       1,
+		ActorUserID: 7,
+		Action:      types.AuditActionUpdate,
+		Entity:      types.AuditEntityTool,
+		EntityID:    5,
+		Before:      sql.NullString{Valid: true, String: `{"stock":2}`},
+		After:       sql.NullString{Valid: true, String: `{"stock":3}`},
+	}
+
+	repository := NewAuditRepositoryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+
+	mock.ExpectQuery("^INSERT INTO audit_log .+ VALUES .+ RETURNING id").
+		WithArgs(entry.LabID, entry.ActorUserID, entry.Action, entry.Entity, entry.EntityID, entry.Before, entry.After).
+		WillReturnRows(rows)
+
+	result, err := repository.Save(&entry)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
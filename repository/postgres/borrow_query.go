@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/fannyhasbi/lab-tools-lending/repository"
 	"github.com/fannyhasbi/lab-tools-lending/types"
@@ -20,7 +21,7 @@ func NewBorrowQueryPostgres(DB *sql.DB) repository.BorrowQuery {
 
 func (bq BorrowQueryPostgres) FindByID(id int64) repository.QueryResult {
 	row := bq.DB.QueryRow(`
-	SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, b.reason, t.name AS tool_name, t.stock AS tool_stock, u.name AS user_name, u.nim, u.address
+	SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, b.reason, t.name AS tool_name, t.stock AS tool_stock, t.kind AS tool_kind, t.min_stock AS tool_min_stock, t.lab_id AS tool_lab_id, u.name AS user_name, u.nim, u.address
 	FROM borrows b
 	INNER JOIN tools t
 		ON t.id = b.tool_id
@@ -44,6 +45,9 @@ func (bq BorrowQueryPostgres) FindByID(id int64) repository.QueryResult {
 		&borrow.Reason,
 		&borrow.Tool.Name,
 		&borrow.Tool.Stock,
+		&borrow.Tool.Kind,
+		&borrow.Tool.MinStock,
+		&borrow.Tool.LabID,
 		&borrow.User.Name,
 		&borrow.User.NIM,
 		&borrow.User.Address,
@@ -96,12 +100,16 @@ func (bq BorrowQueryPostgres) FindByUserIDAndStatus(id int64, status types.Borro
 	return result
 }
 
+// FindByUserID reads every borrow of the user, the latest first, with the
+// notes of the admin who responded to it.
 func (bq BorrowQueryPostgres) FindByUserID(id int64) repository.QueryResult {
 	rows, err := bq.DB.Query(`
-		SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, t.name AS tool_name
+		SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, b.confirmed_by, COALESCE(b.confirmed_by_user_id, 0), COALESCE(cu.name, ''), b.reason, b.admin_notes, t.name AS tool_name, t.kind AS tool_kind
 		FROM borrows b
 		INNER JOIN tools t
 			ON t.id = b.tool_id
+		LEFT JOIN users cu
+			ON cu.id = b.confirmed_by_user_id
 		WHERE b.user_id = $1
 		ORDER BY b.id DESC
 	`, id)
@@ -123,7 +131,13 @@ func (bq BorrowQueryPostgres) FindByUserID(id int64) repository.QueryResult {
 				&temp.ToolID,
 				&temp.CreatedAt,
 				&temp.ConfirmedAt,
+				&temp.ConfirmedBy,
+				&temp.ConfirmedByUser.ID,
+				&temp.ConfirmedByUser.Name,
+				&temp.Reason,
+				&temp.AdminNotes,
 				&temp.Tool.Name,
+				&temp.Tool.Kind,
 			)
 
 			borrows = append(borrows, temp)
@@ -133,7 +147,8 @@ func (bq BorrowQueryPostgres) FindByUserID(id int64) repository.QueryResult {
 	return result
 }
 
-func (bq BorrowQueryPostgres) GetByStatus(status types.BorrowStatus) repository.QueryResult {
+// GetByStatus reads the borrows of the tools of the lab with the status.
+func (bq BorrowQueryPostgres) GetByStatus(labID int64, status types.BorrowStatus) repository.QueryResult {
 	rows, err := bq.DB.Query(`
 		SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, t.name AS tool_name, u.name AS user_name
 		FROM borrows b
@@ -142,8 +157,9 @@ func (bq BorrowQueryPostgres) GetByStatus(status types.BorrowStatus) repository.
 		INNER JOIN users u
 			ON u.id = b.user_id
 		WHERE b.status = $1
+			AND t.lab_id = $2
 		ORDER BY b.id ASC
-	`, status)
+	`, status, labID)
 
 	borrows := []types.Borrow{}
 	result := repository.QueryResult{}
@@ -213,18 +229,23 @@ func (bq BorrowQueryPostgres) GetByUserIDAndMultipleStatus(id int64, statuses []
 	return result
 }
 
-func (bq BorrowQueryPostgres) GetReport(year, month int) repository.QueryResult {
-	rows, err := bq.DB.Query(`SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, b.confirmed_by, t.name AS tool_name, u.name AS user_name
+// GetReport finds the borrows of the tools of the lab confirmed in the
+// [from, to) window.
+func (bq BorrowQueryPostgres) GetReport(labID int64, from, to time.Time) repository.QueryResult {
+	rows, err := bq.DB.Query(`SELECT b.id, b.amount, b.duration, b.status, b.user_id, b.tool_id, b.created_at, b.confirmed_at, b.confirmed_by, COALESCE(b.confirmed_by_user_id, 0), COALESCE(cu.name, ''), t.name AS tool_name, u.name AS user_name
 		FROM borrows b
 		INNER JOIN tools t
 			ON t.id = b.tool_id
 		INNER JOIN users u
 			ON u.id = b.user_id
+		LEFT JOIN users cu
+			ON cu.id = b.confirmed_by_user_id
 		WHERE b.status IN ($1, $2)
-			AND DATE_PART('year', b.confirmed_at) = $3
-			AND DATE_PART('month', b.confirmed_at) = $4
+			AND b.confirmed_at >= $3
+			AND b.confirmed_at < $4
+			AND t.lab_id = $5
 		ORDER BY b.id ASC
-	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), year, month)
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), from, to, labID)
 
 	borrows := []types.Borrow{}
 	result := repository.QueryResult{}
@@ -244,6 +265,8 @@ func (bq BorrowQueryPostgres) GetReport(year, month int) repository.QueryResult
 				&temp.CreatedAt,
 				&temp.ConfirmedAt,
 				&temp.ConfirmedBy,
+				&temp.ConfirmedByUser.ID,
+				&temp.ConfirmedByUser.Name,
 				&temp.Tool.Name,
 				&temp.User.Name,
 			)
@@ -254,3 +277,68 @@ func (bq BorrowQueryPostgres) GetReport(year, month int) repository.QueryResult
 	}
 	return result
 }
+
+// GetConsumptionReport sums the approved consumable requests of each tool of
+// the lab in the [from, to) window.
+func (bq BorrowQueryPostgres) GetConsumptionReport(labID int64, from, to time.Time) repository.QueryResult {
+	rows, err := bq.DB.Query(`SELECT t.id, t.name, t.stock, t.min_stock, SUM(b.amount) AS amount, COUNT(b.id) AS requests
+		FROM borrows b
+		INNER JOIN tools t
+			ON t.id = b.tool_id
+		WHERE b.status = $1
+			AND b.confirmed_at >= $2
+			AND b.confirmed_at < $3
+			AND t.lab_id = $4
+		GROUP BY t.id, t.name, t.stock, t.min_stock
+		ORDER BY amount DESC, t.id ASC
+	`, types.GetBorrowStatus("consumed"), from, to, labID)
+
+	consumptions := []types.Consumption{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+	} else {
+		for rows.Next() {
+			temp := types.Consumption{Tool: types.Tool{Kind: types.ToolKindConsumable}}
+			rows.Scan(
+				&temp.Tool.ID,
+				&temp.Tool.Name,
+				&temp.Tool.Stock,
+				&temp.Tool.MinStock,
+				&temp.Amount,
+				&temp.Requests,
+			)
+
+			consumptions = append(consumptions, temp)
+		}
+		result.Result = consumptions
+	}
+	return result
+}
+
+// CountLateByUserID counts the borrows of the user whose return was requested
+// after the borrow duration ended, or which are past the duration and not
+// returned yet.
+func (bq BorrowQueryPostgres) CountLateByUserID(id int64) repository.QueryResult {
+	row := bq.DB.QueryRow(`
+		SELECT COUNT(b.id)
+		FROM borrows b
+		LEFT JOIN tool_returning tr
+			ON tr.borrow_id = b.id AND tr.status IN ($4, $5)
+		WHERE b.user_id = $1
+			AND b.status IN ($2, $3)
+			AND COALESCE(tr.created_at, NOW()) > b.confirmed_at + b.duration * INTERVAL '1 day'
+	`, id, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetToolReturningStatus("request"), types.GetToolReturningStatus("complete"))
+
+	var late int
+	result := repository.QueryResult{}
+
+	if err := row.Scan(&late); err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = late
+	return result
+}
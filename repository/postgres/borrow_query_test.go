@@ -28,8 +28,10 @@ func TestCanFindBorrowByID(t *testing.T) {
 		CreatedAt: timeNowString(),
 		Reason:    sql.NullString{Valid: true, String: "test reason"},
 		Tool: types.Tool{
-			Name:  "Test Tool Name 1",
-			Stock: 10,
+			Name:     "Test Tool Name 1",
+			Stock:    10,
+			Kind:     types.ToolKindConsumable,
+			MinStock: 5,
 		},
 		User: types.User{
 			NIM:     "21120XXXXXXXXX",
@@ -38,8 +40,8 @@ func TestCanFindBorrowByID(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "reason", "tool_name", "tool_stock", "user_name", "nim", "address"}).
-		AddRow(borrow.ID, borrow.Amount, borrow.Duration, borrow.Status, borrow.UserID, borrow.ToolID, borrow.CreatedAt, borrow.ConfirmedAt, borrow.Reason, borrow.Tool.Name, borrow.Tool.Stock, borrow.User.Name, borrow.User.NIM, borrow.User.Address)
+	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "reason", "tool_name", "tool_stock", "tool_kind", "tool_min_stock", "tool_lab_id", "user_name", "nim", "address"}).
+		AddRow(borrow.ID, borrow.Amount, borrow.Duration, borrow.Status, borrow.UserID, borrow.ToolID, borrow.CreatedAt, borrow.ConfirmedAt, borrow.Reason, borrow.Tool.Name, borrow.Tool.Stock, borrow.Tool.Kind, borrow.Tool.MinStock, borrow.Tool.LabID, borrow.User.Name, borrow.User.NIM, borrow.User.Address)
 
 	mock.ExpectQuery("^SELECT (.+) FROM borrows .+ INNER JOIN tools .+ INNER JOIN users .+ WHERE .+id = .+").WithArgs(id).WillReturnRows(rows)
 
@@ -108,27 +110,37 @@ func TestCanFindBorrowByUserID(t *testing.T) {
 			UserID:    111,
 			ToolID:    222,
 			CreatedAt: timeNowString(),
+			Reason:    sql.NullString{Valid: true, String: "Praktikum"},
 			Tool: types.Tool{
 				Name: "Tool Name Test 1",
+				Kind: types.ToolKindAsset,
 			},
 		},
 		{
-			ID:        124,
-			Amount:    1,
-			Duration:  7,
-			Status:    types.GetBorrowStatus("progress"),
-			UserID:    111,
-			ToolID:    223,
-			CreatedAt: timeNowString(),
+			ID:          124,
+			Amount:      1,
+			Duration:    7,
+			Status:      types.GetBorrowStatus("progress"),
+			UserID:      111,
+			ToolID:      223,
+			CreatedAt:   timeNowString(),
+			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Now()},
+			ConfirmedBy: sql.NullString{Valid: true, String: "Test Confirmed By"},
+			ConfirmedByUser: types.User{
+				ID:   9,
+				Name: "Test Admin",
+			},
+			AdminNotes: sql.NullString{Valid: true, String: "Ambil di lab"},
 			Tool: types.Tool{
 				Name: "Tool Name Test 2",
+				Kind: types.ToolKindAsset,
 			},
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "tool_name"})
+	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "confirmed_by", "confirmed_by_user_id", "confirmed_by_user_name", "reason", "admin_notes", "tool_name", "tool_kind"})
 	for _, v := range tt {
-		rows.AddRow(v.ID, v.Amount, v.Duration, v.Status, v.UserID, v.ToolID, v.CreatedAt, v.ConfirmedAt, v.Tool.Name)
+		rows.AddRow(v.ID, v.Amount, v.Duration, v.Status, v.UserID, v.ToolID, v.CreatedAt, v.ConfirmedAt, v.ConfirmedBy, v.ConfirmedByUser.ID, v.ConfirmedByUser.Name, v.Reason, v.AdminNotes, v.Tool.Name, v.Tool.Kind)
 	}
 
 	mock.ExpectQuery("^SELECT .+ FROM borrows .+ INNER JOIN tools .+ WHERE .+user_id = .+ ORDER BY .+id DESC").
@@ -190,10 +202,10 @@ func TestCanGetBorrowsByStatus(t *testing.T) {
 	}
 
 	mock.ExpectQuery("^SELECT .+ FROM borrows b INNER JOIN tools t .+ INNER JOIN users u .+ WHERE b.status = .+ ORDER BY b.id ASC").
-		WithArgs(status).
+		WithArgs(status, int64(1)).
 		WillReturnRows(rows)
 
-	result := query.GetByStatus(status)
+	result := query.GetByStatus(1, status)
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 	assert.NotPanics(t, func() {
@@ -270,8 +282,8 @@ func TestCanGetBorrowReport(t *testing.T) {
 
 	query := NewBorrowQueryPostgres(db)
 
-	year := 2021
-	month := 8
+	from := time.Date(2021, time.August, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
 	tt := []types.Borrow{
 		{
 			ID:          123,
@@ -300,6 +312,10 @@ func TestCanGetBorrowReport(t *testing.T) {
 			CreatedAt:   timeNowString(),
 			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Now()},
 			ConfirmedBy: sql.NullString{Valid: true, String: "Test Confirmed By 2"},
+			ConfirmedByUser: types.User{
+				ID:   9,
+				Name: "Test Admin",
+			},
 			Tool: types.Tool{
 				Name: "Tool Name Test 2",
 			},
@@ -309,16 +325,16 @@ func TestCanGetBorrowReport(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "confirmed_by", "tool_name", "user_name"})
+	rows := sqlmock.NewRows([]string{"id", "amount", "duration", "status", "user_id", "tool_id", "created_at", "confirmed_at", "confirmed_by", "confirmed_by_user_id", "confirmed_by_user_name", "tool_name", "user_name"})
 	for _, v := range tt {
-		rows.AddRow(v.ID, v.Amount, v.Duration, v.Status, v.UserID, v.ToolID, v.CreatedAt, v.ConfirmedAt, v.ConfirmedBy, v.Tool.Name, v.User.Name)
+		rows.AddRow(v.ID, v.Amount, v.Duration, v.Status, v.UserID, v.ToolID, v.CreatedAt, v.ConfirmedAt, v.ConfirmedBy, v.ConfirmedByUser.ID, v.ConfirmedByUser.Name, v.Tool.Name, v.User.Name)
 	}
 
-	mock.ExpectQuery(`^SELECT .+ FROM borrows b INNER JOIN tools t .+ INNER JOIN users u .+ WHERE b.status IN .+ AND DATE_PART\('year', b.confirmed_at\) = .+ AND DATE_PART\('month', b.confirmed_at\) = .+ ORDER BY b.id ASC`).
-		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), year, month).
+	mock.ExpectQuery(`^SELECT .+ FROM borrows b INNER JOIN tools t .+ INNER JOIN users u .+ WHERE b.status IN .+ AND b.confirmed_at >= .+ AND b.confirmed_at < .+ ORDER BY b.id ASC`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), from, to, int64(1)).
 		WillReturnRows(rows)
 
-	result := query.GetReport(year, month)
+	result := query.GetReport(1, from, to)
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 	assert.NotPanics(t, func() {
@@ -326,3 +342,50 @@ func TestCanGetBorrowReport(t *testing.T) {
 		assert.Equal(t, tt, r)
 	})
 }
+
+func TestCanGetConsumptionReport(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewBorrowQueryPostgres(db)
+
+	from := time.Date(2021, time.August, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "stock", "min_stock", "amount", "requests"}).
+		AddRow(3, "Timah Solder", 2, 5, 12, 4).
+		AddRow(7, "Resistor 1K", 150, 20, 30, 2)
+
+	mock.ExpectQuery(`^SELECT .+ FROM borrows b INNER JOIN tools t .+ WHERE b.status = .+ AND b.confirmed_at >= .+ AND b.confirmed_at < .+ GROUP BY .+`).
+		WithArgs(types.GetBorrowStatus("consumed"), from, to, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetConsumptionReport(1, from, to)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.Consumption)
+		assert.Len(t, r, 2)
+		assert.Equal(t, 12, r[0].Amount)
+		assert.Equal(t, 4, r[0].Requests)
+		assert.True(t, r[0].Tool.IsLowStock())
+		assert.False(t, r[1].Tool.IsLowStock())
+	})
+}
+
+func TestCanCountLateBorrowsByUserID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	query := NewBorrowQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+
+	mock.ExpectQuery(`^SELECT COUNT\(b.id\) FROM borrows b LEFT JOIN tool_returning tr .+ WHERE b.user_id = .+`).
+		WithArgs(id, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetToolReturningStatus("request"), types.GetToolReturningStatus("complete")).
+		WillReturnRows(rows)
+
+	result := query.CountLateByUserID(id)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, 2, result.Result)
+}
@@ -37,7 +37,7 @@ func (br *BorrowRepositoryPostgres) UpdateStatus(id int64, status types.BorrowSt
 	return err
 }
 
-func (br *BorrowRepositoryPostgres) UpdateConfirm(id int64, confirmedAt time.Time, confirmedBy string) error {
-	_, err := br.DB.Exec(`UPDATE borrows SET confirmed_at = $1, confirmed_by = $2 WHERE id = $3`, confirmedAt, confirmedBy, id)
+func (br *BorrowRepositoryPostgres) UpdateConfirm(id int64, confirmedAt time.Time, confirmedByUserID int64, confirmedBy, adminNotes string) error {
+	_, err := br.DB.Exec(`UPDATE borrows SET confirmed_at = $1, confirmed_by_user_id = $2, confirmed_by = $3, admin_notes = $4 WHERE id = $5`, confirmedAt, confirmedByUserID, confirmedBy, adminNotes, id)
 	return err
 }
@@ -66,16 +66,18 @@ func TestCanUpdateBorrowConfirm(t *testing.T) {
 	defer db.Close()
 
 	var id int64 = 123
+	var confirmedByUserID int64 = 7
 	confirmedAt := time.Now()
 	confirmedBy := "Test Confirmed By"
+	adminNotes := "Silahkan ambil di lab"
 
 	repository := NewBorrowRepositoryPostgres(db)
 
-	mock.ExpectExec("^UPDATE borrows SET confirmed_at = .+ confirmed_by = .+ WHERE id = .+").
-		WithArgs(confirmedAt, confirmedBy, id).
+	mock.ExpectExec("^UPDATE borrows SET confirmed_at = .+ confirmed_by_user_id = .+ confirmed_by = .+ admin_notes = .+ WHERE id = .+").
+		WithArgs(confirmedAt, confirmedByUserID, confirmedBy, adminNotes, id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err := repository.UpdateConfirm(id, confirmedAt, confirmedBy)
+	err := repository.UpdateConfirm(id, confirmedAt, confirmedByUserID, confirmedBy, adminNotes)
 	assert.NoError(t, err)
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
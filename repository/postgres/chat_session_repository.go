@@ -73,3 +73,13 @@ func (csr *ChatSessionRepositoryPostgres) DeleteDetailByChatSessionID(id int64)
 	_, err := csr.DB.Exec(`DELETE FROM chat_session_details WHERE chat_session_id = $1`, id)
 	return err
 }
+
+func (csr *ChatSessionRepositoryPostgres) DeleteDetailAfter(chatSessionID, id int64) error {
+	_, err := csr.DB.Exec(`DELETE FROM chat_session_details WHERE chat_session_id = $1 AND id > $2`, chatSessionID, id)
+	return err
+}
+
+func (csr *ChatSessionRepositoryPostgres) UpdateDetailData(id int64, data string) error {
+	_, err := csr.DB.Exec(`UPDATE chat_session_details SET data = $1 WHERE id = $2`, data, id)
+	return err
+}
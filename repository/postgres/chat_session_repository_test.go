@@ -122,3 +122,41 @@ func TestCanDeleteChatSessionDetailByChatSessionID(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestCanDeleteChatSessionDetailAfter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var chatSessionID int64 = 123
+	var id int64 = 5
+
+	repository := NewChatSessionRepositoryPostgres(db)
+
+	mock.ExpectExec("^DELETE FROM chat_session_details WHERE chat_session_id = (.+) AND id > (.+)").
+		WithArgs(chatSessionID, id).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	err := repository.DeleteDetailAfter(chatSessionID, id)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateChatSessionDetailData(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	data := `{"amount":2}`
+
+	repository := NewChatSessionRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE chat_session_details SET data = (.+) WHERE id = (.+)").
+		WithArgs(data, id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repository.UpdateDetailData(id, data)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type LabQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewLabQueryPostgres(DB *sql.DB) repository.LabQuery {
+	return &LabQueryPostgres{
+		DB: DB,
+	}
+}
+
+const labColumns = `id, name, admin_group_id, created_at`
+
+func scanLab(scanner interface{ Scan(...interface{}) error }) (types.Lab, error) {
+	l := types.Lab{}
+	err := scanner.Scan(
+		&l.ID,
+		&l.Name,
+		&l.AdminGroupID,
+		&l.CreatedAt,
+	)
+	return l, err
+}
+
+func (lq LabQueryPostgres) findOne(condition string, args ...interface{}) repository.QueryResult {
+	row := lq.DB.QueryRow(`SELECT `+labColumns+` FROM labs WHERE `+condition+` ORDER BY id ASC LIMIT 1`, args...)
+
+	result := repository.QueryResult{}
+
+	lab, err := scanLab(row)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = lab
+	return result
+}
+
+func (lq LabQueryPostgres) FindByID(id int64) repository.QueryResult {
+	return lq.findOne(`id = $1`, id)
+}
+
+// FindByAdminGroupID reads the lab looked after in the group.
+func (lq LabQueryPostgres) FindByAdminGroupID(groupID int64) repository.QueryResult {
+	return lq.findOne(`admin_group_id = $1 AND admin_group_id <> 0`, groupID)
+}
+
+// FindWithoutAdminGroup reads the earliest lab still waiting for an admin group.
+func (lq LabQueryPostgres) FindWithoutAdminGroup() repository.QueryResult {
+	return lq.findOne(`admin_group_id = 0`)
+}
+
+// Get reads every lab, the earliest first.
+func (lq LabQueryPostgres) Get() repository.QueryResult {
+	rows, err := lq.DB.Query(`SELECT ` + labColumns + ` FROM labs ORDER BY id ASC`)
+
+	labs := []types.Lab{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		lab, err := scanLab(rows)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+
+		labs = append(labs, lab)
+	}
+
+	result.Result = labs
+	return result
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanFindLabByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewLabQueryPostgres(db)
+
+	lab := types.Lab{
+		ID:           1,
+		Name:         "Laboratorium Elektronika",
+		AdminGroupID: -1001234567890,
+		CreatedAt:    timeNowString(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "admin_group_id", "created_at"}).
+		AddRow(lab.ID, lab.Name, lab.AdminGroupID, lab.CreatedAt)
+
+	mock.ExpectQuery("^SELECT (.+) FROM labs WHERE id = (.+)").
+		WithArgs(lab.ID).
+		WillReturnRows(rows)
+
+	result := query.FindByID(lab.ID)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, lab, result.Result.(types.Lab))
+}
+
+func TestCanFindLabByAdminGroupID(t *testing.T) {
+	var adminGroupID int64 = -1001234567890
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewLabQueryPostgres(db)
+
+		rows := sqlmock.NewRows([]string{"id", "name", "admin_group_id", "created_at"}).
+			AddRow(1, "Laboratorium Elektronika", adminGroupID, timeNowString())
+
+		mock.ExpectQuery("^SELECT (.+) FROM labs WHERE admin_group_id = (.+) AND admin_group_id <> 0").
+			WithArgs(adminGroupID).
+			WillReturnRows(rows)
+
+		result := query.FindByAdminGroupID(adminGroupID)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, int64(1), result.Result.(types.Lab).ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewLabQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM labs").
+			WithArgs(adminGroupID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result := query.FindByAdminGroupID(adminGroupID)
+		assert.Equal(t, sql.ErrNoRows, result.Error)
+	})
+}
+
+func TestCanFindLabWithoutAdminGroup(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewLabQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "admin_group_id", "created_at"}).
+		AddRow(1, "Laboratorium Elektronika", 0, timeNowString())
+
+	mock.ExpectQuery("^SELECT (.+) FROM labs WHERE admin_group_id = 0").
+		WillReturnRows(rows)
+
+	result := query.FindWithoutAdminGroup()
+	assert.NoError(t, result.Error)
+	assert.False(t, result.Result.(types.Lab).HasAdminGroup())
+}
+
+func TestCanGetLabs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewLabQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "admin_group_id", "created_at"}).
+		AddRow(1, "Laboratorium Elektronika", -1001234567890, timeNowString()).
+		AddRow(2, "Laboratorium Jaringan", 0, timeNowString())
+
+	mock.ExpectQuery("^SELECT (.+) FROM labs ORDER BY id ASC").
+		WillReturnRows(rows)
+
+	result := query.Get()
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.Lab)
+		assert.Len(t, r, 2)
+		assert.Equal(t, "Laboratorium Elektronika", r[0].Name)
+		assert.Equal(t, "Laboratorium Jaringan", r[1].Name)
+	})
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type LabRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewLabRepositoryPostgres(DB *sql.DB) repository.LabRepository {
+	return &LabRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (lr *LabRepositoryPostgres) Save(lab *types.Lab) (int64, error) {
+	row := lr.DB.QueryRow(`INSERT INTO labs (name, admin_group_id)
+		VALUES ($1, $2)
+		RETURNING id`, lab.Name, lab.AdminGroupID)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
+
+// UpdateAdminGroup hands the lab over to the admins of the group.
+func (lr *LabRepositoryPostgres) UpdateAdminGroup(id, groupID int64, name string) error {
+	_, err := lr.DB.Exec(`UPDATE labs SET admin_group_id = $1, name = $2 WHERE id = $3`, groupID, name, id)
+	return err
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveLab(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	lab := types.Lab{
+		ID:           1,
+		Name:         "Laboratorium Elektronika",
+		AdminGroupID: -1001234567890,
+	}
+
+	repository := NewLabRepositoryPostgres(db)
+
+	mock.ExpectQuery("^INSERT INTO labs .+ VALUES .+ RETURNING id").
+		WithArgs(lab.Name, lab.AdminGroupID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lab.ID))
+
+	result, err := repository.Save(&lab)
+	assert.NoError(t, err)
+	assert.Equal(t, lab.ID, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateLabAdminGroup(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	lab := types.Lab{
+		ID:           1,
+		Name:         "Laboratorium Elektronika",
+		AdminGroupID: -1001234567890,
+	}
+
+	repository := NewLabRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE labs SET admin_group_id = (.+), name = (.+) WHERE id = (.+)").
+		WithArgs(lab.AdminGroupID, lab.Name, lab.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.UpdateAdminGroup(lab.ID, lab.AdminGroupID, lab.Name)
+	assert.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type MaintenanceQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewMaintenanceQueryPostgres(DB *sql.DB) repository.MaintenanceQuery {
+	return &MaintenanceQueryPostgres{
+		DB: DB,
+	}
+}
+
+// GetByToolID reads the latest maintenance records of a tool, the latest first.
+func (mq MaintenanceQueryPostgres) GetByToolID(toolID int64, limit int) repository.QueryResult {
+	rows, err := mq.DB.Query(`
+		SELECT id, tool_id, maintenance_type, performed_at, technician, COALESCE(notes, ''), created_at
+		FROM tool_maintenances
+		WHERE tool_id = $1
+		ORDER BY performed_at DESC, id DESC
+		LIMIT $2
+	`, toolID, limit)
+
+	maintenances := []types.Maintenance{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.Maintenance{}
+		rows.Scan(
+			&temp.ID,
+			&temp.ToolID,
+			&temp.Type,
+			&temp.PerformedAt,
+			&temp.Technician,
+			&temp.Notes,
+			&temp.CreatedAt,
+		)
+
+		maintenances = append(maintenances, temp)
+	}
+
+	result.Result = maintenances
+	return result
+}
+
+// GetCalibrationDue reads the tools of the lab needing calibration whose next
+// calibration is due on or before the given date, including tools never
+// calibrated.
+func (mq MaintenanceQueryPostgres) GetCalibrationDue(labID int64, until time.Time) repository.QueryResult {
+	rows, err := mq.DB.Query(`
+		SELECT `+toolColumns+`, last_calibrated_at
+		FROM tools
+		LEFT JOIN (
+			SELECT tool_id, MAX(performed_at) AS last_calibrated_at
+			FROM tool_maintenances
+			WHERE maintenance_type = $1
+			GROUP BY tool_id
+		) c
+			ON c.tool_id = tools.id
+		WHERE deleted_at IS NULL
+			AND lab_id = $3
+			AND calibration_interval_days > 0
+			AND (last_calibrated_at IS NULL OR last_calibrated_at + calibration_interval_days <= $2::date)
+		ORDER BY last_calibrated_at + calibration_interval_days ASC NULLS FIRST, id ASC
+	`, types.MaintenanceTypeCalibration, until, labID)
+
+	dues := []types.CalibrationDue{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.CalibrationDue{}
+		rows.Scan(append(toolScanArgs(&temp.Tool), &temp.LastCalibratedAt)...)
+
+		dues = append(dues, temp)
+	}
+
+	result.Result = dues
+	return result
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanGetMaintenancesByToolID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewMaintenanceQueryPostgres(db)
+
+	maintenance := types.Maintenance{
+		ID:          1,
+		ToolID:      5,
+		Type:        types.MaintenanceTypeCalibration,
+		PerformedAt: "2021-08-03",
+		Technician:  "Budi",
+		Notes:       "sesuai standar",
+		CreatedAt:   timeNowString(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "tool_id", "maintenance_type", "performed_at", "technician", "notes", "created_at"}).
+		AddRow(maintenance.ID, maintenance.ToolID, maintenance.Type, maintenance.PerformedAt, maintenance.Technician, maintenance.Notes, maintenance.CreatedAt)
+
+	mock.ExpectQuery("^SELECT (.+) FROM tool_maintenances WHERE tool_id = (.+) ORDER BY performed_at DESC, id DESC LIMIT (.+)").
+		WithArgs(maintenance.ToolID, 10).
+		WillReturnRows(rows)
+
+	result := query.GetByToolID(maintenance.ToolID, 10)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.Maintenance)
+		assert.Equal(t, []types.Maintenance{maintenance}, r)
+	})
+}
+
+func TestCanGetCalibrationDue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewMaintenanceQueryPostgres(db)
+
+	until := time.Date(2021, time.August, 10, 0, 0, 0, 0, time.UTC)
+	lastCalibratedAt := time.Date(2021, time.February, 10, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id", "last_calibrated_at"}).
+		AddRow(1, "Osiloskop", "Rigol", "DS1054Z", 99.0, 2, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 180, timeNowString(), timeNowString(), 1, lastCalibratedAt).
+		AddRow(2, "Multimeter", "Sanwa", "CD800a", 99.0, 5, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 365, timeNowString(), timeNowString(), 1, nil)
+
+	mock.ExpectQuery("^SELECT (.+) FROM tools LEFT JOIN (.+) WHERE deleted_at IS NULL AND lab_id = (.+) AND calibration_interval_days > 0 (.+)").
+		WithArgs(types.MaintenanceTypeCalibration, until, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetCalibrationDue(1, until)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.CalibrationDue)
+		assert.Len(t, r, 2)
+		assert.Equal(t, time.Date(2021, time.August, 9, 0, 0, 0, 0, time.UTC), r[0].DueAt())
+		assert.False(t, r[1].LastCalibratedAt.Valid)
+	})
+}
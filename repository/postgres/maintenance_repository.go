@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type MaintenanceRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewMaintenanceRepositoryPostgres(DB *sql.DB) repository.MaintenanceRepository {
+	return &MaintenanceRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (mr *MaintenanceRepositoryPostgres) Save(maintenance *types.Maintenance) (int64, error) {
+	row := mr.DB.QueryRow(`INSERT INTO tool_maintenances (tool_id, maintenance_type, performed_at, technician, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, maintenance.ToolID, maintenance.Type, maintenance.PerformedAt, maintenance.Technician, maintenance.Notes)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
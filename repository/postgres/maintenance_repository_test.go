@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveMaintenance(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 3
+	maintenance := types.Maintenance{
+		ToolID:      5,
+		Type:        types.MaintenanceTypeRepair,
+		PerformedAt: "2021-08-03",
+		Technician:  "Budi",
+		Notes:       "ganti sekring",
+	}
+
+	repository := NewMaintenanceRepositoryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+
+	mock.ExpectQuery("^INSERT INTO tool_maintenances .+ VALUES .+ RETURNING id").
+		WithArgs(maintenance.ToolID, maintenance.Type, maintenance.PerformedAt, maintenance.Technician, maintenance.Notes).
+		WillReturnRows(rows)
+
+	result, err := repository.Save(&maintenance)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
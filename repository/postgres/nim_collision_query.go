@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type NIMCollisionQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewNIMCollisionQueryPostgres(DB *sql.DB) repository.NIMCollisionQuery {
+	return &NIMCollisionQueryPostgres{
+		DB: DB,
+	}
+}
+
+const nimCollisionColumns = `c.id, c.user_id, c.existing_user_id, c.name, c.nim, COALESCE(c.batch, 0), COALESCE(c.address, ''), c.language, c.status, c.created_at, c.resolved_at, c.resolved_by, COALESCE(c.resolved_by_user_id, 0),
+	u.name, u.nim, COALESCE(u.batch, 0), COALESCE(u.address, ''), u.created_at`
+
+func scanNIMCollision(scanner interface{ Scan(...interface{}) error }) (types.NIMCollision, error) {
+	c := types.NIMCollision{}
+	err := scanner.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.ExistingUserID,
+		&c.Name,
+		&c.NIM,
+		&c.Batch,
+		&c.Address,
+		&c.Language,
+		&c.Status,
+		&c.CreatedAt,
+		&c.ResolvedAt,
+		&c.ResolvedBy,
+		&c.ResolvedByUserID,
+		&c.ExistingUser.Name,
+		&c.ExistingUser.NIM,
+		&c.ExistingUser.Batch,
+		&c.ExistingUser.Address,
+		&c.ExistingUser.CreatedAt,
+	)
+	c.ExistingUser.ID = c.ExistingUserID
+	return c, err
+}
+
+func (cq NIMCollisionQueryPostgres) FindByID(id int64) repository.QueryResult {
+	row := cq.DB.QueryRow(`
+		SELECT `+nimCollisionColumns+`
+		FROM nim_collisions c
+		INNER JOIN users u
+			ON u.id = c.existing_user_id
+		WHERE c.id = $1
+	`, id)
+
+	result := repository.QueryResult{}
+
+	collision, err := scanNIMCollision(row)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = collision
+	return result
+}
+
+// GetPending reads the collisions waiting for an admin, the oldest first.
+func (cq NIMCollisionQueryPostgres) GetPending() repository.QueryResult {
+	rows, err := cq.DB.Query(`
+		SELECT `+nimCollisionColumns+`
+		FROM nim_collisions c
+		INNER JOIN users u
+			ON u.id = c.existing_user_id
+		WHERE c.status = $1
+		ORDER BY c.created_at ASC, c.id ASC
+	`, types.NIMCollisionStatusPending)
+
+	collisions := []types.NIMCollision{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		collision, err := scanNIMCollision(rows)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+
+		collisions = append(collisions, collision)
+	}
+
+	result.Result = collisions
+	return result
+}
+
+func (cq NIMCollisionQueryPostgres) FindPendingByUserID(userID int64) repository.QueryResult {
+	row := cq.DB.QueryRow(`
+		SELECT `+nimCollisionColumns+`
+		FROM nim_collisions c
+		INNER JOIN users u
+			ON u.id = c.existing_user_id
+		WHERE c.user_id = $1 AND c.status = $2
+		ORDER BY c.id DESC
+		LIMIT 1
+	`, userID, types.NIMCollisionStatusPending)
+
+	result := repository.QueryResult{}
+
+	collision, err := scanNIMCollision(row)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = collision
+	return result
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanFindNIMCollisionByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewNIMCollisionQueryPostgres(db)
+
+	collision := types.NIMCollision{
+		ID:             1,
+		UserID:         456,
+		ExistingUserID: 123,
+		Name:           "Fanny Hasbi",
+		NIM:            "21120117130000",
+		Batch:          2017,
+		Address:        "Tembalang",
+		Language:       types.LanguageIndonesian,
+		Status:         types.NIMCollisionStatusPending,
+		CreatedAt:      timeNowString(),
+		ExistingUser: types.User{
+			ID:        123,
+			Name:      "Fanny",
+			NIM:       "21120117130000",
+			Batch:     2017,
+			Address:   "Semarang",
+			CreatedAt: timeNowString(),
+		},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "existing_user_id", "name", "nim", "batch", "address", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id", "existing_name", "existing_nim", "existing_batch", "existing_address", "existing_created_at"}).
+		AddRow(collision.ID, collision.UserID, collision.ExistingUserID, collision.Name, collision.NIM, collision.Batch, collision.Address, collision.Language, collision.Status, collision.CreatedAt, nil, nil, 0, collision.ExistingUser.Name, collision.ExistingUser.NIM, collision.ExistingUser.Batch, collision.ExistingUser.Address, collision.ExistingUser.CreatedAt)
+
+	mock.ExpectQuery("^SELECT (.+) FROM nim_collisions c INNER JOIN users u (.+) WHERE c.id = (.+)").
+		WithArgs(collision.ID).
+		WillReturnRows(rows)
+
+	result := query.FindByID(collision.ID)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, collision, result.Result.(types.NIMCollision))
+}
+
+func TestCanGetPendingNIMCollisions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewNIMCollisionQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "existing_user_id", "name", "nim", "batch", "address", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id", "existing_name", "existing_nim", "existing_batch", "existing_address", "existing_created_at"}).
+		AddRow(1, 456, 123, "Fanny Hasbi", "21120117130000", 2017, "", types.LanguageIndonesian, types.NIMCollisionStatusPending, timeNowString(), nil, nil, 0, "Fanny", "21120117130000", 2017, "", timeNowString()).
+		AddRow(2, 789, 321, "Budi Santoso", "21120117130001", 2017, "", types.LanguageEnglish, types.NIMCollisionStatusPending, timeNowString(), nil, nil, 0, "Budi", "21120117130001", 2017, "", timeNowString())
+
+	mock.ExpectQuery("^SELECT (.+) FROM nim_collisions c INNER JOIN users u (.+) WHERE c.status = (.+) ORDER BY c.created_at ASC, c.id ASC").
+		WithArgs(types.NIMCollisionStatusPending).
+		WillReturnRows(rows)
+
+	result := query.GetPending()
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.NIMCollision)
+		assert.Len(t, r, 2)
+		assert.Equal(t, int64(1), r[0].ID)
+		assert.Equal(t, int64(123), r[0].ExistingUser.ID)
+		assert.Equal(t, int64(2), r[1].ID)
+		assert.Equal(t, int64(321), r[1].ExistingUser.ID)
+	})
+}
+
+func TestCanFindPendingNIMCollisionByUserID(t *testing.T) {
+	var userID int64 = 456
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewNIMCollisionQueryPostgres(db)
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "existing_user_id", "name", "nim", "batch", "address", "language", "status", "created_at", "resolved_at", "resolved_by", "resolved_by_user_id", "existing_name", "existing_nim", "existing_batch", "existing_address", "existing_created_at"}).
+			AddRow(1, userID, 123, "Fanny Hasbi", "21120117130000", 2017, "", types.LanguageIndonesian, types.NIMCollisionStatusPending, timeNowString(), nil, nil, 0, "Fanny", "21120117130000", 2017, "", timeNowString())
+
+		mock.ExpectQuery("^SELECT (.+) FROM nim_collisions c INNER JOIN users u (.+) WHERE c.user_id = (.+) AND c.status = (.+)").
+			WithArgs(userID, types.NIMCollisionStatusPending).
+			WillReturnRows(rows)
+
+		result := query.FindPendingByUserID(userID)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, "21120117130000", result.Result.(types.NIMCollision).NIM)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		query := NewNIMCollisionQueryPostgres(db)
+
+		mock.ExpectQuery("^SELECT (.+) FROM nim_collisions c INNER JOIN users u (.+) WHERE c.user_id = (.+) AND c.status = (.+)").
+			WithArgs(userID, types.NIMCollisionStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result := query.FindPendingByUserID(userID)
+		assert.Equal(t, sql.ErrNoRows, result.Error)
+	})
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type NIMCollisionRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewNIMCollisionRepositoryPostgres(DB *sql.DB) repository.NIMCollisionRepository {
+	return &NIMCollisionRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (cr *NIMCollisionRepositoryPostgres) Save(collision *types.NIMCollision) (int64, error) {
+	row := cr.DB.QueryRow(`INSERT INTO nim_collisions (user_id, existing_user_id, name, nim, batch, address, phone, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`, collision.UserID, collision.ExistingUserID, collision.Name, collision.NIM, collision.Batch, collision.Address, collision.Phone, collision.Language)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
+
+// Approve moves the NIM from the existing account to the account that
+// registered it, creating that account when the registration was held back.
+// The review counts as the approval of the registration, while the existing
+// account waits for a new one until it has a NIM again.
+func (cr *NIMCollisionRepositoryPostgres) Approve(id, resolvedByUserID int64, resolvedBy string) error {
+	tx, err := cr.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET nim = '', status = $2
+		WHERE id = (SELECT existing_user_id FROM nim_collisions WHERE id = $1)`, id, types.UserStatusPending); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO users (id, name, nim, batch, address, phone, user_type, language, status)
+		SELECT user_id, name, nim, batch, address, phone, $2, language, $3
+		FROM nim_collisions
+		WHERE id = $1
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, nim = EXCLUDED.nim, batch = EXCLUDED.batch, address = EXCLUDED.address, phone = EXCLUDED.phone, status = EXCLUDED.status`, id, types.UserTypeStudent, types.UserStatusApproved); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := resolveNIMCollision(tx, id, types.NIMCollisionStatusApproved, resolvedByUserID, resolvedBy); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reject keeps the NIM with the existing account.
+func (cr *NIMCollisionRepositoryPostgres) Reject(id, resolvedByUserID int64, resolvedBy string) error {
+	return resolveNIMCollision(cr.DB, id, types.NIMCollisionStatusRejected, resolvedByUserID, resolvedBy)
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func resolveNIMCollision(db execer, id int64, status types.NIMCollisionStatus, resolvedByUserID int64, resolvedBy string) error {
+	_, err := db.Exec(`UPDATE nim_collisions SET status = $1, resolved_at = NOW(), resolved_by_user_id = $2, resolved_by = $3
+		WHERE id = $4`, status, resolvedByUserID, resolvedBy, id)
+	return err
+}
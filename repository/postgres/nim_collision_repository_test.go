@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveNIMCollision(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 7
+	collision := types.NIMCollision{
+		UserID:         456,
+		ExistingUserID: 123,
+		Name:           "Fanny Hasbi",
+		NIM:            "21120117130000",
+		Batch:          2017,
+		Address:        "Tembalang",
+		Phone:          "081234567890",
+		Language:       types.LanguageIndonesian,
+	}
+
+	repository := NewNIMCollisionRepositoryPostgres(db)
+
+	mock.ExpectQuery("^INSERT INTO nim_collisions .+ VALUES .+ RETURNING id").
+		WithArgs(collision.UserID, collision.ExistingUserID, collision.Name, collision.NIM, collision.Batch, collision.Address, collision.Phone, collision.Language).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+	result, err := repository.Save(&collision)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanApproveNIMCollision(t *testing.T) {
+	var id int64 = 7
+	var resolvedByUserID int64 = 99
+	resolvedBy := "Budi"
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewNIMCollisionRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^UPDATE users SET nim = '', status = (.+) WHERE id = \\(SELECT existing_user_id FROM nim_collisions WHERE id = (.+)\\)").
+			WithArgs(id, types.UserStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("^INSERT INTO users (.+) SELECT (.+) FROM nim_collisions WHERE id = (.+) ON CONFLICT \\(id\\) DO UPDATE").
+			WithArgs(id, types.UserTypeStudent, types.UserStatusApproved).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("^UPDATE nim_collisions SET status = (.+), resolved_at = NOW\\(\\), resolved_by_user_id = (.+), resolved_by = (.+) WHERE id = (.+)").
+			WithArgs(types.NIMCollisionStatusApproved, resolvedByUserID, resolvedBy, id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.Approve(id, resolvedByUserID, resolvedBy)
+		assert.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolled back on error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewNIMCollisionRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^UPDATE users SET nim = ''").
+			WithArgs(id, types.UserStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("^INSERT INTO users").
+			WithArgs(id, types.UserTypeStudent, types.UserStatusApproved).
+			WillReturnError(errors.New("duplicate key"))
+		mock.ExpectRollback()
+
+		err := repository.Approve(id, resolvedByUserID, resolvedBy)
+		assert.Error(t, err)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCanRejectNIMCollision(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 7
+	var resolvedByUserID int64 = 99
+	resolvedBy := "Budi"
+
+	repository := NewNIMCollisionRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE nim_collisions SET status = (.+), resolved_at = NOW\\(\\), resolved_by_user_id = (.+), resolved_by = (.+) WHERE id = (.+)").
+		WithArgs(types.NIMCollisionStatusRejected, resolvedByUserID, resolvedBy, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.Reject(id, resolvedByUserID, resolvedBy)
+	assert.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
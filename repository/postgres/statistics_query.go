@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type StatisticsQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewStatisticsQueryPostgres(DB *sql.DB) repository.StatisticsQuery {
+	return &StatisticsQueryPostgres{
+		DB: DB,
+	}
+}
+
+// GetToolUtilization compares the unit-days each asset was lent out in the
+// window with the unit-days it could have been. A borrow lasts from its
+// confirmation until its return is confirmed, or until now while it is still
+// borrowed. The units of a tool are its stock plus the units lent out now,
+// since the stock is decreased while a unit is borrowed.
+func (sq StatisticsQueryPostgres) GetToolUtilization(labID int64, from, to time.Time, limit int) repository.QueryResult {
+	rows, err := sq.DB.Query(`
+		WITH loans AS (
+			SELECT b.tool_id, b.amount,
+				GREATEST(b.confirmed_at, $3) AS since,
+				LEAST(COALESCE(tr.confirmed_at, NOW()), $4) AS until
+			FROM borrows b
+			LEFT JOIN tool_returning tr
+				ON tr.borrow_id = b.id AND tr.status = $5
+			WHERE b.status IN ($1, $2)
+				AND b.confirmed_at < $4
+				AND COALESCE(tr.confirmed_at, NOW()) > $3
+		)
+		SELECT t.id, t.name,
+			SUM(l.amount * EXTRACT(EPOCH FROM (l.until - l.since))) / 86400 AS borrowed_days,
+			(t.stock + COALESCE(o.amount, 0)) * EXTRACT(EPOCH FROM ($4::timestamp - $3::timestamp)) / 86400 AS available_days
+		FROM loans l
+		INNER JOIN tools t
+			ON t.id = l.tool_id
+		LEFT JOIN (
+			SELECT tool_id, SUM(amount) AS amount
+			FROM borrows
+			WHERE status = $1
+			GROUP BY tool_id
+		) o
+			ON o.tool_id = t.id
+		WHERE t.kind = $6
+			AND t.lab_id = $8
+		GROUP BY t.id, t.name, t.stock, o.amount
+		ORDER BY borrowed_days / NULLIF(available_days, 0) DESC NULLS LAST, t.id ASC
+		LIMIT $7
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), from, to, types.GetToolReturningStatus("complete"), types.ToolKindAsset, limit, labID)
+
+	utilizations := []types.ToolUtilization{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.ToolUtilization{}
+		rows.Scan(
+			&temp.Tool.ID,
+			&temp.Tool.Name,
+			&temp.BorrowedDays,
+			&temp.AvailableDays,
+		)
+
+		utilizations = append(utilizations, temp)
+	}
+
+	result.Result = utilizations
+	return result
+}
+
+// GetTopBorrowedTools counts the borrows confirmed in the window by tool, the
+// most borrowed first.
+func (sq StatisticsQueryPostgres) GetTopBorrowedTools(labID int64, from, to time.Time, limit int) repository.QueryResult {
+	rows, err := sq.DB.Query(`
+		SELECT t.id, t.name, COUNT(b.id) AS borrows, SUM(b.amount) AS amount
+		FROM borrows b
+		INNER JOIN tools t
+			ON t.id = b.tool_id
+		WHERE b.status IN ($1, $2)
+			AND b.confirmed_at >= $3
+			AND b.confirmed_at < $4
+			AND t.lab_id = $6
+		GROUP BY t.id, t.name
+		ORDER BY borrows DESC, amount DESC, t.id ASC
+		LIMIT $5
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), from, to, limit, labID)
+
+	counts := []types.ToolBorrowCount{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.ToolBorrowCount{}
+		rows.Scan(
+			&temp.Tool.ID,
+			&temp.Tool.Name,
+			&temp.Borrows,
+			&temp.Amount,
+		)
+
+		counts = append(counts, temp)
+	}
+
+	result.Result = counts
+	return result
+}
+
+// GetUsageSummary averages the borrows confirmed in the window and counts the
+// returns completed in it. The response time covers rejected requests too, and
+// a return is late when it was requested after the borrow duration ended.
+func (sq StatisticsQueryPostgres) GetUsageSummary(labID int64, from, to time.Time) repository.QueryResult {
+	row := sq.DB.QueryRow(`
+		WITH r AS (
+			SELECT
+				COUNT(tr.id) AS returns,
+				COUNT(tr.id) FILTER (WHERE tr.created_at > rb.confirmed_at + rb.duration * INTERVAL '1 day') AS late_returns
+			FROM tool_returning tr
+			INNER JOIN borrows rb
+				ON rb.id = tr.borrow_id
+			WHERE tr.status = $6
+				AND tr.confirmed_at >= $4
+				AND tr.confirmed_at < $5
+				AND rb.tool_id IN (SELECT id FROM tools WHERE lab_id = $7)
+		)
+		SELECT
+			COUNT(b.id) FILTER (WHERE b.status IN ($1, $2)),
+			COALESCE(AVG(b.duration) FILTER (WHERE b.status IN ($1, $2)), 0),
+			COALESCE(AVG(EXTRACT(EPOCH FROM (b.confirmed_at - b.created_at))), 0),
+			(SELECT returns FROM r),
+			(SELECT late_returns FROM r)
+		FROM borrows b
+		WHERE b.status IN ($1, $2, $3)
+			AND b.confirmed_at >= $4
+			AND b.confirmed_at < $5
+			AND b.tool_id IN (SELECT id FROM tools WHERE lab_id = $7)
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetBorrowStatus("reject"), from, to, types.GetToolReturningStatus("complete"), labID)
+
+	summary := types.UsageSummary{}
+	var responseSeconds float64
+	result := repository.QueryResult{}
+
+	err := row.Scan(
+		&summary.Borrows,
+		&summary.AverageDuration,
+		&responseSeconds,
+		&summary.Returns,
+		&summary.LateReturns,
+	)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	summary.AverageResponse = time.Duration(responseSeconds * float64(time.Second))
+	result.Result = summary
+	return result
+}
+
+// GetBatchUsage counts the borrowers and the borrows confirmed in the window
+// by the batch of the borrowers, consumables included.
+func (sq StatisticsQueryPostgres) GetBatchUsage(labID int64, from, to time.Time) repository.QueryResult {
+	rows, err := sq.DB.Query(`
+		SELECT u.batch, COUNT(DISTINCT u.id) AS users, COUNT(b.id) AS borrows, SUM(b.amount) AS amount
+		FROM borrows b
+		INNER JOIN users u
+			ON u.id = b.user_id
+		WHERE b.status IN ($1, $2, $3)
+			AND b.confirmed_at >= $4
+			AND b.confirmed_at < $5
+			AND b.tool_id IN (SELECT id FROM tools WHERE lab_id = $6)
+		GROUP BY u.batch
+		ORDER BY u.batch DESC
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetBorrowStatus("consumed"), from, to, labID)
+
+	batches := []types.BatchUsage{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.BatchUsage{}
+		rows.Scan(
+			&temp.Batch,
+			&temp.Users,
+			&temp.Borrows,
+			&temp.Amount,
+		)
+
+		batches = append(batches, temp)
+	}
+
+	result.Result = batches
+	return result
+}
+
+// GetMonthlyUsage counts the borrows confirmed in each month of the window that
+// has any. A borrow is overdue when its return was requested after the borrow
+// duration ended, or when it is past the duration and not returned yet.
+func (sq StatisticsQueryPostgres) GetMonthlyUsage(labID int64, from, to time.Time) repository.QueryResult {
+	rows, err := sq.DB.Query(`
+		SELECT DATE_TRUNC('month', b.confirmed_at) AS month,
+			COUNT(b.id) AS borrows,
+			COUNT(b.id) FILTER (WHERE COALESCE(tr.created_at, NOW()) > b.confirmed_at + b.duration * INTERVAL '1 day') AS overdue
+		FROM borrows b
+		LEFT JOIN tool_returning tr
+			ON tr.borrow_id = b.id AND tr.status IN ($3, $4)
+		WHERE b.status IN ($1, $2)
+			AND b.confirmed_at >= $5
+			AND b.confirmed_at < $6
+			AND b.tool_id IN (SELECT id FROM tools WHERE lab_id = $7)
+		GROUP BY month
+		ORDER BY month ASC
+	`, types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetToolReturningStatus("request"), types.GetToolReturningStatus("complete"), from, to, labID)
+
+	months := []types.MonthlyUsage{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.MonthlyUsage{}
+		rows.Scan(
+			&temp.Month,
+			&temp.Borrows,
+			&temp.Overdue,
+		)
+
+		months = append(months, temp)
+	}
+
+	result.Result = months
+	return result
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	statisticsFrom = time.Date(2021, time.August, 1, 0, 0, 0, 0, time.Local)
+	statisticsTo   = statisticsFrom.AddDate(0, 1, 0)
+)
+
+func TestCanGetToolUtilization(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewStatisticsQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "borrowed_days", "available_days"}).
+		AddRow(3, "Osiloskop", 31.0, 62.0)
+
+	mock.ExpectQuery(`^WITH loans AS \( SELECT .+ FROM borrows b LEFT JOIN tool_returning tr .+\) SELECT .+ FROM loans l INNER JOIN tools t .+ WHERE t.kind = .+ GROUP BY .+ LIMIT .+`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), statisticsFrom, statisticsTo, types.GetToolReturningStatus("complete"), types.ToolKindAsset, 10, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetToolUtilization(1, statisticsFrom, statisticsTo, 10)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.ToolUtilization)
+		assert.Len(t, r, 1)
+		assert.Equal(t, "Osiloskop", r[0].Tool.Name)
+		assert.Equal(t, 0.5, r[0].Rate())
+	})
+}
+
+func TestCanGetTopBorrowedTools(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewStatisticsQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "borrows", "amount"}).
+		AddRow(3, "Osiloskop", 4, 5).
+		AddRow(7, "Multimeter", 2, 2)
+
+	mock.ExpectQuery(`^SELECT .+ FROM borrows b INNER JOIN tools t .+ WHERE b.status IN .+ AND b.confirmed_at >= .+ AND b.confirmed_at < .+ GROUP BY t.id, t.name ORDER BY borrows DESC, .+ LIMIT .+`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), statisticsFrom, statisticsTo, 5, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetTopBorrowedTools(1, statisticsFrom, statisticsTo, 5)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.ToolBorrowCount)
+		assert.Equal(t, []types.ToolBorrowCount{
+			{Tool: types.Tool{ID: 3, Name: "Osiloskop"}, Borrows: 4, Amount: 5},
+			{Tool: types.Tool{ID: 7, Name: "Multimeter"}, Borrows: 2, Amount: 2},
+		}, r)
+	})
+}
+
+func TestCanGetUsageSummary(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewStatisticsQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"borrows", "average_duration", "average_response", "returns", "late_returns"}).
+		AddRow(6, 4.5, 5400.0, 4, 1)
+
+	mock.ExpectQuery(`^WITH r AS \( SELECT .+ FROM tool_returning tr INNER JOIN borrows rb .+\) SELECT .+ FROM borrows b WHERE b.status IN .+`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetBorrowStatus("reject"), statisticsFrom, statisticsTo, types.GetToolReturningStatus("complete"), int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetUsageSummary(1, statisticsFrom, statisticsTo)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.UsageSummary)
+		assert.Equal(t, types.UsageSummary{
+			Borrows:         6,
+			AverageDuration: 4.5,
+			AverageResponse: 90 * time.Minute,
+			Returns:         4,
+			LateReturns:     1,
+		}, r)
+	})
+}
+
+func TestCanGetBatchUsage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewStatisticsQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"batch", "users", "borrows", "amount"}).
+		AddRow(2018, 3, 5, 7).
+		AddRow(2017, 1, 1, 1)
+
+	mock.ExpectQuery(`^SELECT u.batch, .+ FROM borrows b INNER JOIN users u .+ GROUP BY u.batch ORDER BY u.batch DESC`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetBorrowStatus("consumed"), statisticsFrom, statisticsTo, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetBatchUsage(1, statisticsFrom, statisticsTo)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.BatchUsage)
+		assert.Equal(t, []types.BatchUsage{
+			{Batch: 2018, Users: 3, Borrows: 5, Amount: 7},
+			{Batch: 2017, Users: 1, Borrows: 1, Amount: 1},
+		}, r)
+	})
+}
+
+func TestCanGetMonthlyUsage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewStatisticsQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"month", "borrows", "overdue"}).
+		AddRow(statisticsFrom, 6, 2)
+
+	mock.ExpectQuery(`^SELECT DATE_TRUNC\('month', b.confirmed_at\) AS month, .+ FROM borrows b LEFT JOIN tool_returning tr .+ GROUP BY month ORDER BY month ASC`).
+		WithArgs(types.GetBorrowStatus("progress"), types.GetBorrowStatus("returned"), types.GetToolReturningStatus("request"), types.GetToolReturningStatus("complete"), statisticsFrom, statisticsTo, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetMonthlyUsage(1, statisticsFrom, statisticsTo)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.MonthlyUsage)
+		assert.Equal(t, []types.MonthlyUsage{{Month: statisticsFrom, Borrows: 6, Overdue: 2}}, r)
+	})
+}
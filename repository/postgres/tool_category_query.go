@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type ToolCategoryQueryPostgres struct {
+	DB *sql.DB
+}
+
+func NewToolCategoryQueryPostgres(DB *sql.DB) repository.ToolCategoryQuery {
+	return &ToolCategoryQueryPostgres{
+		DB: DB,
+	}
+}
+
+func (cq ToolCategoryQueryPostgres) FindByID(id int64) repository.QueryResult {
+	row := cq.DB.QueryRow(`SELECT id, COALESCE(parent_id, 0), name, created_at, updated_at FROM tool_categories WHERE id = $1`, id)
+
+	return cq.scanRow(row)
+}
+
+// FindByName finds the category with the name, ignoring case, directly under
+// the parent. A zero parentID looks at the top level categories.
+func (cq ToolCategoryQueryPostgres) FindByName(parentID int64, name string) repository.QueryResult {
+	row := cq.DB.QueryRow(`SELECT id, COALESCE(parent_id, 0), name, created_at, updated_at FROM tool_categories WHERE COALESCE(parent_id, 0) = $1 AND lower(name) = lower($2)`, parentID, name)
+
+	return cq.scanRow(row)
+}
+
+func (cq ToolCategoryQueryPostgres) scanRow(row *sql.Row) repository.QueryResult {
+	category := types.ToolCategory{}
+	result := repository.QueryResult{}
+
+	err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = category
+	return result
+}
+
+// GetChildren reads the categories directly under the parent, or the top level
+// categories when parentID is zero.
+func (cq ToolCategoryQueryPostgres) GetChildren(parentID int64) repository.QueryResult {
+	rows, err := cq.DB.Query(`SELECT id, COALESCE(parent_id, 0), name, created_at, updated_at FROM tool_categories WHERE COALESCE(parent_id, 0) = $1 ORDER BY name ASC`, parentID)
+
+	return cq.scanRows(rows, err)
+}
+
+// GetPath reads the category and its ancestors, the top level category first.
+func (cq ToolCategoryQueryPostgres) GetPath(id int64) repository.QueryResult {
+	rows, err := cq.DB.Query(`
+		WITH RECURSIVE path AS (
+			SELECT id, parent_id, name, created_at, updated_at, 0 AS depth
+			FROM tool_categories
+			WHERE id = $1
+			UNION ALL
+			SELECT tc.id, tc.parent_id, tc.name, tc.created_at, tc.updated_at, path.depth + 1
+			FROM tool_categories tc
+			INNER JOIN path
+				ON tc.id = path.parent_id
+		)
+		SELECT id, COALESCE(parent_id, 0), name, created_at, updated_at
+		FROM path
+		ORDER BY depth DESC
+	`, id)
+
+	return cq.scanRows(rows, err)
+}
+
+func (cq ToolCategoryQueryPostgres) scanRows(rows *sql.Rows, err error) repository.QueryResult {
+	categories := []types.ToolCategory{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.ToolCategory{}
+		rows.Scan(
+			&temp.ID,
+			&temp.ParentID,
+			&temp.Name,
+			&temp.CreatedAt,
+			&temp.UpdatedAt,
+		)
+
+		categories = append(categories, temp)
+	}
+
+	result.Result = categories
+	return result
+}
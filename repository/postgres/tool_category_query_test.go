@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanFindToolCategoryByName(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolCategoryQueryPostgres(db)
+
+	category := types.ToolCategory{
+		ID:        2,
+		ParentID:  1,
+		Name:      "Alat Ukur",
+		CreatedAt: timeNowString(),
+		UpdatedAt: timeNowString(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"}).
+		AddRow(category.ID, category.ParentID, category.Name, category.CreatedAt, category.UpdatedAt)
+
+	mock.ExpectQuery("^SELECT (.+) FROM tool_categories WHERE COALESCE\\(parent_id, 0\\) = .+ AND lower\\(name\\) = lower\\(.+\\)").
+		WithArgs(category.ParentID, "alat ukur").
+		WillReturnRows(rows)
+
+	result := query.FindByName(category.ParentID, "alat ukur")
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.ToolCategory)
+		assert.Equal(t, category, r)
+	})
+}
+
+func TestCanGetToolCategoryChildren(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolCategoryQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"}).
+		AddRow(1, 0, "Elektronika", timeNowString(), timeNowString()).
+		AddRow(5, 0, "Mekanika", timeNowString(), timeNowString())
+
+	mock.ExpectQuery("^SELECT (.+) FROM tool_categories WHERE COALESCE\\(parent_id, 0\\) = .+ ORDER BY name ASC").
+		WithArgs(0).
+		WillReturnRows(rows)
+
+	result := query.GetChildren(0)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.ToolCategory)
+		assert.Len(t, r, 2)
+		assert.Equal(t, "Mekanika", r[1].Name)
+	})
+}
+
+func TestCanGetToolCategoryPath(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolCategoryQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"}).
+		AddRow(1, 0, "Elektronika", timeNowString(), timeNowString()).
+		AddRow(2, 1, "Alat Ukur", timeNowString(), timeNowString())
+
+	mock.ExpectQuery("^WITH RECURSIVE path AS (.+) SELECT (.+) FROM path ORDER BY depth DESC").
+		WithArgs(2).
+		WillReturnRows(rows)
+
+	result := query.GetPath(2)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.ToolCategory)
+		assert.Len(t, r, 2)
+		assert.Equal(t, "Elektronika", r[0].Name)
+		assert.Equal(t, "Alat Ukur", r[1].Name)
+	})
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type ToolCategoryRepositoryPostgres struct {
+	DB *sql.DB
+}
+
+func NewToolCategoryRepositoryPostgres(DB *sql.DB) repository.ToolCategoryRepository {
+	return &ToolCategoryRepositoryPostgres{
+		DB: DB,
+	}
+}
+
+func (cr *ToolCategoryRepositoryPostgres) Save(category *types.ToolCategory) (int64, error) {
+	row := cr.DB.QueryRow(`INSERT INTO tool_categories (parent_id, name)
+		VALUES (NULLIF($1, 0), $2)
+		RETURNING id`, category.ParentID, category.Name)
+
+	var id int64
+	err := row.Scan(&id)
+	if err != nil {
+		return int64(0), err
+	}
+
+	return id, nil
+}
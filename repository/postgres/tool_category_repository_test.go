@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSaveToolCategory(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 7
+	category := types.ToolCategory{
+		ParentID: 1,
+		Name:     "Alat Ukur",
+	}
+
+	repository := NewToolCategoryRepositoryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+
+	mock.ExpectQuery("^INSERT INTO tool_categories .+ VALUES .+ RETURNING id").
+		WithArgs(category.ParentID, category.Name).
+		WillReturnRows(rows)
+
+	result, err := repository.Save(&category)
+	assert.NoError(t, err)
+	assert.Equal(t, id, result)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -2,11 +2,40 @@ package postgres
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/fannyhasbi/lab-tools-lending/repository"
 	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/lib/pq"
 )
 
+// toolColumns are the columns read into a types.Tool by toolScanArgs.
+const toolColumns = `id, name, brand, product_type, weight, stock, kind, min_stock, additional_info, COALESCE(category_id, 0), tags, location_room, location_cabinet, location_shelf, status, calibration_interval_days, created_at, updated_at, lab_id`
+
+func toolScanArgs(tool *types.Tool) []interface{} {
+	return []interface{}{
+		&tool.ID,
+		&tool.Name,
+		&tool.Brand,
+		&tool.ProductType,
+		&tool.Weight,
+		&tool.Stock,
+		&tool.Kind,
+		&tool.MinStock,
+		&tool.AdditionalInformation,
+		&tool.CategoryID,
+		pq.Array(&tool.Tags),
+		&tool.Location.Room,
+		&tool.Location.Cabinet,
+		&tool.Location.Shelf,
+		&tool.Status,
+		&tool.CalibrationIntervalDays,
+		&tool.CreatedAt,
+		&tool.UpdatedAt,
+		&tool.LabID,
+	}
+}
+
 type ToolQueryPostgres struct {
 	DB *sql.DB
 }
@@ -18,22 +47,12 @@ func NewToolQueryPostgres(DB *sql.DB) repository.ToolQuery {
 }
 
 func (tq ToolQueryPostgres) FindByID(id int64) repository.QueryResult {
-	row := tq.DB.QueryRow(`SELECT id, name, brand, product_type, weight, stock, additional_info, created_at, updated_at FROM tools WHERE id = $1 AND deleted_at IS NULL`, id)
+	row := tq.DB.QueryRow(`SELECT `+toolColumns+` FROM tools WHERE id = $1 AND deleted_at IS NULL`, id)
 
 	tool := types.Tool{}
 	result := repository.QueryResult{}
 
-	err := row.Scan(
-		&tool.ID,
-		&tool.Name,
-		&tool.Brand,
-		&tool.ProductType,
-		&tool.Weight,
-		&tool.Stock,
-		&tool.AdditionalInformation,
-		&tool.CreatedAt,
-		&tool.UpdatedAt,
-	)
+	err := row.Scan(toolScanArgs(&tool)...)
 
 	if err != nil {
 		result.Error = err
@@ -44,8 +63,116 @@ func (tq ToolQueryPostgres) FindByID(id int64) repository.QueryResult {
 	return result
 }
 
-func (tq ToolQueryPostgres) Get() repository.QueryResult {
-	rows, err := tq.DB.Query(`SELECT id, name, brand, product_type, weight, stock, additional_info, created_at, updated_at FROM tools WHERE deleted_at IS NULL ORDER BY id ASC`)
+func (tq ToolQueryPostgres) Get(filter types.ToolFilter, cursor types.Cursor) repository.QueryResult {
+	return tq.getPage(`deleted_at IS NULL`, filter, cursor)
+}
+
+func (tq ToolQueryPostgres) GetAvailableTools(filter types.ToolFilter, cursor types.Cursor) repository.QueryResult {
+	return tq.getPage(`stock > 0 AND status = '`+string(types.ToolStatusActive)+`' AND deleted_at IS NULL`, filter, cursor)
+}
+
+// filterCondition appends the conditions of the filter to condition. Its
+// arguments are numbered after the given ones.
+func filterCondition(condition string, filter types.ToolFilter, args []interface{}) (string, []interface{}) {
+	if filter.CategoryID > 0 {
+		args = append(args, filter.CategoryID)
+		condition += fmt.Sprintf(` AND category_id IN (
+			WITH RECURSIVE c AS (
+				SELECT id FROM tool_categories WHERE id = $%d
+				UNION ALL
+				SELECT tc.id FROM tool_categories tc INNER JOIN c ON tc.parent_id = c.id
+			)
+			SELECT id FROM c
+		)`, len(args))
+	}
+
+	if len(filter.Tag) > 0 {
+		args = append(args, filter.Tag)
+		condition += fmt.Sprintf(` AND $%d = ANY(tags)`, len(args))
+	}
+
+	if len(filter.Status) > 0 {
+		args = append(args, filter.Status)
+		condition += fmt.Sprintf(` AND status = $%d`, len(args))
+	}
+
+	if filter.LabID > 0 {
+		args = append(args, filter.LabID)
+		condition += fmt.Sprintf(` AND lab_id = $%d`, len(args))
+	}
+
+	return condition, args
+}
+
+// getPage reads one page of the tools matching the condition and the filter.
+// One more row than the limit is read to know whether there is a page after it.
+func (tq ToolQueryPostgres) getPage(condition string, filter types.ToolFilter, cursor types.Cursor) repository.QueryResult {
+	var rows *sql.Rows
+	var err error
+	if cursor.Before > 0 {
+		condition, args := filterCondition(condition+` AND id < $1`, filter, []interface{}{cursor.Before})
+		args = append(args, cursor.Limit+1)
+		rows, err = tq.DB.Query(fmt.Sprintf(`SELECT %s FROM tools WHERE %s ORDER BY id DESC LIMIT $%d`, toolColumns, condition, len(args)), args...)
+	} else {
+		condition, args := filterCondition(condition+` AND id > $1`, filter, []interface{}{cursor.After})
+		args = append(args, cursor.Limit+1)
+		rows, err = tq.DB.Query(fmt.Sprintf(`SELECT %s FROM tools WHERE %s ORDER BY id ASC LIMIT $%d`, toolColumns, condition, len(args)), args...)
+	}
+
+	tools := []types.Tool{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	for rows.Next() {
+		temp := types.Tool{}
+		rows.Scan(toolScanArgs(&temp)...)
+
+		tools = append(tools, temp)
+	}
+
+	more := len(tools) > cursor.Limit
+	if more {
+		tools = tools[:cursor.Limit]
+	}
+
+	page := types.ToolPage{}
+	if cursor.Before > 0 {
+		for i, j := 0, len(tools)-1; i < j; i, j = i+1, j-1 {
+			tools[i], tools[j] = tools[j], tools[i]
+		}
+		page.HasPrevious = more
+		page.HasNext = true
+	} else {
+		page.HasPrevious = cursor.After > 0
+		page.HasNext = more
+	}
+	page.Tools = tools
+
+	result.Result = page
+	return result
+}
+
+// Search ranks the tools whose name, brand, product type or additional info
+// contain the keyword or resemble it, so that small typos still match. Tools
+// tagged with the keyword match as well. A zero lab searches every lab. The
+// keyword is matched with strpos rather than LIKE so "%" and "_" are taken
+// literally.
+func (tq ToolQueryPostgres) Search(keyword string, labID int64, onlyAvailable bool, limit int) repository.QueryResult {
+	rows, err := tq.DB.Query(`
+		SELECT `+toolColumns+`
+		FROM (
+			SELECT *, lower(coalesce(name, '') || ' ' || coalesce(brand, '') || ' ' || coalesce(product_type, '') || ' ' || coalesce(additional_info, '')) AS document
+			FROM tools
+			WHERE deleted_at IS NULL AND ((stock > 0 AND status = '`+string(types.ToolStatusActive)+`') OR NOT $2) AND ($5 = 0 OR lab_id = $5)
+		) t
+		WHERE strpos(t.document, $1) > 0 OR word_similarity($1, t.document) >= $3 OR $1 = ANY(t.tags)
+		ORDER BY word_similarity($1, lower(t.name)) DESC, word_similarity($1, t.document) DESC, t.id ASC
+		LIMIT $4
+	`, keyword, onlyAvailable, types.ToolSearchMinSimilarity, limit, labID)
 
 	tools := []types.Tool{}
 	result := repository.QueryResult{}
@@ -55,17 +182,7 @@ func (tq ToolQueryPostgres) Get() repository.QueryResult {
 	} else {
 		for rows.Next() {
 			temp := types.Tool{}
-			rows.Scan(
-				&temp.ID,
-				&temp.Name,
-				&temp.Brand,
-				&temp.ProductType,
-				&temp.Weight,
-				&temp.Stock,
-				&temp.AdditionalInformation,
-				&temp.CreatedAt,
-				&temp.UpdatedAt,
-			)
+			rows.Scan(toolScanArgs(&temp)...)
 
 			tools = append(tools, temp)
 		}
@@ -74,8 +191,10 @@ func (tq ToolQueryPostgres) Get() repository.QueryResult {
 	return result
 }
 
-func (tq ToolQueryPostgres) GetAvailableTools() repository.QueryResult {
-	rows, err := tq.DB.Query(`SELECT id, name, brand, product_type, weight, stock, additional_info, created_at, updated_at FROM tools WHERE stock > 0 AND deleted_at IS NULL ORDER BY id ASC`)
+// GetLowStock returns the consumables of the lab that have run down to their
+// minimum stock.
+func (tq ToolQueryPostgres) GetLowStock(labID int64) repository.QueryResult {
+	rows, err := tq.DB.Query(`SELECT `+toolColumns+` FROM tools WHERE kind = $1 AND stock <= min_stock AND lab_id = $2 AND deleted_at IS NULL ORDER BY stock ASC, id ASC`, types.ToolKindConsumable, labID)
 
 	tools := []types.Tool{}
 	result := repository.QueryResult{}
@@ -85,17 +204,7 @@ func (tq ToolQueryPostgres) GetAvailableTools() repository.QueryResult {
 	} else {
 		for rows.Next() {
 			temp := types.Tool{}
-			rows.Scan(
-				&temp.ID,
-				&temp.Name,
-				&temp.Brand,
-				&temp.ProductType,
-				&temp.Weight,
-				&temp.Stock,
-				&temp.AdditionalInformation,
-				&temp.CreatedAt,
-				&temp.UpdatedAt,
-			)
+			rows.Scan(toolScanArgs(&temp)...)
 
 			tools = append(tools, temp)
 		}
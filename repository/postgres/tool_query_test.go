@@ -15,19 +15,27 @@ func TestCanFindToolByID(t *testing.T) {
 	query := NewToolQueryPostgres(db)
 
 	tt := types.Tool{
-		ID:                    1,
-		Name:                  "nametest",
-		Brand:                 "brandtest",
-		ProductType:           "producttypetest",
-		Weight:                99.0,
-		Stock:                 10,
-		AdditionalInformation: "additionaltest",
-		CreatedAt:             timeNowString(),
-		UpdatedAt:             timeNowString(),
+		ID:                      1,
+		Name:                    "nametest",
+		Brand:                   "brandtest",
+		ProductType:             "producttypetest",
+		Weight:                  99.0,
+		Stock:                   10,
+		Kind:                    types.ToolKindConsumable,
+		MinStock:                5,
+		AdditionalInformation:   "additionaltest",
+		CategoryID:              3,
+		Tags:                    []string{"digital", "portable"},
+		Location:                types.ToolLocation{Room: "Lab 1", Cabinet: "A", Shelf: "2"},
+		Status:                  types.ToolStatusOutOfService,
+		CalibrationIntervalDays: 180,
+		CreatedAt:               timeNowString(),
+		UpdatedAt:               timeNowString(),
+		LabID:                   2,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "additional_info", "created_at", "updated_at"}).
-		AddRow(tt.ID, tt.Name, tt.Brand, tt.ProductType, tt.Weight, tt.Stock, tt.AdditionalInformation, tt.CreatedAt, tt.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(tt.ID, tt.Name, tt.Brand, tt.ProductType, tt.Weight, tt.Stock, tt.Kind, tt.MinStock, tt.AdditionalInformation, tt.CategoryID, "{digital,portable}", tt.Location.Room, tt.Location.Cabinet, tt.Location.Shelf, tt.Status, tt.CalibrationIntervalDays, tt.CreatedAt, tt.UpdatedAt, tt.LabID)
 
 	mock.ExpectQuery("^SELECT (.+) FROM tools WHERE id = (.+) AND deleted_at IS NULL").
 		WithArgs(tt.ID).
@@ -57,6 +65,8 @@ func TestCanGetTools(t *testing.T) {
 			Weight:                123,
 			Stock:                 10,
 			AdditionalInformation: "test additional info 1",
+			Tags:                  []string{},
+			Status:                types.ToolStatusActive,
 			CreatedAt:             timeNowString(),
 			UpdatedAt:             timeNowString(),
 		},
@@ -68,24 +78,81 @@ func TestCanGetTools(t *testing.T) {
 			Weight:                321,
 			Stock:                 100,
 			AdditionalInformation: "test additional info 2",
+			Tags:                  []string{},
+			Status:                types.ToolStatusActive,
 			CreatedAt:             timeNowString(),
 			UpdatedAt:             timeNowString(),
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "additional_info", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"})
 	for _, v := range tools {
-		rows.AddRow(v.ID, v.Name, v.Brand, v.ProductType, v.Weight, v.Stock, v.AdditionalInformation, v.CreatedAt, v.UpdatedAt)
+		rows.AddRow(v.ID, v.Name, v.Brand, v.ProductType, v.Weight, v.Stock, v.Kind, v.MinStock, v.AdditionalInformation, v.CategoryID, "{}", v.Location.Room, v.Location.Cabinet, v.Location.Shelf, v.Status, v.CalibrationIntervalDays, v.CreatedAt, v.UpdatedAt, v.LabID)
 	}
 
-	mock.ExpectQuery("^SELECT .+ FROM tools WHERE deleted_at IS NULL ORDER BY id ASC").WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE deleted_at IS NULL AND id > .+ ORDER BY id ASC LIMIT .+").
+		WithArgs(0, 21).
+		WillReturnRows(rows)
 
-	result := query.Get()
+	result := query.Get(types.ToolFilter{}, types.Cursor{Limit: 20})
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 	assert.NotPanics(t, func() {
-		r := result.Result.([]types.Tool)
-		assert.Equal(t, tools, r)
+		r := result.Result.(types.ToolPage)
+		assert.Equal(t, types.ToolPage{Tools: tools}, r)
+	})
+}
+
+func TestCanGetToolsNextPage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(11, "name 11", "brand", "type", 1.0, 1, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1).
+		AddRow(12, "name 12", "brand", "type", 1.0, 1, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1).
+		AddRow(13, "name 13", "brand", "type", 1.0, 1, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE deleted_at IS NULL AND id > .+ ORDER BY id ASC LIMIT .+").
+		WithArgs(10, 3).
+		WillReturnRows(rows)
+
+	result := query.Get(types.ToolFilter{}, types.Cursor{After: 10, Limit: 2})
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.ToolPage)
+		assert.Len(t, r.Tools, 2)
+		assert.Equal(t, int64(11), r.Tools[0].ID)
+		assert.Equal(t, int64(12), r.Tools[1].ID)
+		assert.True(t, r.HasPrevious)
+		assert.True(t, r.HasNext)
+	})
+}
+
+func TestCanGetToolsPreviousPage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(9, "name 9", "brand", "type", 1.0, 1, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1).
+		AddRow(8, "name 8", "brand", "type", 1.0, 1, "ASSET", 0, "info", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE deleted_at IS NULL AND id < .+ ORDER BY id DESC LIMIT .+").
+		WithArgs(10, 3).
+		WillReturnRows(rows)
+
+	result := query.Get(types.ToolFilter{}, types.Cursor{Before: 10, Limit: 2})
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.ToolPage)
+		assert.Len(t, r.Tools, 2)
+		assert.Equal(t, int64(8), r.Tools[0].ID)
+		assert.Equal(t, int64(9), r.Tools[1].ID)
+		assert.False(t, r.HasPrevious)
+		assert.True(t, r.HasNext)
 	})
 }
 
@@ -95,17 +162,116 @@ func TestCanGetAvailableTool(t *testing.T) {
 
 	query := NewToolQueryPostgres(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "additional_info", "created_at", "updated_at"}).
-		AddRow(1, "nametest", "brandtest", "producttypetest", 99.0, 10, "additionaltest", timeNowString(), timeNowString())
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(1, "nametest", "brandtest", "producttypetest", 99.0, 10, "ASSET", 0, "additionaltest", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
 
-	mock.ExpectQuery("^SELECT (.+) FROM tools WHERE stock > 0 AND deleted_at IS NULL ORDER BY id ASC").
+	mock.ExpectQuery("^SELECT (.+) FROM tools WHERE stock > 0 AND status = 'ACTIVE' AND deleted_at IS NULL AND id > .+ ORDER BY id ASC LIMIT .+").
+		WithArgs(0, 21).
 		WillReturnRows(rows)
 
-	result := query.GetAvailableTools()
+	result := query.GetAvailableTools(types.ToolFilter{}, types.Cursor{Limit: 20})
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 }
 
+func TestCanGetFilteredTools(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(1, "Solder", "Dekko", "60W", 99.0, 10, "ASSET", 0, "additionaltest", 3, "{solder}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE stock > 0 AND status = 'ACTIVE' AND deleted_at IS NULL AND id > \\$1 AND category_id IN \\( WITH RECURSIVE .+ \\) AND \\$3 = ANY\\(tags\\) ORDER BY id ASC LIMIT \\$4").
+		WithArgs(0, 3, "solder", 21).
+		WillReturnRows(rows)
+
+	result := query.GetAvailableTools(types.ToolFilter{CategoryID: 3, Tag: "solder"}, types.Cursor{Limit: 20})
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.ToolPage)
+		assert.Len(t, r.Tools, 1)
+		assert.Equal(t, []string{"solder"}, r.Tools[0].Tags)
+	})
+
+	err := mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanGetToolsByStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(4, "Osiloskop", "Rigol", "DS1054Z", 3000.0, 1, "ASSET", 0, "additionaltest", 0, "{}", "", "", "", "OUT_OF_SERVICE", 180, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE deleted_at IS NULL AND id > \\$1 AND status = \\$2 AND lab_id = \\$3 ORDER BY id ASC LIMIT \\$4").
+		WithArgs(0, types.ToolStatusOutOfService, int64(1), 21).
+		WillReturnRows(rows)
+
+	result := query.Get(types.ToolFilter{Status: types.ToolStatusOutOfService, LabID: 1}, types.Cursor{Limit: 20})
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.(types.ToolPage)
+		assert.Len(t, r.Tools, 1)
+		assert.Equal(t, types.ToolStatusOutOfService, r.Tools[0].Status)
+		assert.Equal(t, 180, r.Tools[0].CalibrationIntervalDays)
+	})
+
+	err := mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanGetLowStockTools(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(3, "Timah Solder", "Asahi", "0.8mm", 100.0, 2, "CONSUMABLE", 5, "additionaltest", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT .+ FROM tools WHERE kind = \\$1 AND stock <= min_stock AND lab_id = \\$2 AND deleted_at IS NULL").
+		WithArgs(types.ToolKindConsumable, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetLowStock(1)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.Tool)
+		assert.Len(t, r, 1)
+		assert.True(t, r[0].IsLowStock())
+	})
+}
+
+func TestCanSearchTools(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewToolQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "brand", "product_type", "weight", "stock", "kind", "min_stock", "additional_info", "category_id", "tags", "location_room", "location_cabinet", "location_shelf", "status", "calibration_interval_days", "created_at", "updated_at", "lab_id"}).
+		AddRow(1, "Multimeter Digital", "Sanwa", "CD800a", 99.0, 10, "ASSET", 0, "additionaltest", 0, "{}", "", "", "", "ACTIVE", 0, timeNowString(), timeNowString(), 1)
+
+	mock.ExpectQuery("^SELECT (.+) FROM (.+) WHERE deleted_at IS NULL AND (.+) WHERE strpos\\(t.document, \\$1\\) > 0 (.+) ORDER BY word_similarity(.+) LIMIT (.+)").
+		WithArgs("multimter", true, types.ToolSearchMinSimilarity, 10, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.Search("multimter", 1, true, 10)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.Tool)
+		assert.Len(t, r, 1)
+		assert.Equal(t, "Multimeter Digital", r[0].Name)
+	})
+
+	err := mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
 func TestCanGetPhotos(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
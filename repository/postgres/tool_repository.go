@@ -8,6 +8,7 @@ import (
 
 	"github.com/fannyhasbi/lab-tools-lending/repository"
 	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/lib/pq"
 )
 
 type ToolRepositoryPostgres struct {
@@ -21,15 +22,16 @@ func NewToolRepositoryPostgres(DB *sql.DB) repository.ToolRepository {
 }
 
 func (tr *ToolRepositoryPostgres) Save(tool *types.Tool) (int64, error) {
-	stmt, err := tr.DB.Prepare(`INSERT INTO tools (name, brand, product_type, weight, stock, additional_info)
-		VALUES ($1, $2, $3, $4, $5, $6)
+	stmt, err := tr.DB.Prepare(`INSERT INTO tools (name, brand, product_type, weight, stock, additional_info, category_id, tags, location_room, location_cabinet, location_shelf, kind, min_stock, lab_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id`)
 
 	if err != nil {
 		return int64(0), err
 	}
 
-	row := stmt.QueryRow(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation)
+	row := stmt.QueryRow(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation,
+		tool.CategoryID, pq.Array(toolTags(tool)), tool.Location.Room, tool.Location.Cabinet, tool.Location.Shelf, toolKind(tool), tool.MinStock, tool.LabID)
 
 	var id int64
 	err = row.Scan(&id)
@@ -41,16 +43,34 @@ func (tr *ToolRepositoryPostgres) Save(tool *types.Tool) (int64, error) {
 }
 
 func (tr *ToolRepositoryPostgres) Update(tool *types.Tool) error {
-	stmt, err := tr.DB.Prepare(`UPDATE tools SET name = $1, brand = $2, product_type = $3, weight = $4, stock = $5, additional_info = $6
-		WHERE id = $7`)
+	stmt, err := tr.DB.Prepare(`UPDATE tools SET name = $1, brand = $2, product_type = $3, weight = $4, stock = $5, additional_info = $6,
+		category_id = NULLIF($7, 0), tags = $8, location_room = $9, location_cabinet = $10, location_shelf = $11, kind = $12, min_stock = $13
+		WHERE id = $14`)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation, tool.ID)
+	_, err = stmt.Exec(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation,
+		tool.CategoryID, pq.Array(toolTags(tool)), tool.Location.Room, tool.Location.Cabinet, tool.Location.Shelf, toolKind(tool), tool.MinStock, tool.ID)
 	return err
 }
 
+// toolTags never returns nil, which pq would store as NULL.
+func toolTags(tool *types.Tool) []string {
+	if tool.Tags == nil {
+		return []string{}
+	}
+	return tool.Tags
+}
+
+// toolKind defaults a tool without kind to an asset.
+func toolKind(tool *types.Tool) types.ToolKind {
+	if len(tool.Kind) == 0 {
+		return types.ToolKindAsset
+	}
+	return tool.Kind
+}
+
 func (tr *ToolRepositoryPostgres) Delete(toolID int64, deletedAt time.Time) error {
 	stmt, err := tr.DB.Prepare(`UPDATE tools SET deleted_at = $1 WHERE id = $2`)
 	if err != nil {
@@ -105,3 +125,13 @@ func (tr *ToolRepositoryPostgres) DecreaseStock(toolID int64, amount int) error
 	_, err := tr.DB.Exec(`UPDATE tools SET stock = stock - $1 WHERE id = $2`, amount, toolID)
 	return err
 }
+
+func (tr *ToolRepositoryPostgres) UpdateStatus(toolID int64, status types.ToolStatus) error {
+	_, err := tr.DB.Exec(`UPDATE tools SET status = $1 WHERE id = $2`, status, toolID)
+	return err
+}
+
+func (tr *ToolRepositoryPostgres) UpdateCalibrationInterval(toolID int64, days int) error {
+	_, err := tr.DB.Exec(`UPDATE tools SET calibration_interval_days = $1 WHERE id = $2`, days, toolID)
+	return err
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fannyhasbi/lab-tools-lending/types"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,6 +22,12 @@ func TestCanSaveTool(t *testing.T) {
 		Weight:                120,
 		Stock:                 23,
 		AdditionalInformation: "test additional info",
+		CategoryID:            4,
+		Tags:                  []string{"digital"},
+		Location:              types.ToolLocation{Room: "Lab 1", Cabinet: "A", Shelf: "2"},
+		Kind:                  types.ToolKindConsumable,
+		MinStock:              5,
+		LabID:                 1,
 	}
 
 	repository := NewToolRepositoryPostgres(db)
@@ -29,7 +36,8 @@ func TestCanSaveTool(t *testing.T) {
 
 	mock.ExpectPrepare("^INSERT INTO tools .+ VALUES .+ RETURNING id").
 		ExpectQuery().
-		WithArgs(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation).
+		WithArgs(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation,
+			tool.CategoryID, pq.Array(tool.Tags), tool.Location.Room, tool.Location.Cabinet, tool.Location.Shelf, tool.Kind, tool.MinStock, tool.LabID).
 		WillReturnRows(rows)
 
 	result, err := repository.Save(&tool)
@@ -58,7 +66,8 @@ func TestCanUpdateTool(t *testing.T) {
 
 	mock.ExpectPrepare("^UPDATE tools SET .+ WHERE id = .+").
 		ExpectExec().
-		WithArgs(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation, tool.ID).
+		WithArgs(tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation,
+			tool.CategoryID, pq.Array([]string{}), tool.Location.Room, tool.Location.Cabinet, tool.Location.Shelf, types.ToolKindAsset, tool.MinStock, tool.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repository.Update(&tool)
@@ -165,3 +174,35 @@ func TestCanDecreaseStock(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestCanUpdateToolStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	repository := NewToolRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE tools SET status = .+ WHERE id = .+").
+		WithArgs(types.ToolStatusOutOfService, id).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repository.UpdateStatus(id, types.ToolStatusOutOfService)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateCalibrationInterval(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	repository := NewToolRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE tools SET calibration_interval_days = .+ WHERE id = .+").
+		WithArgs(180, id).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repository.UpdateCalibrationInterval(id, 180)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/fannyhasbi/lab-tools-lending/repository"
 	"github.com/fannyhasbi/lab-tools-lending/types"
@@ -19,7 +20,7 @@ func NewToolReturningQueryPostgres(DB *sql.DB) repository.ToolReturningQuery {
 
 func (trq ToolReturningQueryPostgres) FindByID(id int64) repository.QueryResult {
 	row := trq.DB.QueryRow(`
-		SELECT tr.id, tr.borrow_id, tr.status, tr.created_at, tr.additional_info, b.amount, b.duration, b.tool_id, b.confirmed_at AS borrow_confirmed_at, t.name AS tool_name, b.user_id, u.name AS user_name, u.nim, u.address
+		SELECT tr.id, tr.borrow_id, tr.status, tr.created_at, tr.additional_info, b.amount, b.duration, b.tool_id, b.confirmed_at AS borrow_confirmed_at, t.name AS tool_name, t.lab_id AS tool_lab_id, b.user_id, u.name AS user_name, u.nim, u.address
 		FROM tool_returning tr
 		INNER JOIN borrows b
 			ON b.id = tr.borrow_id
@@ -44,6 +45,7 @@ func (trq ToolReturningQueryPostgres) FindByID(id int64) repository.QueryResult
 		&ret.Borrow.ToolID,
 		&ret.Borrow.ConfirmedAt,
 		&ret.Borrow.Tool.Name,
+		&ret.Borrow.Tool.LabID,
 		&ret.Borrow.UserID,
 		&ret.Borrow.User.Name,
 		&ret.Borrow.User.NIM,
@@ -95,7 +97,8 @@ func (trq ToolReturningQueryPostgres) GetByUserIDAndStatus(id int64, status type
 	return result
 }
 
-func (trq ToolReturningQueryPostgres) GetByStatus(status types.ToolReturningStatus) repository.QueryResult {
+// GetByStatus reads the returnings of the tools of the lab with the status.
+func (trq ToolReturningQueryPostgres) GetByStatus(labID int64, status types.ToolReturningStatus) repository.QueryResult {
 	rows, err := trq.DB.Query(`
 		SELECT tr.id, tr.borrow_id, tr.status, tr.created_at, tr.additional_info, t.name AS tool_name, u.name AS user_name
 		FROM tool_returning tr
@@ -106,8 +109,9 @@ func (trq ToolReturningQueryPostgres) GetByStatus(status types.ToolReturningStat
 		INNER JOIN users u
 			ON u.id = b.user_id
 		WHERE tr.status = $1
+			AND t.lab_id = $2
 		ORDER BY tr.id ASC
-	`, status)
+	`, status, labID)
 
 	rets := []types.ToolReturning{}
 	result := repository.QueryResult{}
@@ -134,8 +138,10 @@ func (trq ToolReturningQueryPostgres) GetByStatus(status types.ToolReturningStat
 	return result
 }
 
-func (trq ToolReturningQueryPostgres) GetReport(year, month int) repository.QueryResult {
-	rows, err := trq.DB.Query(`SELECT tr.id, tr.borrow_id, tr.status, tr.created_at, tr.confirmed_at, tr.confirmed_by, b.amount, t.name AS tool_name, u.name AS user_name
+// GetReport finds the returnings of the tools of the lab confirmed in the
+// [from, to) window.
+func (trq ToolReturningQueryPostgres) GetReport(labID int64, from, to time.Time) repository.QueryResult {
+	rows, err := trq.DB.Query(`SELECT tr.id, tr.borrow_id, tr.status, tr.created_at, tr.confirmed_at, tr.confirmed_by, COALESCE(tr.confirmed_by_user_id, 0), COALESCE(cu.name, ''), b.amount, t.name AS tool_name, u.name AS user_name
 		FROM tool_returning tr
 		INNER JOIN borrows b
 			ON b.id = tr.borrow_id
@@ -143,11 +149,14 @@ func (trq ToolReturningQueryPostgres) GetReport(year, month int) repository.Quer
 			ON t.id = b.tool_id
 		INNER JOIN users u
 			ON u.id = b.user_id
+		LEFT JOIN users cu
+			ON cu.id = tr.confirmed_by_user_id
 		WHERE tr.status = $1
-			AND DATE_PART('year', tr.confirmed_at) = $2
-			AND DATE_PART('month', tr.confirmed_at) = $3
+			AND tr.confirmed_at >= $2
+			AND tr.confirmed_at < $3
+			AND t.lab_id = $4
 		ORDER BY tr.id ASC
-	`, types.GetToolReturningStatus("complete"), year, month)
+	`, types.GetToolReturningStatus("complete"), from, to, labID)
 
 	rets := []types.ToolReturning{}
 	result := repository.QueryResult{}
@@ -164,6 +173,8 @@ func (trq ToolReturningQueryPostgres) GetReport(year, month int) repository.Quer
 				&temp.CreatedAt,
 				&temp.ConfirmedAt,
 				&temp.ConfirmedBy,
+				&temp.ConfirmedByUser.ID,
+				&temp.ConfirmedByUser.Name,
 				&temp.Borrow.Amount,
 				&temp.Borrow.Tool.Name,
 				&temp.Borrow.User.Name,
@@ -43,8 +43,8 @@ func TestCanFindToolReturningByID(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "borrow_id", "status", "created_at", "additional_info", "amount", "duration", "tool_id", "borrow_confirmed_at", "tool_name", "user_id", "user_name", "nim", "address"}).
-		AddRow(toolReturning.ID, toolReturning.BorrowID, toolReturning.Status, toolReturning.CreatedAt, toolReturning.AdditionalInfo, toolReturning.Borrow.Amount, toolReturning.Borrow.Duration, toolReturning.Borrow.ToolID, toolReturning.Borrow.ConfirmedAt, toolReturning.Borrow.Tool.Name, toolReturning.Borrow.UserID, toolReturning.Borrow.User.Name, toolReturning.Borrow.User.NIM, toolReturning.Borrow.User.Address)
+	rows := sqlmock.NewRows([]string{"id", "borrow_id", "status", "created_at", "additional_info", "amount", "duration", "tool_id", "borrow_confirmed_at", "tool_name", "tool_lab_id", "user_id", "user_name", "nim", "address"}).
+		AddRow(toolReturning.ID, toolReturning.BorrowID, toolReturning.Status, toolReturning.CreatedAt, toolReturning.AdditionalInfo, toolReturning.Borrow.Amount, toolReturning.Borrow.Duration, toolReturning.Borrow.ToolID, toolReturning.Borrow.ConfirmedAt, toolReturning.Borrow.Tool.Name, toolReturning.Borrow.Tool.LabID, toolReturning.Borrow.UserID, toolReturning.Borrow.User.Name, toolReturning.Borrow.User.NIM, toolReturning.Borrow.User.Address)
 
 	mock.ExpectQuery("^SELECT .+ FROM tool_returning tr INNER JOIN borrows b .+ INNER JOIN tools t .+ INNER JOIN users u .+ WHERE tr.id = .+").
 		WithArgs(id).
@@ -153,10 +153,10 @@ func TestCanGetToolReturningByStatus(t *testing.T) {
 	}
 
 	mock.ExpectQuery("^SELECT .+ FROM tool_returning tr INNER JOIN borrows b .+ INNER JOIN tools t .+ INNER JOIN users u .+ WHERE tr.status = .+ ORDER BY tr.id ASC").
-		WithArgs(status).
+		WithArgs(status, int64(1)).
 		WillReturnRows(rows)
 
-	result := query.GetByStatus(status)
+	result := query.GetByStatus(1, status)
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 	assert.NotPanics(t, func() {
@@ -171,8 +171,8 @@ func TestCangGetToolReturningReport(t *testing.T) {
 
 	query := NewToolReturningQueryPostgres(db)
 
-	year := 2021
-	month := 8
+	from := time.Date(2021, time.August, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
 	toolRets := []types.ToolReturning{
 		{
 			ID:          123,
@@ -198,6 +198,10 @@ func TestCangGetToolReturningReport(t *testing.T) {
 			CreatedAt:   timeNowString(),
 			ConfirmedAt: sql.NullTime{Valid: true, Time: time.Now()},
 			ConfirmedBy: sql.NullString{Valid: true, String: "Test Confirmed By 2"},
+			ConfirmedByUser: types.User{
+				ID:   9,
+				Name: "Test Admin",
+			},
 			Borrow: types.Borrow{
 				Amount: 5,
 				Tool: types.Tool{
@@ -210,16 +214,16 @@ func TestCangGetToolReturningReport(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "borrow_id", "status", "created_at", "confirmed_at", "confirmed_by", "amount", "tool_name", "user_name"})
+	rows := sqlmock.NewRows([]string{"id", "borrow_id", "status", "created_at", "confirmed_at", "confirmed_by", "confirmed_by_user_id", "confirmed_by_user_name", "amount", "tool_name", "user_name"})
 	for _, v := range toolRets {
-		rows.AddRow(v.ID, v.BorrowID, v.Status, v.CreatedAt, v.ConfirmedAt, v.ConfirmedBy, v.Borrow.Amount, v.Borrow.Tool.Name, v.Borrow.User.Name)
+		rows.AddRow(v.ID, v.BorrowID, v.Status, v.CreatedAt, v.ConfirmedAt, v.ConfirmedBy, v.ConfirmedByUser.ID, v.ConfirmedByUser.Name, v.Borrow.Amount, v.Borrow.Tool.Name, v.Borrow.User.Name)
 	}
 
-	mock.ExpectQuery(`^SELECT .+ FROM tool_returning tr INNER JOIN borrows b .+ INNER JOIN tools t .+ INNER JOIN users u .+ WHERE tr.status = .+ AND DATE_PART\('year', tr.confirmed_at\) = .+ AND DATE_PART\('month', tr.confirmed_at\) = .+ ORDER BY tr.id ASC`).
-		WithArgs(types.GetToolReturningStatus("complete"), year, month).
+	mock.ExpectQuery(`^SELECT .+ FROM tool_returning tr INNER JOIN borrows b .+ INNER JOIN tools t .+ INNER JOIN users u .+ WHERE tr.status = .+ AND tr.confirmed_at >= .+ AND tr.confirmed_at < .+ ORDER BY tr.id ASC`).
+		WithArgs(types.GetToolReturningStatus("complete"), from, to, int64(1)).
 		WillReturnRows(rows)
 
-	result := query.GetReport(year, month)
+	result := query.GetReport(1, from, to)
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
 	assert.NotPanics(t, func() {
@@ -47,7 +47,7 @@ func (trr *ToolReturningRepositoryPostgres) UpdateStatus(id int64, status types.
 	return err
 }
 
-func (trr *ToolReturningRepositoryPostgres) UpdateConfirm(id int64, datetime time.Time, confirmedBy string) error {
-	_, err := trr.DB.Exec(`UPDATE tool_returning SET confirmed_at = $1, confirmed_by = $2 WHERE id = $3`, datetime, confirmedBy, id)
+func (trr *ToolReturningRepositoryPostgres) UpdateConfirm(id int64, datetime time.Time, confirmedByUserID int64, confirmedBy string) error {
+	_, err := trr.DB.Exec(`UPDATE tool_returning SET confirmed_at = $1, confirmed_by_user_id = $2, confirmed_by = $3 WHERE id = $4`, datetime, confirmedByUserID, confirmedBy, id)
 	return err
 }
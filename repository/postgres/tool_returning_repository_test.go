@@ -64,16 +64,17 @@ func TestCanUpdateConfirmToolReturning(t *testing.T) {
 	defer db.Close()
 
 	var id int64 = 123
+	var confirmedByUserID int64 = 7
 	now := time.Now()
 	confirmedBy := "Test Confirmed By"
 
 	repository := NewToolReturningRepositoryPostgres(db)
 
-	mock.ExpectExec("^UPDATE tool_returning SET confirmed_at = .+ confirmed_by = .+ WHERE id = .+").
-		WithArgs(now, confirmedBy, id).
+	mock.ExpectExec("^UPDATE tool_returning SET confirmed_at = .+ confirmed_by_user_id = .+ confirmed_by = .+ WHERE id = .+").
+		WithArgs(now, confirmedByUserID, confirmedBy, id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err := repository.UpdateConfirm(id, now, confirmedBy)
+	err := repository.UpdateConfirm(id, now, confirmedByUserID, confirmedBy)
 	assert.NoError(t, err)
 
 	err = mock.ExpectationsWereMet()
@@ -19,9 +19,9 @@ func NewUserQueryPostgres(DB *sql.DB) repository.UserQuery {
 
 func (uq UserQueryPostgres) FindByID(chatID int64) repository.QueryResult {
 	row := uq.DB.QueryRow(`
-		SELECT id, name, nim, batch, address, created_at, user_type
+		SELECT id, name, nim, batch, address, phone, created_at, user_type, language, status, role, COALESCE(admin_lab_id, 0), COALESCE(lab_id, 0), suspended_at, suspension_reason
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`, chatID)
 
 	user := types.User{}
@@ -33,8 +33,16 @@ func (uq UserQueryPostgres) FindByID(chatID int64) repository.QueryResult {
 		&user.NIM,
 		&user.Batch,
 		&user.Address,
+		&user.Phone,
 		&user.CreatedAt,
 		&user.UserType,
+		&user.Language,
+		&user.Status,
+		&user.Role,
+		&user.AdminLabID,
+		&user.LabID,
+		&user.SuspendedAt,
+		&user.SuspensionReason,
 	)
 
 	if err != nil {
@@ -45,3 +53,238 @@ func (uq UserQueryPostgres) FindByID(chatID int64) repository.QueryResult {
 	result.Result = user
 	return result
 }
+
+// FindByNIM reads the account registered with the NIM.
+func (uq UserQueryPostgres) FindByNIM(nim string) repository.QueryResult {
+	row := uq.DB.QueryRow(`
+		SELECT id, name, nim, batch, address, phone, created_at, user_type, language, status, role
+		FROM users
+		WHERE nim = $1
+	`, nim)
+
+	user := types.User{}
+	result := repository.QueryResult{}
+
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.NIM,
+		&user.Batch,
+		&user.Address,
+		&user.Phone,
+		&user.CreatedAt,
+		&user.UserType,
+		&user.Language,
+		&user.Status,
+		&user.Role,
+	)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = user
+	return result
+}
+
+// GetPendingRegistrations reads the students who filled the registration form
+// and wait for an admin, the oldest first.
+func (uq UserQueryPostgres) GetPendingRegistrations() repository.QueryResult {
+	rows, err := uq.DB.Query(`
+		SELECT id, name, nim, batch, address, phone, created_at, user_type, language, status
+		FROM users
+		WHERE status = $1 AND nim <> ''
+		ORDER BY created_at ASC, id ASC
+	`, types.UserStatusPending)
+
+	users := []types.User{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := types.User{}
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.Name,
+			&temp.NIM,
+			&temp.Batch,
+			&temp.Address,
+			&temp.Phone,
+			&temp.CreatedAt,
+			&temp.UserType,
+			&temp.Language,
+			&temp.Status,
+		); err != nil {
+			result.Error = err
+			return result
+		}
+
+		users = append(users, temp)
+	}
+
+	result.Result = users
+	return result
+}
+
+// GetAdmins reads the users who can respond to the requests of the lab, the
+// earliest admin first.
+func (uq UserQueryPostgres) GetAdmins(labID int64) repository.QueryResult {
+	rows, err := uq.DB.Query(`
+		SELECT id, name, username, created_at, user_type, role
+		FROM users
+		WHERE user_type IN ($1, $2) AND admin_lab_id = $3
+		ORDER BY created_at ASC, id ASC
+	`, types.UserTypeAdmin, types.UserTypeBoth, labID)
+
+	users := []types.User{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := types.User{}
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.Name,
+			&temp.Username,
+			&temp.CreatedAt,
+			&temp.UserType,
+			&temp.Role,
+		); err != nil {
+			result.Error = err
+			return result
+		}
+
+		users = append(users, temp)
+	}
+
+	result.Result = users
+	return result
+}
+
+// FindByUsername reads the account with the Telegram username, which is only
+// recorded for admins.
+func (uq UserQueryPostgres) FindByUsername(username string) repository.QueryResult {
+	row := uq.DB.QueryRow(`
+		SELECT id, name, username, created_at, user_type, role, COALESCE(admin_lab_id, 0)
+		FROM users
+		WHERE LOWER(username) = LOWER($1)
+	`, username)
+
+	user := types.User{}
+	result := repository.QueryResult{}
+
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Username,
+		&user.CreatedAt,
+		&user.UserType,
+		&user.Role,
+		&user.AdminLabID,
+	)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = user
+	return result
+}
+
+// labStudentCondition keeps the users who borrow from the lab in $1, or did
+// borrow one of its tools before.
+const labStudentCondition = `(users.lab_id = $1 OR EXISTS (
+	SELECT 1 FROM borrows b
+	JOIN tools t ON t.id = b.tool_id
+	WHERE b.user_id = users.id AND t.lab_id = $1
+))`
+
+// Search reads the students of the lab whose name contains the keyword, whose
+// NIM starts with it or whose batch is the keyword, ordered by name.
+func (uq UserQueryPostgres) Search(keyword string, labID int64, limit int) repository.QueryResult {
+	rows, err := uq.DB.Query(`
+		SELECT id, name, nim, batch, status, suspended_at
+		FROM users
+		WHERE `+labStudentCondition+`
+			AND nim <> '' AND (name ILIKE '%' || $2 || '%' OR nim LIKE $2 || '%' OR CAST(batch AS TEXT) = $2)
+		ORDER BY name ASC, id ASC
+		LIMIT $3
+	`, labID, keyword, limit)
+
+	return scanUserSummaries(rows, err)
+}
+
+// GetSuspended reads the students of the lab who may not borrow, the latest
+// suspension first.
+func (uq UserQueryPostgres) GetSuspended(labID int64) repository.QueryResult {
+	rows, err := uq.DB.Query(`
+		SELECT id, name, nim, batch, status, suspended_at
+		FROM users
+		WHERE `+labStudentCondition+` AND suspended_at IS NOT NULL
+		ORDER BY suspended_at DESC, id ASC
+	`, labID)
+
+	return scanUserSummaries(rows, err)
+}
+
+// IsLabStudent tells whether the user is a student of the lab, so an admin
+// of the lab may look after the user.
+func (uq UserQueryPostgres) IsLabStudent(id, labID int64) repository.QueryResult {
+	row := uq.DB.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM users WHERE `+labStudentCondition+` AND users.id = $2)
+	`, labID, id)
+
+	var exists bool
+	result := repository.QueryResult{}
+
+	if err := row.Scan(&exists); err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Result = exists
+	return result
+}
+
+func scanUserSummaries(rows *sql.Rows, err error) repository.QueryResult {
+	users := []types.User{}
+	result := repository.QueryResult{}
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := types.User{}
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.Name,
+			&temp.NIM,
+			&temp.Batch,
+			&temp.Status,
+			&temp.SuspendedAt,
+		); err != nil {
+			result.Error = err
+			return result
+		}
+
+		users = append(users, temp)
+	}
+
+	result.Result = users
+	return result
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fannyhasbi/lab-tools-lending/types"
@@ -15,14 +16,180 @@ func TestCanFindUserByID(t *testing.T) {
 	var id int64 = 123
 	query := NewUserQueryPostgres(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "created_at", "user_type"}).
-		AddRow(id, "testname", "2111", 2016, "testaddress", timeNowString(), types.UserTypeStudent)
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "phone", "created_at", "user_type", "language", "status", "role", "admin_lab_id", "lab_id", "suspended_at", "suspension_reason"}).
+		AddRow(id, "testname", "2111", 2016, "testaddress", "", timeNowString(), types.UserTypeBoth, types.LanguageIndonesian, types.UserStatusApproved, types.RoleAssistant, 1, 2, nil, "")
 
-	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE id = (.+)").
+	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE id = (.+) AND deleted_at IS NULL").
 		WithArgs(id).
 		WillReturnRows(rows)
 
 	result := query.FindByID(id)
 	assert.NoError(t, result.Error)
 	assert.NotEmpty(t, result.Result)
+	assert.Equal(t, types.RoleAssistant, result.Result.(types.User).Role)
+	assert.Equal(t, int64(1), result.Result.(types.User).AdminLabID)
+	assert.Equal(t, int64(2), result.Result.(types.User).LabID)
+}
+
+func TestCanFindUserByNIM(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	nim := "21120117130000"
+	query := NewUserQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "phone", "created_at", "user_type", "language", "status", "role"}).
+		AddRow(123, "testname", nim, 2017, "testaddress", "", timeNowString(), types.UserTypeStudent, types.LanguageIndonesian, types.UserStatusApproved, "")
+
+	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE nim = (.+)").
+		WithArgs(nim).
+		WillReturnRows(rows)
+
+	result := query.FindByNIM(nim)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, nim, result.Result.(types.User).NIM)
+}
+
+func TestCanGetPendingRegistrations(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewUserQueryPostgres(db)
+
+	user := types.User{
+		ID:        123,
+		Name:      "testname",
+		NIM:       "21120117130000",
+		Batch:     2017,
+		Address:   "testaddress",
+		CreatedAt: timeNowString(),
+		UserType:  types.UserTypeStudent,
+		Language:  types.LanguageIndonesian,
+		Status:    types.UserStatusPending,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "phone", "created_at", "user_type", "language", "status"}).
+		AddRow(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.Phone, user.CreatedAt, user.UserType, user.Language, user.Status)
+
+	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE status = (.+) AND nim <> '' ORDER BY created_at ASC, id ASC").
+		WithArgs(types.UserStatusPending).
+		WillReturnRows(rows)
+
+	result := query.GetPendingRegistrations()
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		r := result.Result.([]types.User)
+		assert.Equal(t, []types.User{user}, r)
+	})
+}
+
+func TestCanGetAdmins(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewUserQueryPostgres(db)
+
+	user := types.User{
+		ID:        123,
+		Name:      "Budi",
+		Username:  "budi",
+		CreatedAt: timeNowString(),
+		UserType:  types.UserTypeAdmin,
+		Role:      types.RoleHead,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "username", "created_at", "user_type", "role"}).
+		AddRow(user.ID, user.Name, user.Username, user.CreatedAt, user.UserType, user.Role)
+
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE user_type IN (.+) AND admin_lab_id = (.+) ORDER BY created_at ASC, id ASC").
+		WithArgs(types.UserTypeAdmin, types.UserTypeBoth, int64(1)).
+		WillReturnRows(rows)
+
+	result := query.GetAdmins(1)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, []types.User{user}, result.Result.([]types.User))
+}
+
+func TestCanFindUserByUsername(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := NewUserQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "username", "created_at", "user_type", "role", "admin_lab_id"}).
+		AddRow(123, "Budi", "budi", timeNowString(), types.UserTypeBoth, types.RoleLecturer, 1)
+
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE LOWER\\(username\\) = LOWER\\((.+)\\)").
+		WithArgs("Budi").
+		WillReturnRows(rows)
+
+	result := query.FindByUsername("Budi")
+	assert.NoError(t, result.Error)
+	assert.Equal(t, int64(123), result.Result.(types.User).ID)
+}
+
+func TestCanSearchUsers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	keyword := "2017"
+	var labID int64 = 2
+	query := NewUserQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "status", "suspended_at"}).
+		AddRow(123, "Budi", "21120117130000", 2017, types.UserStatusApproved, nil).
+		AddRow(124, "Sari", "21120117130001", 2017, types.UserStatusApproved, time.Now())
+
+	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE \\(users.lab_id = \\$1 OR EXISTS(.+)AND nim <> ''(.+)ORDER BY name ASC, id ASC(.+)LIMIT (.+)").
+		WithArgs(labID, keyword, types.UserSearchLimit).
+		WillReturnRows(rows)
+
+	result := query.Search(keyword, labID, types.UserSearchLimit)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		users := result.Result.([]types.User)
+		assert.Len(t, users, 2)
+		assert.False(t, users[0].IsSuspended())
+		assert.True(t, users[1].IsSuspended())
+	})
+}
+
+func TestCanGetSuspendedUsers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var labID int64 = 2
+	query := NewUserQueryPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "status", "suspended_at"}).
+		AddRow(124, "Sari", "21120117130001", 2017, types.UserStatusApproved, time.Now())
+
+	mock.ExpectQuery("^SELECT(.+)FROM users(.+)WHERE \\(users.lab_id = \\$1 OR EXISTS(.+)AND suspended_at IS NOT NULL").
+		WithArgs(labID).
+		WillReturnRows(rows)
+
+	result := query.GetSuspended(labID)
+	assert.NoError(t, result.Error)
+	assert.NotPanics(t, func() {
+		assert.Len(t, result.Result.([]types.User), 1)
+	})
+}
+
+func TestCanCheckLabStudent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id, labID int64 = 123, 2
+	query := NewUserQueryPostgres(db)
+
+	mock.ExpectQuery("^SELECT EXISTS \\(SELECT 1 FROM users WHERE \\(users.lab_id = \\$1 OR EXISTS \\(.+JOIN tools t ON t.id = b.tool_id.+\\)\\) AND users.id = \\$2\\)").
+		WithArgs(labID, id).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	result := query.IsLabStudent(id, labID)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, true, result.Result)
+
+	err := mock.ExpectationsWereMet()
+	assert.NoError(t, err)
 }
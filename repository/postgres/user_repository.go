@@ -17,10 +17,16 @@ func NewUserRepositoryPostgres(DB *sql.DB) repository.UserRepository {
 	}
 }
 
+// Save registers the user. A deleted user registering again gets the old row
+// back, keeping the borrowing history.
 func (ur *UserRepositoryPostgres) Save(user *types.User) (types.User, error) {
-	row := ur.DB.QueryRow(`INSERT INTO users (id, name, nim, batch, address, user_type)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, name, nim, batch, address, created_at, user_type`, user.ID, user.Name, user.NIM, user.Batch, user.Address, user.UserType)
+	row := ur.DB.QueryRow(`INSERT INTO users (id, name, nim, batch, address, user_type, language, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, nim = EXCLUDED.nim, batch = EXCLUDED.batch, address = EXCLUDED.address,
+			user_type = EXCLUDED.user_type, language = EXCLUDED.language, status = EXCLUDED.status, created_at = NOW(), deleted_at = NULL
+		WHERE users.deleted_at IS NOT NULL
+		RETURNING id, name, nim, batch, address, created_at, user_type, language, status`, user.ID, user.Name, user.NIM, user.Batch, user.Address, user.UserType, user.Language, user.Status)
 
 	u := types.User{}
 	err := row.Scan(
@@ -31,6 +37,8 @@ func (ur *UserRepositoryPostgres) Save(user *types.User) (types.User, error) {
 		&u.Address,
 		&u.CreatedAt,
 		&u.UserType,
+		&u.Language,
+		&u.Status,
 	)
 	if err != nil {
 		return types.User{}, err
@@ -40,9 +48,9 @@ func (ur *UserRepositoryPostgres) Save(user *types.User) (types.User, error) {
 }
 
 func (ur *UserRepositoryPostgres) Update(user *types.User) (types.User, error) {
-	row := ur.DB.QueryRow(`UPDATE users SET name = $1, nim = $2, batch = $3, address = $4
-		WHERE id = $5
-		RETURNING id, name, nim, batch, address, created_at`, user.Name, user.NIM, user.Batch, user.Address, user.ID)
+	row := ur.DB.QueryRow(`UPDATE users SET name = $1, nim = $2, batch = $3, address = $4, phone = $5
+		WHERE id = $6
+		RETURNING id, name, nim, batch, address, phone, created_at`, user.Name, user.NIM, user.Batch, user.Address, user.Phone, user.ID)
 
 	u := types.User{}
 	err := row.Scan(
@@ -51,6 +59,7 @@ func (ur *UserRepositoryPostgres) Update(user *types.User) (types.User, error) {
 		&u.NIM,
 		&u.Batch,
 		&u.Address,
+		&u.Phone,
 		&u.CreatedAt,
 	)
 	if err != nil {
@@ -69,3 +78,107 @@ func (ur *UserRepositoryPostgres) UpdateUserType(id int64, userType types.UserTy
 	_, err := ur.DB.Exec(`UPDATE users SET user_type = $1 WHERE id = $2`, userType, id)
 	return err
 }
+
+func (ur *UserRepositoryPostgres) UpdateLanguage(id int64, language types.Language) error {
+	_, err := ur.DB.Exec(`UPDATE users SET language = $1 WHERE id = $2`, language, id)
+	return err
+}
+
+func (ur *UserRepositoryPostgres) UpdateStatus(id int64, status types.UserStatus) error {
+	_, err := ur.DB.Exec(`UPDATE users SET status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+func (ur *UserRepositoryPostgres) UpdateUsername(id int64, username string) error {
+	_, err := ur.DB.Exec(`UPDATE users SET username = $1 WHERE id = $2`, username, id)
+	return err
+}
+
+func (ur *UserRepositoryPostgres) UpdateRole(id int64, role types.Role) error {
+	_, err := ur.DB.Exec(`UPDATE users SET role = $1 WHERE id = $2`, role, id)
+	return err
+}
+
+// UpdateAdminLab moves the admin to the lab, zero leaving every lab.
+func (ur *UserRepositoryPostgres) UpdateAdminLab(id, labID int64) error {
+	_, err := ur.DB.Exec(`UPDATE users SET admin_lab_id = NULLIF($1, 0) WHERE id = $2`, labID, id)
+	return err
+}
+
+// UpdateLab chooses the lab the student borrows from.
+func (ur *UserRepositoryPostgres) UpdateLab(id, labID int64) error {
+	_, err := ur.DB.Exec(`UPDATE users SET lab_id = NULLIF($1, 0) WHERE id = $2`, labID, id)
+	return err
+}
+
+// DeleteWithChatSessions removes the user together with the conversations it
+// has had with the bot.
+func (ur *UserRepositoryPostgres) DeleteWithChatSessions(id int64) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM chat_session_details
+		WHERE chat_session_id IN (SELECT id FROM chat_sessions WHERE user_id = $1)`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM chat_sessions WHERE user_id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM users WHERE id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SoftDelete removes the NIM reviews and the conversations of the user and
+// clears its personal data, but keeps the row with the name so the borrows and
+// their returns still count in the reports.
+func (ur *UserRepositoryPostgres) SoftDelete(id int64) error {
+	tx, err := ur.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM nim_collisions WHERE user_id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM chat_session_details
+		WHERE chat_session_id IN (SELECT id FROM chat_sessions WHERE user_id = $1)`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM chat_sessions WHERE user_id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET nim = '', address = '', phone = '', username = '', lab_id = NULL,
+			suspended_at = NULL, suspension_reason = '', deleted_at = NOW()
+		WHERE id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (ur *UserRepositoryPostgres) Suspend(id int64, reason string) error {
+	_, err := ur.DB.Exec(`UPDATE users SET suspended_at = NOW(), suspension_reason = $1 WHERE id = $2`, reason, id)
+	return err
+}
+
+func (ur *UserRepositoryPostgres) Unsuspend(id int64) error {
+	_, err := ur.DB.Exec(`UPDATE users SET suspended_at = NULL, suspension_reason = '' WHERE id = $1`, id)
+	return err
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -20,15 +21,17 @@ func TestCanSaveUser(t *testing.T) {
 		Address:   "jalan test message",
 		CreatedAt: timeNowString(),
 		UserType:  types.UserTypeStudent,
+		Language:  types.LanguageEnglish,
+		Status:    types.UserStatusPending,
 	}
 
 	repository := NewUserRepositoryPostgres(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "created_at", "user_type"}).
-		AddRow(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.CreatedAt, user.UserType)
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "created_at", "user_type", "language", "status"}).
+		AddRow(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.CreatedAt, user.UserType, user.Language, user.Status)
 
-	mock.ExpectQuery("^INSERT INTO users (.+) VALUES (.+) RETURNING (.+)").
-		WithArgs(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.UserType).
+	mock.ExpectQuery("^INSERT INTO users (.+) VALUES (.+) ON CONFLICT \\(id\\) DO UPDATE (.+) WHERE users.deleted_at IS NOT NULL RETURNING (.+)").
+		WithArgs(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.UserType, user.Language, user.Status).
 		WillReturnRows(rows)
 
 	result, err := repository.Save(&user)
@@ -49,16 +52,17 @@ func TestCanUpdateUser(t *testing.T) {
 		NIM:       "2112xxxxxxxxxx",
 		Batch:     2016,
 		Address:   "jalan test message",
+		Phone:     "+6281234567890",
 		CreatedAt: timeNowString(),
 	}
 
 	repository := NewUserRepositoryPostgres(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "created_at"}).
-		AddRow(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.CreatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "nim", "batch", "address", "phone", "created_at"}).
+		AddRow(user.ID, user.Name, user.NIM, user.Batch, user.Address, user.Phone, user.CreatedAt)
 
 	mock.ExpectQuery("^UPDATE users SET (.+) WHERE id = (.+)").
-		WithArgs(user.Name, user.NIM, user.Batch, user.Address, user.ID).
+		WithArgs(user.Name, user.NIM, user.Batch, user.Address, user.Phone, user.ID).
 		WillReturnRows(rows)
 
 	result, err := repository.Update(&user)
@@ -105,3 +109,252 @@ func TestCanUpdateUserType(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestCanUpdateUserLanguage(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	language := types.LanguageEnglish
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET language = .+ WHERE id = .+").
+		WithArgs(language, id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repository.UpdateLanguage(id, language)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateUserStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	status := types.UserStatusApproved
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET status = .+ WHERE id = .+").
+		WithArgs(status, id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repository.UpdateStatus(id, status)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateUsername(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET username = (.+) WHERE id = (.+)").
+		WithArgs("budi", id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.UpdateUsername(id, "budi")
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanDeleteUserWithChatSessions(t *testing.T) {
+	var id int64 = 123
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^DELETE FROM chat_session_details WHERE chat_session_id IN \\(SELECT id FROM chat_sessions WHERE user_id = (.+)\\)").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec("^DELETE FROM chat_sessions WHERE user_id = (.+)").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("^DELETE FROM users WHERE id = (.+)").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.DeleteWithChatSessions(id)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolled back on error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^DELETE FROM chat_session_details").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec("^DELETE FROM chat_sessions").
+			WithArgs(id).
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := repository.DeleteWithChatSessions(id)
+		assert.Error(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCanUpdateUserRole(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	role := types.RoleLecturer
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET role = (.+) WHERE id = (.+)").
+		WithArgs(role, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.UpdateRole(id, role)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUpdateUserLabs(t *testing.T) {
+	var id int64 = 123
+
+	t.Run("admin lab", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectExec("^UPDATE users SET admin_lab_id = NULLIF\\((.+), 0\\) WHERE id = (.+)").
+			WithArgs(int64(2), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.UpdateAdminLab(id, 2)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("lab", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectExec("^UPDATE users SET lab_id = NULLIF\\((.+), 0\\) WHERE id = (.+)").
+			WithArgs(int64(2), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.UpdateLab(id, 2)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCanSoftDeleteUser(t *testing.T) {
+	var id int64 = 123
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^DELETE FROM nim_collisions").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("^DELETE FROM chat_session_details").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec("^DELETE FROM chat_sessions").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("^UPDATE users SET nim = '', address = '', phone = '', username = '', lab_id = NULL, suspended_at = NULL, suspension_reason = '', deleted_at = NOW\\(\\) WHERE id = (.+)").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.SoftDelete(id)
+		assert.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolled back on error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		repository := NewUserRepositoryPostgres(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("^DELETE FROM nim_collisions").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("^DELETE FROM chat_session_details").
+			WithArgs(id).
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := repository.SoftDelete(id)
+		assert.Error(t, err)
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCanSuspendUser(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+	reason := "Terlambat mengembalikan osiloskop"
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET suspended_at = NOW(.+), suspension_reason = (.+) WHERE id = (.+)").
+		WithArgs(reason, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.Suspend(id, reason)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCanUnsuspendUser(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	var id int64 = 123
+
+	repository := NewUserRepositoryPostgres(db)
+
+	mock.ExpectExec("^UPDATE users SET suspended_at = NULL, suspension_reason = '' WHERE id = (.+)").
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repository.Unsuspend(id)
+	assert.NoError(t, err)
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
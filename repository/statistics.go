@@ -0,0 +1,13 @@
+package repository
+
+import "time"
+
+// StatisticsQuery aggregates the borrows of the tools of a lab in the
+// [from, to) window.
+type StatisticsQuery interface {
+	GetToolUtilization(labID int64, from, to time.Time, limit int) QueryResult
+	GetTopBorrowedTools(labID int64, from, to time.Time, limit int) QueryResult
+	GetUsageSummary(labID int64, from, to time.Time) QueryResult
+	GetBatchUsage(labID int64, from, to time.Time) QueryResult
+	GetMonthlyUsage(labID int64, from, to time.Time) QueryResult
+}
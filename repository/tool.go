@@ -8,8 +8,10 @@ import (
 
 type ToolQuery interface {
 	FindByID(id int64) QueryResult
-	Get() QueryResult
-	GetAvailableTools() QueryResult
+	Get(filter types.ToolFilter, cursor types.Cursor) QueryResult
+	GetAvailableTools(filter types.ToolFilter, cursor types.Cursor) QueryResult
+	Search(keyword string, labID int64, onlyAvailable bool, limit int) QueryResult
+	GetLowStock(labID int64) QueryResult
 	GetPhotos(toolID int64) QueryResult
 }
 
@@ -21,4 +23,6 @@ type ToolRepository interface {
 	DeletePhotos(toolID int64) error
 	IncreaseStock(toolID int64, amount int) error
 	DecreaseStock(toolID int64, amount int) error
+	UpdateStatus(toolID int64, status types.ToolStatus) error
+	UpdateCalibrationInterval(toolID int64, days int) error
 }
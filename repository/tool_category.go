@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/fannyhasbi/lab-tools-lending/types"
+
+type ToolCategoryQuery interface {
+	FindByID(id int64) QueryResult
+	FindByName(parentID int64, name string) QueryResult
+	GetChildren(parentID int64) QueryResult
+	GetPath(id int64) QueryResult
+}
+
+type ToolCategoryRepository interface {
+	Save(category *types.ToolCategory) (int64, error)
+}
@@ -9,12 +9,12 @@ import (
 type ToolReturningQuery interface {
 	FindByID(id int64) QueryResult
 	GetByUserIDAndStatus(id int64, status types.ToolReturningStatus) QueryResult
-	GetByStatus(status types.ToolReturningStatus) QueryResult
-	GetReport(year, month int) QueryResult
+	GetByStatus(labID int64, status types.ToolReturningStatus) QueryResult
+	GetReport(labID int64, from, to time.Time) QueryResult
 }
 
 type ToolReturningRepository interface {
 	Save(toolReturning *types.ToolReturning) (types.ToolReturning, error)
 	UpdateStatus(id int64, status types.ToolReturningStatus) error
-	UpdateConfirm(id int64, datetime time.Time, confirmedBy string) error
+	UpdateConfirm(id int64, datetime time.Time, confirmedByUserID int64, confirmedBy string) error
 }
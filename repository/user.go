@@ -4,6 +4,13 @@ import "github.com/fannyhasbi/lab-tools-lending/types"
 
 type UserQuery interface {
 	FindByID(chatID int64) QueryResult
+	FindByNIM(nim string) QueryResult
+	GetPendingRegistrations() QueryResult
+	GetAdmins(labID int64) QueryResult
+	FindByUsername(username string) QueryResult
+	Search(keyword string, labID int64, limit int) QueryResult
+	GetSuspended(labID int64) QueryResult
+	IsLabStudent(id, labID int64) QueryResult
 }
 
 type UserRepository interface {
@@ -11,4 +18,14 @@ type UserRepository interface {
 	Update(user *types.User) (types.User, error)
 	Delete(id int64) error
 	UpdateUserType(id int64, userType types.UserType) error
+	UpdateLanguage(id int64, language types.Language) error
+	UpdateStatus(id int64, status types.UserStatus) error
+	UpdateUsername(id int64, username string) error
+	UpdateRole(id int64, role types.Role) error
+	UpdateAdminLab(id, labID int64) error
+	UpdateLab(id, labID int64) error
+	DeleteWithChatSessions(id int64) error
+	SoftDelete(id int64) error
+	Suspend(id int64, reason string) error
+	Unsuspend(id int64) error
 }
@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Daily runs the job every day at the given hour of the local time. It blocks
+// forever, so it is meant to be started in its own goroutine.
+func Daily(hour int, job func() error) {
+	for {
+		time.Sleep(time.Until(NextRun(time.Now(), hour)))
+
+		if err := job(); err != nil {
+			log.Println("[ERR][Daily][job]", err)
+		}
+	}
+}
+
+// NextRun is the next time after now that the clock shows the given hour.
+func NextRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRun(t *testing.T) {
+	t.Run("later today", func(t *testing.T) {
+		now := time.Date(2021, time.August, 10, 5, 30, 0, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2021, time.August, 10, 7, 0, 0, 0, time.UTC), NextRun(now, 7))
+	})
+
+	t.Run("already passed", func(t *testing.T) {
+		now := time.Date(2021, time.August, 10, 7, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2021, time.August, 11, 7, 0, 0, 0, time.UTC), NextRun(now, 7))
+	})
+
+	t.Run("end of month", func(t *testing.T) {
+		now := time.Date(2021, time.August, 31, 20, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2021, time.September, 1, 7, 0, 0, 0, time.UTC), NextRun(now, 7))
+	})
+}
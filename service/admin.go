@@ -0,0 +1,99 @@
+package service
+
+import (
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// AdminService admits new admins, either with an invitation code issued by an
+// admin or by an admin approving their request.
+type AdminService struct {
+	InvitationRepository repository.AdminInvitationRepository
+	RequestQuery         repository.AdminRequestQuery
+	RequestRepository    repository.AdminRequestRepository
+}
+
+func NewAdminService() *AdminService {
+	var invitationRepository repository.AdminInvitationRepository
+	var requestQuery repository.AdminRequestQuery
+	var requestRepository repository.AdminRequestRepository
+
+	db := config.InitPostgresDB()
+	invitationRepository = postgres.NewAdminInvitationRepositoryPostgres(db)
+	requestQuery = postgres.NewAdminRequestQueryPostgres(db)
+	requestRepository = postgres.NewAdminRequestRepositoryPostgres(db)
+
+	return &AdminService{
+		InvitationRepository: invitationRepository,
+		RequestQuery:         requestQuery,
+		RequestRepository:    requestRepository,
+	}
+}
+
+// CreateInvitation issues a new invitation code to the lab valid for
+// types.AdminInvitationValidity.
+func (as AdminService) CreateInvitation(labID, createdBy int64) (types.AdminInvitation, error) {
+	code, err := helper.GenerateInvitationCode()
+	if err != nil {
+		return types.AdminInvitation{}, err
+	}
+
+	invitation := types.AdminInvitation{
+		Code:      code,
+		LabID:     labID,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(types.AdminInvitationValidity),
+	}
+
+	return as.InvitationRepository.Save(&invitation)
+}
+
+// RedeemInvitation returns sql.ErrNoRows when the code cannot be used in the
+// lab.
+func (as AdminService) RedeemInvitation(code string, labID, userID int64) error {
+	return as.InvitationRepository.Redeem(code, labID, userID)
+}
+
+func (as AdminService) SaveAdminRequest(request types.AdminRequest) (int64, error) {
+	return as.RequestRepository.Save(&request)
+}
+
+func (as AdminService) FindAdminRequestByID(id int64) (types.AdminRequest, error) {
+	result := as.RequestQuery.FindByID(id)
+	if result.Error != nil {
+		return types.AdminRequest{}, result.Error
+	}
+
+	return result.Result.(types.AdminRequest), nil
+}
+
+func (as AdminService) FindPendingAdminRequestByUserID(userID, labID int64) (types.AdminRequest, error) {
+	result := as.RequestQuery.FindPendingByUserID(userID, labID)
+	if result.Error != nil {
+		return types.AdminRequest{}, result.Error
+	}
+
+	return result.Result.(types.AdminRequest), nil
+}
+
+func (as AdminService) GetPendingAdminRequests(labID int64) ([]types.AdminRequest, error) {
+	result := as.RequestQuery.GetPending(labID)
+	if result.Error != nil {
+		return []types.AdminRequest{}, result.Error
+	}
+
+	return result.Result.([]types.AdminRequest), nil
+}
+
+func (as AdminService) ApproveAdminRequest(id, userID int64, firstName, lastName string) error {
+	return as.RequestRepository.Resolve(id, types.AdminRequestStatusApproved, userID, resolverName(firstName, lastName))
+}
+
+func (as AdminService) RejectAdminRequest(id, userID int64, firstName, lastName string) error {
+	return as.RequestRepository.Resolve(id, types.AdminRequestStatusRejected, userID, resolverName(firstName, lastName))
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// AuditService keeps the append-only trail of what the admins did.
+type AuditService struct {
+	Query      repository.AuditQuery
+	Repository repository.AuditRepository
+}
+
+func NewAuditService() *AuditService {
+	var auditQuery repository.AuditQuery
+	var auditRepository repository.AuditRepository
+
+	db := config.InitPostgresDB()
+	auditQuery = postgres.NewAuditQueryPostgres(db)
+	auditRepository = postgres.NewAuditRepositoryPostgres(db)
+
+	return &AuditService{
+		Query:      auditQuery,
+		Repository: auditRepository,
+	}
+}
+
+func (as AuditService) SaveAuditEntry(entry types.AuditEntry) (int64, error) {
+	return as.Repository.Save(&entry)
+}
+
+func (as AuditService) GetAuditEntries(labID int64) ([]types.AuditEntry, error) {
+	return as.result(as.Query.Get(labID, types.AuditLimit))
+}
+
+func (as AuditService) GetAuditEntriesByEntity(labID int64, entity types.AuditEntity, entityID int64) ([]types.AuditEntry, error) {
+	return as.result(as.Query.GetByEntity(labID, entity, entityID, types.AuditLimit))
+}
+
+func (as AuditService) GetAuditEntriesByActor(labID, actorUserID int64) ([]types.AuditEntry, error) {
+	return as.result(as.Query.GetByActor(labID, actorUserID, types.AuditLimit))
+}
+
+func (as AuditService) result(result repository.QueryResult) ([]types.AuditEntry, error) {
+	if result.Error != nil {
+		return []types.AuditEntry{}, result.Error
+	}
+
+	return result.Result.([]types.AuditEntry), nil
+}
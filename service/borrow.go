@@ -1,7 +1,6 @@
 package service
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/fannyhasbi/lab-tools-lending/config"
@@ -42,13 +41,11 @@ func (bs BorrowService) UpdateBorrowStatus(id int64, status types.BorrowStatus)
 	return bs.Repository.UpdateStatus(id, status)
 }
 
-func (bs BorrowService) UpdateBorrowConfirm(id int64, confirmedAt time.Time, firstName, lastName string) error {
-	confirmedBy := firstName
-	if len(lastName) > 0 {
-		confirmedBy = fmt.Sprintf("%s %s", firstName, lastName)
-	}
-
-	return bs.Repository.UpdateConfirm(id, confirmedAt, confirmedBy)
+// UpdateBorrowConfirm records the admin who responded to the request, when, and
+// the description they gave the borrower. The name is kept for when the admin
+// is deleted.
+func (bs BorrowService) UpdateBorrowConfirm(id int64, confirmedAt time.Time, userID int64, firstName, lastName, adminNotes string) error {
+	return bs.Repository.UpdateConfirm(id, confirmedAt, userID, resolverName(firstName, lastName), adminNotes)
 }
 
 func (bs BorrowService) FindBorrowByID(id int64) (types.Borrow, error) {
@@ -92,8 +89,18 @@ func (bs BorrowService) GetCurrentlyBeingBorrowedAndRequestedByUserID(id int64)
 	return result.Result.([]types.Borrow), result.Error
 }
 
-func (bs BorrowService) GetBorrowRequests() ([]types.Borrow, error) {
-	result := bs.Query.GetByStatus(types.GetBorrowStatus("request"))
+// CountLateByUserID counts the borrows of the user returned late or overdue.
+func (bs BorrowService) CountLateByUserID(id int64) (int, error) {
+	result := bs.Query.CountLateByUserID(id)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.Result.(int), nil
+}
+
+func (bs BorrowService) GetBorrowRequests(labID int64) ([]types.Borrow, error) {
+	result := bs.Query.GetByStatus(labID, types.GetBorrowStatus("request"))
 	if result.Error != nil {
 		return []types.Borrow{}, result.Error
 	}
@@ -101,11 +108,20 @@ func (bs BorrowService) GetBorrowRequests() ([]types.Borrow, error) {
 	return result.Result.([]types.Borrow), nil
 }
 
-func (bs BorrowService) GetBorrowReport(year, month int) ([]types.Borrow, error) {
-	result := bs.Query.GetReport(year, month)
+func (bs BorrowService) GetBorrowReport(labID int64, period types.ReportPeriod) ([]types.Borrow, error) {
+	result := bs.Query.GetReport(labID, period.From, period.To)
 	if result.Error != nil {
 		return []types.Borrow{}, result.Error
 	}
 
 	return result.Result.([]types.Borrow), nil
 }
+
+func (bs BorrowService) GetConsumptionReport(labID int64, period types.ReportPeriod) ([]types.Consumption, error) {
+	result := bs.Query.GetConsumptionReport(labID, period.From, period.To)
+	if result.Error != nil {
+		return []types.Consumption{}, result.Error
+	}
+
+	return result.Result.([]types.Consumption), nil
+}
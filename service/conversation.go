@@ -0,0 +1,267 @@
+package service
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/fannyhasbi/lab-tools-lending/conversation"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// conversationMachine registers every multi-step flow of the chatbot.
+func (ms *MessageService) conversationMachine() *conversation.Machine {
+	return conversation.NewMachine(
+		ms.chatSessionService.Repository,
+		ms.registerFlow(),
+		ms.profileFlow(),
+		ms.borrowFlow(),
+		ms.consumeFlow(),
+		ms.toolReturningFlow(),
+		ms.respondBorrowFlow(),
+		ms.respondToolReturningFlow(),
+		ms.manageAddFlow(),
+		ms.manageEditFlow(),
+		ms.manageDeleteFlow(),
+		ms.managePhotoFlow(),
+		ms.maintenanceFlow(),
+	)
+}
+
+func (ms *MessageService) registerFlow() conversation.Flow {
+	return conversation.Flow{
+		Name: "register",
+		States: []conversation.State{
+			{Topic: types.Topic["register_init"], Enter: ms.registerAskName, Accept: ms.registerAcceptName},
+			{Topic: types.Topic["register_name"], Label: "label.name", Enter: ms.registerAskNIM, Accept: ms.registerAcceptNIM},
+			{Topic: types.Topic["register_nim"], Label: "label.nim", Enter: ms.registerAskBatch, Accept: ms.registerAcceptBatch},
+			{Topic: types.Topic["register_batch"], Label: "label.batch", Enter: ms.registerAskAddress, Accept: ms.registerAcceptAddress},
+			{Topic: types.Topic["register_address"], Label: "label.address", Enter: ms.registerAskPhone, Accept: ms.registerAcceptPhone},
+			{Topic: types.Topic["register_phone"], Label: "label.phone", Enter: ms.registerConfirm, Accept: ms.registerAcceptConfirmation},
+			{Topic: types.Topic["register_complete"], Enter: ms.registerComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) profileFlow() conversation.Flow {
+	return conversation.Flow{
+		Name: "profile",
+		States: []conversation.State{
+			{Topic: types.Topic["profile_init"], Enter: ms.profileAskValue, Accept: ms.profileAcceptValue},
+			{Topic: types.Topic["profile_complete"], Enter: ms.profileComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) borrowFlow() conversation.Flow {
+	return conversation.Flow{
+		Name: "borrow",
+		States: []conversation.State{
+			{Topic: types.Topic["borrow_init"], Enter: ms.borrowAskAmount, Accept: ms.borrowAcceptAmount},
+			{Topic: types.Topic["borrow_amount"], Label: "label.amount", Enter: ms.borrowAskDuration, Accept: ms.borrowAcceptDuration},
+			{Topic: types.Topic["borrow_date"], Label: "label.duration", Enter: ms.borrowAskReason, Accept: ms.borrowAcceptReason},
+			{Topic: types.Topic["borrow_reason"], Label: "label.reason", Enter: ms.borrowSummary, Accept: ms.borrowAcceptConfirmation},
+			{Topic: types.Topic["borrow_confirm"], Enter: ms.borrowConfirm, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) consumeFlow() conversation.Flow {
+	return conversation.Flow{
+		Name: "consume",
+		States: []conversation.State{
+			{Topic: types.Topic["consume_init"], Enter: ms.consumeAskAmount, Accept: ms.consumeAcceptAmount},
+			{Topic: types.Topic["consume_amount"], Label: "label.amount", Enter: ms.consumeAskReason, Accept: ms.consumeAcceptReason},
+			{Topic: types.Topic["consume_reason"], Label: "label.reason", Enter: ms.consumeSummary, Accept: ms.consumeAcceptConfirmation},
+			{Topic: types.Topic["consume_confirm"], Enter: ms.consumeConfirm, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) toolReturningFlow() conversation.Flow {
+	return conversation.Flow{
+		Name: "tool_returning",
+		States: []conversation.State{
+			{Topic: types.Topic["tool_returning_init"], Enter: ms.toolReturningAskInfo, Accept: ms.toolReturningAcceptInfo},
+			{Topic: types.Topic["tool_returning_confirm"], Label: "label.return_info", Enter: ms.toolReturningSummary, Accept: ms.toolReturningAcceptConfirmation},
+			{Topic: types.Topic["tool_returning_complete"], Enter: ms.toolReturningComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) respondBorrowFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "respond_borrow",
+		Guard: ms.permissionGuard(types.PermissionBorrowApprove),
+		States: []conversation.State{
+			{Topic: types.Topic["respond_borrow_init"], Enter: ms.askRespondDescription, Accept: ms.respondBorrowAcceptDescription},
+			{Topic: types.Topic["respond_borrow_complete"], Enter: ms.respondBorrowComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) respondToolReturningFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "respond_tool_returning",
+		Guard: ms.permissionGuard(types.PermissionBorrowApprove),
+		States: []conversation.State{
+			{Topic: types.Topic["respond_tool_returning_init"], Enter: ms.askRespondDescription, Accept: ms.respondToolReturningAcceptDescription},
+			{Topic: types.Topic["respond_tool_returning_complete"], Enter: ms.respondToolReturningComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) manageAddFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "manage_add",
+		Guard: ms.permissionGuard(types.PermissionToolManage),
+		States: []conversation.State{
+			{Topic: types.Topic["manage_add_init"], Enter: ms.manageAddAskName, Accept: ms.manageAddAcceptName},
+			{Topic: types.Topic["manage_add_name"], Label: "label.name", Enter: ms.manageAddAskBrand, Accept: ms.manageAddAcceptBrand},
+			{Topic: types.Topic["manage_add_brand"], Label: "label.brand", Enter: ms.manageAddAskType, Accept: ms.manageAddAcceptType},
+			{Topic: types.Topic["manage_add_type"], Label: "label.product_type", Enter: ms.manageAddAskWeight, Accept: ms.manageAddAcceptWeight},
+			{Topic: types.Topic["manage_add_weight"], Label: "label.weight", Enter: ms.manageAddAskStock, Accept: ms.manageAddAcceptStock},
+			{Topic: types.Topic["manage_add_stock"], Label: "label.stock", Enter: ms.manageAddAskInfo, Accept: ms.manageAddAcceptInfo},
+			{Topic: types.Topic["manage_add_info"], Label: "label.description", Enter: ms.askToolPhoto, Accept: ms.manageAddAcceptPhoto},
+			{Topic: types.Topic["manage_add_photo"], Enter: ms.manageAddSummary, Accept: ms.manageAddAcceptConfirmation},
+			{Topic: types.Topic["manage_add_confirm"], Enter: ms.manageAddConfirm, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) manageEditFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "manage_edit",
+		Guard: ms.permissionGuard(types.PermissionToolManage),
+		States: []conversation.State{
+			{Topic: types.Topic["manage_edit_init"], Enter: ms.manageEditAskField, Accept: ms.manageEditAcceptField},
+			{Topic: types.Topic["manage_edit_field"], Enter: ms.manageEditAskValue, Accept: ms.manageEditAcceptValue},
+			{Topic: types.Topic["manage_edit_complete"], Enter: ms.manageEditComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) manageDeleteFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "manage_delete",
+		Guard: ms.permissionGuard(types.PermissionToolManage),
+		States: []conversation.State{
+			{Topic: types.Topic["manage_delete_init"], Enter: ms.manageDeleteAskConfirmation, Accept: ms.manageDeleteAcceptConfirmation},
+			{Topic: types.Topic["manage_delete_complete"], Enter: ms.manageDeleteComplete, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) managePhotoFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "manage_photo",
+		Guard: ms.permissionGuard(types.PermissionToolManage),
+		States: []conversation.State{
+			{Topic: types.Topic["manage_photo_init"], Enter: ms.askToolPhoto, Accept: ms.managePhotoAcceptUpload},
+			{Topic: types.Topic["manage_photo_upload"], Enter: ms.managePhotoUploaded, Accept: ms.managePhotoAcceptConfirmation},
+			{Topic: types.Topic["manage_photo_confirm"], Enter: ms.managePhotoConfirm, Final: true},
+		},
+	}
+}
+
+func (ms *MessageService) maintenanceFlow() conversation.Flow {
+	return conversation.Flow{
+		Name:  "maintenance",
+		Guard: ms.permissionGuard(types.PermissionToolManage),
+		States: []conversation.State{
+			{Topic: types.Topic["maintenance_init"], Enter: ms.maintenanceAskType, Accept: ms.maintenanceAcceptType},
+			{Topic: types.Topic["maintenance_type"], Label: "label.maintenance", Enter: ms.maintenanceAskDate, Accept: ms.maintenanceAcceptDate},
+			{Topic: types.Topic["maintenance_date"], Label: "label.date", Enter: ms.maintenanceAskTechnician, Accept: ms.maintenanceAcceptTechnician},
+			{Topic: types.Topic["maintenance_technician"], Label: "label.technician", Enter: ms.maintenanceAskNotes, Accept: ms.maintenanceAcceptNotes},
+			{Topic: types.Topic["maintenance_notes"], Label: "label.notes", Enter: ms.maintenanceSummary, Accept: ms.maintenanceAcceptConfirmation},
+			{Topic: types.Topic["maintenance_confirm"], Enter: ms.maintenanceConfirm, Final: true},
+		},
+	}
+}
+
+// permissionGuard keeps a flow to the admins whose role has the permission.
+func (ms *MessageService) permissionGuard(permission types.Permission) func(c *conversation.Context) bool {
+	return func(c *conversation.Context) bool {
+		return ms.can(permission)
+	}
+}
+
+func (ms *MessageService) newConversationContext(chatSession types.ChatSession) *conversation.Context {
+	return &conversation.Context{
+		UserID:      ms.user.ID,
+		RequestType: ms.requestType,
+		Session:     chatSession,
+		Printer:     ms.printer,
+		Details:     ms.chatSessionDetails,
+		Input: conversation.Input{
+			Text:    ms.messageText,
+			Message: ms.message,
+		},
+	}
+}
+
+// ContinueConversation passes the message to the flow the chat session is currently in.
+func (ms *MessageService) ContinueConversation(chatSession types.ChatSession) error {
+	c := ms.newConversationContext(chatSession)
+	err := ms.conversationMachine().Handle(c)
+	return ms.finishConversationStep(c, err)
+}
+
+// startConversation moves the user into the first state of a flow.
+func (ms *MessageService) startConversation(t conversation.Transition) error {
+	chatSession, err := ms.chatSessionService.GetChatSession(ms.user, ms.requestType)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][startConversation][GetChatSession]", err)
+		return ms.Error()
+	}
+
+	c := ms.newConversationContext(chatSession)
+	err = ms.conversationMachine().Start(c, t)
+	return ms.finishConversationStep(c, err)
+}
+
+func (ms *MessageService) finishConversationStep(c *conversation.Context, err error) error {
+	ms.chatSessionDetails = c.Details
+
+	for _, reply := range c.Replies() {
+		if err := ms.sendMessage(reply); err != nil {
+			log.Println("error in sending reply:", err)
+			return err
+		}
+	}
+
+	switch err {
+	case nil:
+		return nil
+	case conversation.ErrUnknownState:
+		return ms.Unknown()
+	case errAuditFailed:
+		return ms.auditFailed()
+	case conversation.ErrNotAllowed:
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
+
+	log.Println("[ERR][conversation]", c.Topic(), err)
+	return ms.Error()
+}
+
+func isPositiveResponse(text string) bool {
+	return text == "yes"
+}
+
+func confirmationKeyboard(positive, negative string) types.InlineKeyboardMarkup {
+	return types.InlineKeyboardMarkup{
+		InlineKeyboard: [][]types.InlineKeyboardButton{
+			{
+				{
+					Text:         positive,
+					CallbackData: "yes",
+				},
+				{
+					Text:         negative,
+					CallbackData: "no",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// InlineQueryService answers "@bot [kata kunci]" queries typed in any chat with
+// the available tools matching the keyword, from the lab the user borrows from.
+type InlineQueryService struct {
+	query   types.InlineQuery
+	printer i18n.Printer
+
+	// labID is the lab the user borrows from, zero when not chosen.
+	labID int64
+
+	userService *UserService
+	toolService *ToolService
+	labService  *LabService
+}
+
+func NewInlineQueryService(query types.InlineQuery) *InlineQueryService {
+	is := &InlineQueryService{
+		query:       query,
+		userService: NewUserService(),
+		toolService: NewToolService(),
+		labService:  NewLabService(),
+	}
+
+	is.initUser()
+
+	return is
+}
+
+func (is *InlineQueryService) initUser() {
+	language := i18n.LanguageFromTelegram(is.query.From.LanguageCode)
+
+	user, err := is.userService.FindByID(is.query.From.ID)
+	if err == nil && len(user.Language) > 0 {
+		language = user.Language
+	}
+	if err == nil {
+		is.labID = user.LabID
+	}
+
+	is.printer = i18n.NewPrinter(language)
+}
+
+// Answer searches the tools when a keyword is typed, or pages through every
+// available tool otherwise.
+func (is *InlineQueryService) Answer() error {
+	keyword := strings.Join(strings.Fields(is.query.Query), " ")
+
+	var tools []types.Tool
+	nextOffset := ""
+
+	if len(keyword) > 0 {
+		result, err := is.toolService.SearchTools(keyword, is.labID, true)
+		if err != nil {
+			log.Println("[ERR][Answer][SearchTools]", err)
+			return err
+		}
+		tools = result
+	} else {
+		page, err := is.toolService.GetAvailableTools(types.ToolFilter{LabID: is.labID}, helper.GetInlineQueryCursor(is.query.Offset))
+		if err != nil {
+			log.Println("[ERR][Answer][GetAvailableTools]", err)
+			return err
+		}
+
+		tools = page.Tools
+		if page.HasNext && len(tools) > 0 {
+			nextOffset = strconv.FormatInt(tools[len(tools)-1].ID, 10)
+		}
+	}
+
+	labNames, err := is.labNames()
+	if err != nil {
+		log.Println("[ERR][Answer][labNames]", err)
+		return err
+	}
+
+	results := []types.InlineQueryResultArticle{}
+	for _, tool := range tools {
+		link := config.BotDeepLink(fmt.Sprintf("%s%d", types.StartPayloadBorrow, tool.ID))
+		results = append(results, helper.BuildToolArticle(is.printer, tool, labNames[tool.LabID], link))
+	}
+
+	reqBody := types.AnswerInlineQueryRequest{
+		InlineQueryID: is.query.ID,
+		Results:       results,
+		CacheTime:     types.InlineQueryCacheTime,
+		IsPersonal:    true,
+		NextOffset:    nextOffset,
+	}
+
+	if len(results) == 0 {
+		reqBody.SwitchPMText = is.printer.Text("inline.empty")
+		reqBody.SwitchPMParam = types.CommandCheck
+	}
+
+	return is.answerInlineQuery(reqBody)
+}
+
+// labNames names the labs of the tools when a user who has not chosen a lab
+// gets the tools of several labs, and is empty otherwise.
+func (is *InlineQueryService) labNames() (map[int64]string, error) {
+	names := map[int64]string{}
+	if is.labID != 0 {
+		return names, nil
+	}
+
+	labs, err := is.labService.GetLabs()
+	if err != nil {
+		return names, err
+	}
+
+	if len(labs) > 1 {
+		for _, lab := range labs {
+			names[lab.ID] = lab.Name
+		}
+	}
+
+	return names, nil
+}
+
+func (is *InlineQueryService) answerInlineQuery(reqBody types.AnswerInlineQueryRequest) error {
+	reqBytes, err := json.Marshal(&reqBody)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.Post(fmt.Sprintf("%s/answerInlineQuery", config.WebhookUrl()), "application/json", bytes.NewBuffer(reqBytes))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New("unexpected status " + res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// LabService keeps the labs sharing the bot, each with its own tools, admins
+// and admin group.
+type LabService struct {
+	Query      repository.LabQuery
+	Repository repository.LabRepository
+}
+
+func NewLabService() *LabService {
+	var labQuery repository.LabQuery
+	var labRepository repository.LabRepository
+
+	db := config.InitPostgresDB()
+	labQuery = postgres.NewLabQueryPostgres(db)
+	labRepository = postgres.NewLabRepositoryPostgres(db)
+
+	return &LabService{
+		Query:      labQuery,
+		Repository: labRepository,
+	}
+}
+
+func (ls LabService) FindByID(id int64) (types.Lab, error) {
+	result := ls.Query.FindByID(id)
+	if result.Error != nil {
+		return types.Lab{}, result.Error
+	}
+
+	return result.Result.(types.Lab), nil
+}
+
+// FindByAdminGroupID returns sql.ErrNoRows when the group looks after no lab.
+func (ls LabService) FindByAdminGroupID(groupID int64) (types.Lab, error) {
+	result := ls.Query.FindByAdminGroupID(groupID)
+	if result.Error != nil {
+		return types.Lab{}, result.Error
+	}
+
+	return result.Result.(types.Lab), nil
+}
+
+// FindWithoutAdminGroup returns sql.ErrNoRows when every lab has its admin
+// group.
+func (ls LabService) FindWithoutAdminGroup() (types.Lab, error) {
+	result := ls.Query.FindWithoutAdminGroup()
+	if result.Error != nil {
+		return types.Lab{}, result.Error
+	}
+
+	return result.Result.(types.Lab), nil
+}
+
+func (ls LabService) GetLabs() ([]types.Lab, error) {
+	result := ls.Query.Get()
+	if result.Error != nil {
+		return []types.Lab{}, result.Error
+	}
+
+	return result.Result.([]types.Lab), nil
+}
+
+func (ls LabService) SaveLab(lab types.Lab) (int64, error) {
+	return ls.Repository.Save(&lab)
+}
+
+// ClaimLab makes the group the admin group of the lab.
+func (ls LabService) ClaimLab(id, groupID int64, name string) error {
+	return ls.Repository.UpdateAdminGroup(id, groupID, name)
+}
+
+// ClaimConfiguredAdminGroup hands the lab still waiting for an admin group to
+// the group in ADMIN_GROUP_ID, so a single lab keeps working as it did before
+// labs were added.
+func ClaimConfiguredAdminGroup() error {
+	groupID := helper.GetAdminGroupID()
+	if groupID == 0 {
+		return nil
+	}
+
+	ls := NewLabService()
+	if _, err := ls.FindByAdminGroupID(groupID); err != sql.ErrNoRows {
+		return err
+	}
+
+	lab, err := ls.FindWithoutAdminGroup()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		log.Println("[ERR][ClaimConfiguredAdminGroup][FindWithoutAdminGroup]", err)
+		return err
+	}
+
+	return ls.ClaimLab(lab.ID, groupID, lab.Name)
+}
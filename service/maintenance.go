@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type MaintenanceService struct {
+	Query      repository.MaintenanceQuery
+	Repository repository.MaintenanceRepository
+}
+
+func NewMaintenanceService() *MaintenanceService {
+	var maintenanceQuery repository.MaintenanceQuery
+	var maintenanceRepository repository.MaintenanceRepository
+
+	db := config.InitPostgresDB()
+	maintenanceQuery = postgres.NewMaintenanceQueryPostgres(db)
+	maintenanceRepository = postgres.NewMaintenanceRepositoryPostgres(db)
+
+	return &MaintenanceService{
+		Query:      maintenanceQuery,
+		Repository: maintenanceRepository,
+	}
+}
+
+func (ms MaintenanceService) SaveMaintenance(maintenance types.Maintenance) (int64, error) {
+	return ms.Repository.Save(&maintenance)
+}
+
+// GetHistory returns the latest maintenance records of a tool.
+func (ms MaintenanceService) GetHistory(toolID int64) ([]types.Maintenance, error) {
+	result := ms.Query.GetByToolID(toolID, types.MaintenanceHistoryLimit)
+	if result.Error != nil {
+		return []types.Maintenance{}, result.Error
+	}
+
+	return result.Result.([]types.Maintenance), nil
+}
+
+// GetCalibrationDue returns the tools of the lab whose calibration is due
+// within types.CalibrationReminderDays from now.
+func (ms MaintenanceService) GetCalibrationDue(labID int64, now time.Time) ([]types.CalibrationDue, error) {
+	until := now.AddDate(0, 0, types.CalibrationReminderDays)
+
+	result := ms.Query.GetCalibrationDue(labID, until)
+	if result.Error != nil {
+		return []types.CalibrationDue{}, result.Error
+	}
+
+	return result.Result.([]types.CalibrationDue), nil
+}
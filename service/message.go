@@ -2,22 +2,30 @@ package service
 
 import (
 	"bytes"
-	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jeffail/gabs"
+	"github.com/fannyhasbi/lab-tools-lending/chart"
 	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/conversation"
+	"github.com/fannyhasbi/lab-tools-lending/export"
+	"github.com/fannyhasbi/lab-tools-lending/format"
 	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
-	"golang.org/x/sync/errgroup"
 )
 
 type MessageService struct {
@@ -27,15 +35,26 @@ type MessageService struct {
 	requestType        types.RequestType
 	user               types.User
 	chatSessionDetails []types.ChatSessionDetail
+	printer            i18n.Printer
 
 	chatSessionService   *ChatSessionService
 	userService          *UserService
 	toolService          *ToolService
+	toolCategoryService  *ToolCategoryService
 	borrowService        *BorrowService
 	toolReturningService *ToolReturningService
+	maintenanceService   *MaintenanceService
+	statisticsService    *StatisticsService
+	nimCollisionService  *NIMCollisionService
+	adminService         *AdminService
+	labService           *LabService
+	auditService         *AuditService
+
+	// lab is the lab whose admin group the message was sent in.
+	lab types.Lab
 }
 
-func NewMessageService(chatID, senderID int64, text string, requestType types.RequestType, teleMessage types.TeleMessage) *MessageService {
+func NewMessageService(chatID, senderID int64, text string, requestType types.RequestType, teleMessage types.TeleMessage, languageCode string) *MessageService {
 	ms := &MessageService{
 		chatID:      chatID,
 		messageText: text,
@@ -47,8 +66,17 @@ func NewMessageService(chatID, senderID int64, text string, requestType types.Re
 	ms.initChatSessionService()
 	ms.initUserService()
 	ms.initToolService()
+	ms.initToolCategoryService()
 	ms.initBorrowService()
 	ms.initToolReturningService()
+	ms.initMaintenanceService()
+	ms.initStatisticsService()
+	ms.initNIMCollisionService()
+	ms.initAdminService()
+	ms.initLabService()
+	ms.initAuditService()
+	ms.initLab()
+	ms.initLanguage(languageCode)
 
 	return ms
 }
@@ -65,6 +93,10 @@ func (ms *MessageService) initToolService() {
 	ms.toolService = NewToolService()
 }
 
+func (ms *MessageService) initToolCategoryService() {
+	ms.toolCategoryService = NewToolCategoryService()
+}
+
 func (ms *MessageService) initBorrowService() {
 	ms.borrowService = NewBorrowService()
 }
@@ -73,7 +105,134 @@ func (ms *MessageService) initToolReturningService() {
 	ms.toolReturningService = NewToolReturningService()
 }
 
+func (ms *MessageService) initMaintenanceService() {
+	ms.maintenanceService = NewMaintenanceService()
+}
+
+func (ms *MessageService) initStatisticsService() {
+	ms.statisticsService = NewStatisticsService()
+}
+
+func (ms *MessageService) initNIMCollisionService() {
+	ms.nimCollisionService = NewNIMCollisionService()
+}
+
+func (ms *MessageService) initAdminService() {
+	ms.adminService = NewAdminService()
+}
+
+func (ms *MessageService) initLabService() {
+	ms.labService = NewLabService()
+}
+
+func (ms *MessageService) initAuditService() {
+	ms.auditService = NewAuditService()
+}
+
+// initLab reads the lab looked after in the group the message was sent in.
+func (ms *MessageService) initLab() {
+	if ms.requestType != types.RequestTypeGroup {
+		return
+	}
+
+	lab, err := ms.labService.FindByAdminGroupID(ms.chatID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][initLab][FindByAdminGroupID]", err)
+	}
+
+	ms.lab = lab
+}
+
+// initLanguage uses the language chosen by a registered user, or the language
+// of the Telegram client otherwise.
+func (ms *MessageService) initLanguage(languageCode string) {
+	language := i18n.LanguageFromTelegram(languageCode)
+
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err == nil && len(user.Language) > 0 {
+		language = user.Language
+	}
+
+	ms.printer = i18n.NewPrinter(language)
+}
+
+// userPrinter renders messages sent to another user in that user's language.
+func (ms *MessageService) userPrinter(userID int64) i18n.Printer {
+	user, err := ms.userService.FindByID(userID)
+	if err != nil {
+		return i18n.NewPrinter(types.DefaultLanguage)
+	}
+
+	return i18n.NewPrinter(user.Language)
+}
+
+// adminPrinter renders messages sent to the admin group.
+func (ms *MessageService) adminPrinter() i18n.Printer {
+	return i18n.NewPrinter(types.DefaultLanguage)
+}
+
+// sendToLab sends the message to the admin group of the lab. A lab without an
+// admin group yet has no one to tell.
+func (ms *MessageService) sendToLab(labID int64, reqBody types.MessageRequest) error {
+	lab, err := ms.labService.FindByID(labID)
+	if err != nil {
+		log.Println("[ERR][sendToLab][FindByID]", err)
+		return err
+	}
+
+	if !lab.HasAdminGroup() {
+		log.Println("[INFO] Lab without admin group", lab.ID)
+		return nil
+	}
+
+	reqBody.ChatID = lab.AdminGroupID
+	return ms.sendMessage(reqBody)
+}
+
+// sendToLabs sends the message to the admin group of every lab, for the
+// students who are not bound to a lab.
+func (ms *MessageService) sendToLabs(reqBody types.MessageRequest) error {
+	labs, err := ms.labService.GetLabs()
+	if err != nil {
+		log.Println("[ERR][sendToLabs][GetLabs]", err)
+		return err
+	}
+
+	for _, lab := range labs {
+		if !lab.HasAdminGroup() {
+			continue
+		}
+
+		req := reqBody
+		req.ChatID = lab.AdminGroupID
+		if err := ms.sendMessage(req); err != nil {
+			log.Println("[ERR][sendToLabs][sendMessage]", err)
+		}
+	}
+
+	return nil
+}
+
+// sendMessage sends the message, split into several messages when the text is
+// longer than Telegram allows. The reply markup is attached to the last one.
 func (ms *MessageService) sendMessage(reqBody types.MessageRequest) error {
+	texts := helper.SplitMessageText(reqBody.Text, types.MessageMaxLength)
+	for i, text := range texts {
+		req := reqBody
+		req.Text = text
+		if i < len(texts)-1 {
+			req.ReplyMarkup = nil
+		}
+
+		if err := ms.postMessage(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *MessageService) postMessage(reqBody types.MessageRequest) error {
 	if reqBody.ChatID == 0 {
 		reqBody.ChatID = ms.chatID
 	}
@@ -89,16 +248,12 @@ func (ms *MessageService) sendMessage(reqBody types.MessageRequest) error {
 	if err != nil {
 		return err
 	}
-	if res.StatusCode != http.StatusOK {
-		defer res.Body.Close()
+	defer res.Body.Close()
 
-		var j interface{}
-		err = json.NewDecoder(res.Body).Decode(&j)
-		if err != nil {
-			panic(err)
-		}
-		fmt.Printf("%#v\n", j)
-		return errors.New("unexpected status " + res.Status)
+	if res.StatusCode != http.StatusOK {
+		description, _ := io.ReadAll(res.Body)
+		log.Println("[ERR][postMessage][Post]", res.Status, string(description))
+		return fmt.Errorf("unexpected status %s", res.Status)
 	}
 
 	return nil
@@ -118,16 +273,12 @@ func (ms *MessageService) sendPhoto(reqBody types.PhotoRequest) error {
 	if err != nil {
 		return err
 	}
-	if res.StatusCode != http.StatusOK {
-		defer res.Body.Close()
+	defer res.Body.Close()
 
-		var j interface{}
-		err = json.NewDecoder(res.Body).Decode(&j)
-		if err != nil {
-			panic(err)
-		}
-		fmt.Printf("%#v\n", j)
-		return errors.New("unexpected status " + res.Status)
+	if res.StatusCode != http.StatusOK {
+		description, _ := io.ReadAll(res.Body)
+		log.Println("[ERR][sendPhoto][Post]", res.Status, string(description))
+		return fmt.Errorf("unexpected status %s", res.Status)
 	}
 
 	return nil
@@ -147,16 +298,71 @@ func (ms *MessageService) sendPhotoGroup(reqBody types.PhotoGroupRequest) error
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
-		defer res.Body.Close()
+		description, _ := io.ReadAll(res.Body)
+		log.Println("[ERR][sendPhotoGroup][Post]", res.Status, string(description))
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
 
-		var j interface{}
-		err = json.NewDecoder(res.Body).Decode(&j)
-		if err != nil {
-			panic(err)
+	return nil
+}
+
+// sendDocument uploads the content as a file, which Telegram only accepts as
+// multipart form data.
+func (ms *MessageService) sendDocument(reqBody types.DocumentRequest) error {
+	return ms.uploadFile("sendDocument", "document", reqBody.ChatID, reqBody.Caption, reqBody.FileName, reqBody.Content)
+}
+
+func (ms *MessageService) sendPhotoUpload(reqBody types.PhotoUploadRequest) error {
+	return ms.uploadFile("sendPhoto", "photo", reqBody.ChatID, reqBody.Caption, reqBody.FileName, reqBody.Content)
+}
+
+// uploadFile posts the content as the field of the Telegram method in a
+// multipart form.
+func (ms *MessageService) uploadFile(method, field string, chatID int64, caption, fileName string, content []byte) error {
+	if chatID == 0 {
+		chatID = ms.chatID
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	if err := w.WriteField("chat_id", strconv.FormatInt(chatID, 10)); err != nil {
+		return err
+	}
+
+	if len(caption) > 0 {
+		if err := w.WriteField("caption", caption); err != nil {
+			return err
 		}
+	}
+
+	part, err := w.CreateFormFile(field, fileName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	res, err := http.Post(fmt.Sprintf("%s/%s", config.WebhookUrl(), method), w.FormDataContentType(), &body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
 
-		return errors.New("unexpected status " + res.Status)
+	// the body of an error may not be JSON, such as the HTML page of a proxy
+	if res.StatusCode != http.StatusOK {
+		description, _ := io.ReadAll(res.Body)
+		log.Println("[ERR][uploadFile][Post]", res.Status, string(description))
+		return fmt.Errorf("unexpected status %s", res.Status)
 	}
 
 	return nil
@@ -168,14 +374,14 @@ func (ms *MessageService) ChangeChatSessionDetails(d []types.ChatSessionDetail)
 
 func (ms *MessageService) Error() error {
 	reqBody := types.MessageRequest{
-		Text: "Maaf, sedang terjadi kesalahan. Silahkan coba beberapa saat lagi.",
+		Text: ms.printer.Text("error"),
 	}
 	return ms.sendMessage(reqBody)
 }
 
 func (ms *MessageService) RecommendRegister() error {
 	reqBody := types.MessageRequest{
-		Text: fmt.Sprintf("Silahkan registrasi dengan mengetik `/%s` untuk dapat menggunakan sistem ini secara penuh.", types.CommandRegister),
+		Text: ms.printer.Text("register.recommend", types.CommandRegister),
 	}
 	if err := ms.sendMessage(reqBody); err != nil {
 		log.Println("error in sending reply:", err)
@@ -185,840 +391,1029 @@ func (ms *MessageService) RecommendRegister() error {
 }
 
 func (ms *MessageService) FirstStart() error {
-	message := "Selamat Datang di Layanan Chatbot Peminjaman Barang Laboratorium Teknik Komputer!"
-	message += fmt.Sprintf("\n\nSilahkan lakukan registrasi dengan mengirim perintah \"/%s\"", types.CommandRegister)
+	if toolID, ok := helper.GetStartBorrowToolID(ms.messageText); ok {
+		ms.messageText = fmt.Sprintf("/%s %d", types.CommandBorrow, toolID)
+		return ms.Borrow()
+	}
+
 	return ms.sendMessage(types.MessageRequest{
-		Text: message,
+		Text: ms.printer.Text("start.welcome", types.CommandRegister),
 	})
 }
 
 func (ms *MessageService) Help() error {
-	message := fmt.Sprintf(`/%s - Mendaftarkan diri agar dapat menggunakan sistem
-		/%s - Cek ketersediaan barang
-		/%s - Mulai pengajuan peminjaman barang
-		/%s - Mulai pengajuan Pengembalian barang
-		/%s - Menampilkan panduan penggunaan bot`, types.CommandRegister, types.CommandCheck, types.CommandBorrow, types.CommandReturn, types.CommandHelp)
+	message := ms.printer.Text("help.user", types.CommandRegister, types.CommandCheck, types.CommandBorrow, types.CommandReturn, types.CommandHistory, types.CommandProfile, types.CommandLab, types.CommandLanguage, types.CommandHelp)
 
 	if ms.isEligibleAdmin() {
-		message = fmt.Sprintf(`/%s - Cek ketersediaan barang
-			/%s - Menanggapi pengajuan peminjaman dan pengembalian barang
-			/%s - Menambah dan mengubah data barang
-			/%s - Melihat laporan bulanan
-			/%s - Menampilkan panduan penggunaan bot`, types.CommandCheck, types.CommandRespond, types.CommandManage, types.CommandReport, types.CommandHelp)
+		message = ms.printer.Text("help.admin", types.CommandCheck, types.CommandRespond, types.CommandManage, types.CommandReport, types.CommandStatistics, types.CommandMaintenance, types.CommandUser, types.CommandAdmin, types.CommandRole, types.CommandAudit, types.CommandLanguage, types.CommandHelp)
 	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: helper.RemoveTab(message),
+		Text: message,
 	})
 }
 
-func (ms *MessageService) Unknown() error {
-	reqBody := types.MessageRequest{
-		Text: "Maaf, perintah tidak dikenali.",
+func (ms *MessageService) Language() error {
+	splittedText := strings.Fields(ms.messageText)
+	if len(splittedText) < 2 {
+		return ms.languageMenu()
 	}
-	return ms.sendMessage(reqBody)
-}
-
-func (ms *MessageService) Check() error {
-	checkCommandOrder, ok := helper.GetCheckCommandOrder(ms.messageText)
-	if ok {
-		if checkCommandOrder.Text == types.CheckTypePhoto {
-			return ms.checkDetailPhoto(checkCommandOrder.ID)
-		}
 
-		return ms.checkDetail(checkCommandOrder.ID)
+	language, ok := i18n.ParseLanguage(splittedText[1])
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("language.not_found"),
+		})
 	}
 
-	var tools []types.Tool
-	var err error
-	if ms.isEligibleAdmin() {
-		tools, err = ms.toolService.GetTools()
-	} else {
-		tools, err = ms.toolService.GetAvailableTools()
-	}
+	if _, err := ms.userService.FindByID(ms.user.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return ms.notRegistered()
+		}
 
-	if err != nil {
-		log.Println("[ERR][Check][GetTools]", err)
+		log.Println("[ERR][Language][FindByID]", err)
 		return ms.Error()
 	}
 
-	if len(tools) < 1 {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Tidak ada barang yang tersedia.",
-		})
+	if err := ms.userService.UpdateLanguage(ms.user.ID, language); err != nil {
+		log.Println("[ERR][Language][UpdateLanguage]", err)
+		return ms.Error()
 	}
 
-	message := "Berikut ini daftar alat yang masih tersedia.\n"
-	message += fmt.Sprintf("untuk melihat detail alat, ketik perintah \"/%s [id]\"\n\n", types.CommandCheck)
-	message += helper.BuildToolListMessage(tools)
-
+	ms.printer = i18n.NewPrinter(language)
 	return ms.sendMessage(types.MessageRequest{
-		Text: message,
+		Text: ms.printer.Text("language.changed"),
 	})
 }
 
-func (ms *MessageService) checkDetail(toolID int64) error {
-	tool, err := ms.toolService.FindByID(toolID)
-	if err != nil {
-		log.Println("[ERR][checkDetail][FindByID]", err)
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, nomor alat yang Anda pilih tidak tersedia.",
+func (ms *MessageService) languageMenu() error {
+	var keyboard [][]types.InlineKeyboardButton
+	for _, language := range types.Languages {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         ms.printer.Text(fmt.Sprintf("language.name.%s", language)),
+				CallbackData: fmt.Sprintf("/%s %s", types.CommandLanguage, language),
+			},
 		})
 	}
 
-	if tool.Stock < 1 && !ms.isEligibleAdmin() {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, nomor alat yang Anda pilih tidak tersedia",
-		})
-	}
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("language.choose"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+}
 
-	message := fmt.Sprintf(`Nama: %s
-	Brand: %s
-	Tipe: %s
-	Berat: %.2f gram
-	Stok: %d
+// Lab lets a student choose the lab to borrow from with "/lab [id]". Sent in a
+// group, it shows the lab of the admin group or sets one up.
+func (ms *MessageService) Lab() error {
+	order := helper.GetLabCommandOrder(ms.messageText)
 
-	Keterangan:
-	%s
-	`, tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation)
-	message = helper.RemoveTab(message)
+	if ms.requestType == types.RequestTypeGroup {
+		return ms.labGroup(order)
+	}
 
-	var inlineKeyboard [][]types.InlineKeyboardButton
-	if ms.isEligibleAdmin() {
-		inlineKeyboard = [][]types.InlineKeyboardButton{
-			{{
-				Text:         "Lihat Foto",
-				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
-			}},
-			{{
-				Text:         "Ubah Data",
-				CallbackData: fmt.Sprintf("/%s %s %d", types.CommandManage, types.ManageTypeEdit, tool.ID),
-			}},
-			{{
-				Text:         "Hapus",
-				CallbackData: fmt.Sprintf("/%s %s %d", types.CommandManage, types.ManageTypeDelete, tool.ID),
-			}},
-		}
-	} else {
-		inlineKeyboard = [][]types.InlineKeyboardButton{
-			{{
-				Text:         "Lihat Foto",
-				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
-			}},
-			{{
-				Text:         "Pinjam",
-				CallbackData: fmt.Sprintf("/%s %d", types.CommandBorrow, tool.ID),
-			}},
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ms.notRegistered()
 		}
-	}
 
-	reqBody := types.MessageRequest{
-		Text: message,
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: inlineKeyboard,
-		},
+		log.Println("[ERR][Lab][FindByID]", err)
+		return ms.Error()
 	}
 
-	return ms.sendMessage(reqBody)
-}
-
-func (ms *MessageService) checkDetailPhoto(toolID int64) error {
-	tool, err := ms.toolService.FindByID(toolID)
+	labs, err := ms.labService.GetLabs()
 	if err != nil {
-		log.Println("[ERR][checkDetailPhoto][FindByID]", err)
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, nomor alat yang Anda pilih tidak tersedia.",
-		})
+		log.Println("[ERR][Lab][GetLabs]", err)
+		return ms.Error()
 	}
 
-	if err == sql.ErrNoRows {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "ID tidak ditemukan.",
-		})
+	if order.ID == 0 {
+		return ms.labMenu(labs, user)
 	}
 
-	if tool.Stock < 1 && !ms.isEligibleAdmin() {
+	lab, ok := helper.FindLab(labs, order.ID)
+	if !ok {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, nomor alat yang Anda pilih tidak tersedia.",
+			Text: ms.printer.Text("lab.not_found"),
 		})
 	}
 
-	photos, err := ms.toolService.GetPhotos(toolID)
-	if err != nil {
-		log.Println("[ERR][checkDetailPhoto][GetPhotos]", err)
+	if err := ms.userService.UpdateLab(user.ID, lab.ID); err != nil {
+		log.Println("[ERR][Lab][UpdateLab]", err)
 		return ms.Error()
 	}
 
-	if len(photos) > 1 {
-		var media []types.InputMediaPhoto
-		for _, photo := range photos {
-			temp := types.InputMediaPhoto{
-				Type:  "photo",
-				Media: photo.FileID,
-			}
-			media = append(media, temp)
-		}
-
-		return ms.sendPhotoGroup(types.PhotoGroupRequest{
-			Media: media,
-		})
-	}
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("lab.chosen", lab.Name),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.check_tool"),
+						CallbackData: "/" + types.CommandCheck,
+					},
+				},
+			},
+		},
+	})
+}
 
-	if len(photos) == 1 {
-		return ms.sendPhoto(types.PhotoRequest{
-			Photo: photos[0].FileID,
-		})
+func (ms *MessageService) labMenu(labs []types.Lab, user types.User) error {
+	current := ms.printer.Text("lab.none")
+	if lab, ok := helper.FindLab(labs, user.LabID); ok {
+		current = lab.Name
 	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Foto tidak tersedia untuk barang ini.",
+		Text: ms.printer.Text("lab.choose", current),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: helper.BuildLabButtons(labs),
+		},
 	})
 }
 
-func (ms *MessageService) saveChatSessionDetail(topic types.TopicType, sessionData string) error {
-	chatSession, err := ms.chatSessionService.GetChatSession(ms.user, ms.requestType)
-	if err != nil && err != sql.ErrNoRows {
-		return err
+// canManageLabs tells whether the sender is an admin, of any lab, whose role
+// may manage the admins.
+func (ms *MessageService) canManageLabs() (bool, error) {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	if err == sql.ErrNoRows {
-		chatSessionParam := types.ChatSession{
-			Status: types.ChatSessionStatus["progress"],
-			UserID: ms.user.ID,
-		}
+	isAdmin := user.UserType == types.UserTypeAdmin || user.UserType == types.UserTypeBoth
+	return isAdmin && user.Role.Can(types.PermissionAdminManage), nil
+}
 
-		chatSession, err = ms.chatSessionService.SaveChatSession(chatSessionParam, ms.requestType)
-		if err != nil {
-			return err
-		}
+// labGroup shows the lab of the admin group. A group without a lab takes over
+// the lab still waiting for an admin group, or else starts a new lab, when an
+// admin who may manage the admins asks for it. The configured admin group may
+// always take over the waiting lab.
+func (ms *MessageService) labGroup(order types.LabCommandOrder) error {
+	if ms.lab.ID != 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("lab.group", ms.lab.Name, ms.lab.ID),
+		})
 	}
 
-	if len(sessionData) == 0 {
-		sessionData = "{}"
+	if len(order.Name) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("lab.usage", types.CommandLab),
+		})
 	}
 
-	chatSessionDetail := types.ChatSessionDetail{
-		Topic:         topic,
-		ChatSessionID: chatSession.ID,
-		Data:          sessionData,
+	canManage, err := ms.canManageLabs()
+	if err != nil {
+		log.Println("[ERR][labGroup][canManageLabs]", err)
+		return ms.Error()
 	}
-	_, err = ms.chatSessionService.SaveChatSessionDetail(chatSessionDetail)
 
-	return err
-}
+	isConfiguredGroup := ms.chatID == helper.GetAdminGroupID()
+	if !canManage && !isConfiguredGroup {
+		log.Println("[INFO] Not eligible user setting up a lab", ms.messageText)
+		return ms.Unknown()
+	}
 
-func (ms *MessageService) Register() error {
-	user, err := ms.userService.FindByID(ms.user.ID)
+	lab, err := ms.labService.FindWithoutAdminGroup()
 	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][Register][FindByID]", err)
+		log.Println("[ERR][labGroup][FindWithoutAdminGroup]", err)
 		return ms.Error()
 	}
 
-	if err != sql.ErrNoRows && len(ms.chatSessionDetails) == 0 {
-		message := "Tidak bisa melakukan registrasi, Anda sudah terdaftar ke dalam sistem pada " + helper.TranslateDateStringToBahasa(user.CreatedAt)
+	if err == nil {
+		if err := ms.labService.ClaimLab(lab.ID, ms.chatID, order.Name); err != nil {
+			log.Println("[ERR][labGroup][ClaimLab]", err)
+			return ms.Error()
+		}
 
-		if user.UserType == types.UserTypeAdmin {
-			message = "Pengurus tidak bisa melakukan registrasi."
+		ms.lab = types.Lab{ID: lab.ID, Name: order.Name, AdminGroupID: ms.chatID}
+		if err := ms.audit(types.AuditActionClaim, types.AuditEntityLab, lab.ID, lab, ms.lab); err != nil {
+			return ms.auditFailed()
 		}
 
 		return ms.sendMessage(types.MessageRequest{
-			Text: message,
+			Text: ms.printer.Text("lab.created", order.Name, types.CommandAdmin),
 		})
 	}
 
-	if len(ms.chatSessionDetails) == 0 {
-		return ms.registerInit()
+	if !canManage {
+		log.Println("[INFO] Not eligible user creating a lab", ms.messageText)
+		return ms.Unknown()
 	}
 
-	switch ms.chatSessionDetails[0].Topic {
-	case types.Topic["register_init"]:
-		return ms.registerConfirm()
-	case types.Topic["register_confirm"]:
-		return ms.registerComplete()
+	labID, err := ms.labService.SaveLab(types.Lab{Name: order.Name, AdminGroupID: ms.chatID})
+	if err != nil {
+		log.Println("[ERR][labGroup][SaveLab]", err)
+		return ms.Error()
 	}
 
-	return nil
+	ms.lab = types.Lab{ID: labID, Name: order.Name, AdminGroupID: ms.chatID}
+	if err := ms.audit(types.AuditActionCreate, types.AuditEntityLab, labID, nil, ms.lab); err != nil {
+		return ms.auditFailed()
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("lab.created", order.Name, types.CommandAdmin),
+	})
 }
 
-func (ms *MessageService) registerInit() error {
-	msg := `Silahkan isi beberapa pertanyaan berikut secara urut (pisahkan dengan baris baru)
+func (ms *MessageService) Unknown() error {
+	reqBody := types.MessageRequest{
+		Text: ms.printer.Text("unknown"),
+	}
+	return ms.sendMessage(reqBody)
+}
 
-		Nama Lengkap
-		Nomor Induk Mahasiswa
-		Angkatan
-		Alamat lengkap tempat tinggal sekarang
+func (ms *MessageService) Check() error {
+	checkCommandOrder, ok := helper.GetCheckCommandOrder(ms.messageText)
+	if ok {
+		if checkCommandOrder.Text == types.CheckTypePhoto {
+			return ms.checkDetailPhoto(checkCommandOrder.ID)
+		}
 
-		Contoh
+		return ms.checkDetail(checkCommandOrder.ID)
+	}
 
-		Fanny Hasbi
-		211201XXXXXXXX
-		2016
-		Jalan Jenderal Sudirman No. 189, Pangembon, Brebes, Jawa Tengah`
-	msg = helper.RemoveTab(msg)
+	if order, ok := helper.GetCheckFilterOrder(ms.messageText); ok {
+		if order.Type == types.CheckTypeTag {
+			return ms.checkTag(order)
+		}
 
-	reqBody := types.MessageRequest{
-		Text: msg,
-	}
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("error in sending reply", err)
-		return err
-	}
+		if order.Filter.CategoryID == 0 {
+			return ms.checkCategories()
+		}
 
-	ms.user.UserType = types.UserTypeStudent
-	_, err := ms.userService.SaveUser(ms.user)
-	if err != nil {
-		return err
+		return ms.checkCategory(order)
 	}
 
-	if err = ms.saveChatSessionDetail(types.Topic["register_init"], ""); err != nil {
-		log.Println("[ERR][registerIniti][saveChatSessionDetail]", err)
-		return err
+	if keyword, ok := helper.GetCheckKeyword(ms.messageText); ok {
+		return ms.checkSearch(keyword)
 	}
 
-	return nil
-}
+	cursor, ok := helper.GetCheckPageCursor(ms.messageText)
+	if !ok {
+		cursor = types.Cursor{Limit: types.ToolPageSize}
+	}
 
-func (ms *MessageService) registerConfirm() error {
-	registrationMessage, err := getRegistrationMessage(ms.messageText)
+	labs, err := ms.labService.GetLabs()
 	if err != nil {
-		reqBody := types.MessageRequest{
-			Text: err.Error(),
-		}
-		return ms.sendMessage(reqBody)
+		log.Println("[ERR][Check][GetLabs]", err)
+		return ms.Error()
 	}
 
-	err = validateRegisterConfirmation(registrationMessage)
-	if err != nil {
-		reqBody := types.MessageRequest{
-			Text: err.Error(),
-		}
-		return ms.sendMessage(reqBody)
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][Check][FindByID]", err)
+		return ms.Error()
 	}
 
-	user := types.User{
-		ID:      ms.user.ID,
-		Name:    registrationMessage.Name,
-		NIM:     registrationMessage.NIM,
-		Batch:   uint16(registrationMessage.Batch),
-		Address: registrationMessage.Address,
+	if ms.requestType == types.RequestTypePrivate && err == nil && user.LabID == 0 && len(labs) > 1 {
+		return ms.labMenu(labs, user)
 	}
 
-	user, err = ms.userService.UpdateUser(user)
+	page, err := ms.getToolPage(types.ToolFilter{}, cursor)
 	if err != nil {
-		log.Println("[ERR][registerConfirm[UpdateUser]", err)
-		reqBody := types.MessageRequest{
-			Text: "Terjadi kesalahan, mohon coba beberapa saat lagi.",
-		}
-		return ms.sendMessage(reqBody)
+		log.Println("[ERR][Check][getToolPage]", err)
+		return ms.Error()
 	}
 
-	msg := fmt.Sprintf(`Apakah Anda yakin data ini sudah benar?
+	if len(page.Tools) < 1 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("check.empty"),
+		})
+	}
 
-		Nama : %s
-		NIM : %s
-		Angkatan : %d
-		Alamat : %s`, user.Name, user.NIM, user.Batch, user.Address)
-	msg = helper.RemoveTab(msg)
+	message := ms.printer.Text("check.list", types.CommandCheck, types.CommandCheck)
+	message += helper.BuildToolListMessage(ms.printer, page.Tools)
 
-	reqBody := types.MessageRequest{
-		Text: msg,
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Lanjutkan",
-						CallbackData: "yes",
-					},
-					{
-						Text:         "Batalkan",
-						CallbackData: "no",
-					},
-				},
-			},
-		},
+	var keyboard [][]types.InlineKeyboardButton
+	if buttons := helper.BuildPageButtons("/"+types.CommandCheck, page, ms.printer.Text("button.previous"), ms.printer.Text("button.next")); len(buttons) > 0 {
+		keyboard = append(keyboard, buttons)
 	}
-
-	if err = ms.saveChatSessionDetail(types.Topic["register_confirm"], ""); err != nil {
-		log.Println("[ERR][registerConfirm][saveChatSessionDetail]", err)
-		return err
+	keyboard = append(keyboard, []types.InlineKeyboardButton{{
+		Text:         ms.printer.Text("button.categories"),
+		CallbackData: fmt.Sprintf("/%s %s", types.CommandCheck, types.CheckTypeCategory),
+	}})
+	if ms.requestType == types.RequestTypePrivate && len(labs) > 1 {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{{
+			Text:         ms.printer.Text("button.lab"),
+			CallbackData: "/" + types.CommandLab,
+		}})
 	}
 
-	return ms.sendMessage(reqBody)
+	return ms.sendMessage(types.MessageRequest{
+		Text: message,
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
 }
 
-func (ms *MessageService) registerComplete() error {
-	var err error
+// getToolPage reads every tool for admins, and only the available tools for
+// the other users.
+func (ms *MessageService) getToolPage(filter types.ToolFilter, cursor types.Cursor) (types.ToolPage, error) {
+	filter.LabID = ms.browsingLabID()
+	if ms.can(types.PermissionToolView) {
+		return ms.toolService.GetTools(filter, cursor)
+	}
+	return ms.toolService.GetAvailableTools(filter, cursor)
+}
 
-	if ms.messageText == "yes" {
-		err = ms.registerCompletePositive()
-	} else {
-		err = ms.registerCompleteNegative()
+// browsingLabID is the lab whose tools are shown: the lab of the admin group,
+// or the lab chosen by the user elsewhere. Zero shows the tools of every lab.
+func (ms *MessageService) browsingLabID() int64 {
+	if ms.lab.ID > 0 {
+		return ms.lab.ID
 	}
 
+	user, err := ms.userService.FindByID(ms.user.ID)
 	if err != nil {
-		log.Println("[ERR][registerComplete][registerComplete]", err)
-		return err
+		return 0
 	}
 
-	return nil
+	return user.LabID
 }
 
-func (ms *MessageService) registerCompletePositive() error {
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.RegisterComplete(true)
-
-	if err := ms.saveChatSessionDetail(types.Topic["register_complete"], generatedSessionData); err != nil {
-		return err
-	}
-
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		return err
+func (ms *MessageService) checkCategories() error {
+	categories, err := ms.toolCategoryService.GetChildren(0)
+	if err != nil {
+		log.Println("[ERR][checkCategories][GetChildren]", err)
+		return ms.Error()
 	}
 
-	reqBody := types.MessageRequest{
-		Text: fmt.Sprintf("Selamat! Anda telah terdaftar dan dapat menggunakan sistem ini.\n\nSilahkan ketik `/%s` untuk bantuan.", types.CommandHelp),
+	if len(categories) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("check.category_empty"),
+		})
 	}
 
-	return ms.sendMessage(reqBody)
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("check.category_menu"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: helper.BuildCategoryButtons(categories),
+		},
+	})
 }
 
-func (ms *MessageService) registerCompleteNegative() error {
-	sessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.DeleteChatSessionDetailByChatSessionID(sessionID); err != nil {
-		return err
+// checkCategory shows the subcategories of a category and a page of its tools,
+// including the tools of the subcategories.
+func (ms *MessageService) checkCategory(order types.CheckFilterOrder) error {
+	path, err := ms.toolCategoryService.GetPath(order.Filter.CategoryID)
+	if err != nil {
+		log.Println("[ERR][checkCategory][GetPath]", err)
+		return ms.Error()
 	}
 
-	if err := ms.chatSessionService.DeleteChatSession(sessionID); err != nil {
-		return err
+	if len(path) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("check.category_not_found"),
+		})
 	}
 
-	if err := ms.userService.DeleteUser(ms.user.ID); err != nil {
-		return err
+	children, err := ms.toolCategoryService.GetChildren(order.Filter.CategoryID)
+	if err != nil {
+		log.Println("[ERR][checkCategory][GetChildren]", err)
+		return ms.Error()
 	}
 
-	reqBody := types.MessageRequest{
-		Text: "Registrasi dibatalkan.",
+	page, err := ms.getToolPage(order.Filter, order.Cursor)
+	if err != nil {
+		log.Println("[ERR][checkCategory][getToolPage]", err)
+		return ms.Error()
 	}
 
-	return ms.sendMessage(reqBody)
-}
+	b := format.New(format.MarkdownV2)
+	b.Title(helper.BuildToolCategoryPath(path))
+	if len(page.Tools) == 0 {
+		b.Text(ms.printer.Text("check.category_no_tools"))
+	} else {
+		b.Text(ms.printer.Text("check.search_hint"))
+	}
 
-func getRegistrationMessage(message string) (types.QuestionRegistration, error) {
-	registrationMessage := types.QuestionRegistration{}
+	keyboard := helper.BuildCategoryButtons(children)
+	keyboard = append(keyboard, helper.BuildToolButtons(page.Tools)...)
 
-	splittedMessage := helper.SplitNewLine(message)
-	if len(splittedMessage) != 4 {
-		return registrationMessage, errors.New("format registrasi tidak sesuai")
+	command := fmt.Sprintf("/%s %s %d", types.CommandCheck, types.CheckTypeCategory, order.Filter.CategoryID)
+	if buttons := helper.BuildPageButtons(command, page, ms.printer.Text("button.previous"), ms.printer.Text("button.next")); len(buttons) > 0 {
+		keyboard = append(keyboard, buttons)
 	}
 
-	batch, err := strconv.Atoi(splittedMessage[2])
-	if err != nil {
-		return registrationMessage, errors.New("data tahun angkatan salah")
+	back := fmt.Sprintf("/%s %s", types.CommandCheck, types.CheckTypeCategory)
+	if parentID := path[len(path)-1].ParentID; parentID > 0 {
+		back = fmt.Sprintf("%s %d", back, parentID)
 	}
+	keyboard = append(keyboard, []types.InlineKeyboardButton{{
+		Text:         ms.printer.Text("button.back"),
+		CallbackData: back,
+	}})
 
-	registrationMessage.Name = splittedMessage[0]
-	registrationMessage.NIM = splittedMessage[1]
-	registrationMessage.Batch = batch
-	registrationMessage.Address = splittedMessage[3]
+	reqBody := b.Request()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: keyboard,
+	}
 
-	return registrationMessage, nil
+	return ms.sendMessage(reqBody)
 }
 
-func validateRegisterConfirmation(reg types.QuestionRegistration) error {
-	if err := validateRegisterMessageBatch(reg.Batch); err != nil {
-		return err
+func (ms *MessageService) checkTag(order types.CheckFilterOrder) error {
+	page, err := ms.getToolPage(order.Filter, order.Cursor)
+	if err != nil {
+		log.Println("[ERR][checkTag][getToolPage]", err)
+		return ms.Error()
 	}
 
-	if len(reg.Name) < 4 {
-		return errors.New("data nama minimal 4 karakter")
+	if len(page.Tools) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("check.tag_empty", order.Filter.Tag),
+		})
 	}
 
-	if len(reg.NIM) < 9 || len(reg.NIM) > 14 {
-		return errors.New("NIM tidak valid")
-	}
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("check.tag_title", order.Filter.Tag))
+	b.Text(ms.printer.Text("check.search_hint"))
 
-	if len(reg.Address) < 5 {
-		return fmt.Errorf("data alamat minimal 5 karakter")
+	keyboard := helper.BuildToolButtons(page.Tools)
+	command := fmt.Sprintf("/%s %s %s", types.CommandCheck, types.CheckTypeTag, order.Filter.Tag)
+	if buttons := helper.BuildPageButtons(command, page, ms.printer.Text("button.previous"), ms.printer.Text("button.next")); len(buttons) > 0 {
+		keyboard = append(keyboard, buttons)
 	}
 
-	return nil
-}
-
-func validateRegisterMessageBatch(batch int) error {
-	currentYear := time.Now().Year()
-	if batch < 2008 || batch > currentYear {
-		return errors.New("data angkatan melebihi batas")
+	reqBody := b.Request()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: keyboard,
 	}
-	return nil
-}
 
-func (ms *MessageService) notRegistered() error {
-	reqBody := types.MessageRequest{
-		Text: fmt.Sprintf("Maaf, Anda belum terdaftar kedalam sistem. Silahkan registrasi dengan cara ketik `/%s`.", types.CommandRegister),
-	}
 	return ms.sendMessage(reqBody)
 }
 
-func (ms *MessageService) Borrow() error {
-	user, err := ms.userService.FindByID(ms.user.ID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][Borrow][FindByID]", err)
-		return err
-	}
-
-	ms.user = user
-	if err == sql.ErrNoRows {
-		return ms.notRegistered()
+func (ms *MessageService) checkSearch(keyword string) error {
+	tools, err := ms.toolService.SearchTools(keyword, ms.browsingLabID(), !ms.can(types.PermissionToolView))
+	if err != nil {
+		log.Println("[ERR][checkSearch][SearchTools]", err)
+		return ms.Error()
 	}
 
-	if user.UserType == types.UserTypeAdmin {
+	if len(tools) == 0 {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Pengurus tidak dapat melakukan peminjaman barang.",
+			Text: ms.printer.Text("check.search_empty", keyword),
 		})
 	}
 
-	toolID, ok := isIDWithinCommand(ms.messageText)
-	if ok && toolID > 0 {
-		return ms.borrowInit(toolID)
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("check.search_title", keyword))
+	for i, tool := range tools {
+		b.NumberedItem(i+1, fmt.Sprintf("%s (%s %s)", tool.Name, tool.Brand, tool.ProductType))
 	}
+	b.Line()
+	b.Text(ms.printer.Text("check.search_hint"))
 
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["borrow_init"]:
-			return ms.borrowAmount()
-		case types.Topic["borrow_amount"]:
-			return ms.borrowDuration()
-		case types.Topic["borrow_date"]:
-			return ms.borrowReason()
-		case types.Topic["borrow_reason"]:
-			return ms.borrowConfirm()
-		}
+	reqBody := b.Request()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: helper.BuildToolButtons(tools),
 	}
 
-	return ms.borrowMechanism()
+	return ms.sendMessage(reqBody)
 }
 
-func isIDWithinCommand(s string) (int64, bool) {
-	ss := strings.Split(s, " ")
-	if len(ss) != 2 {
-		return 0, false
-	}
-
-	i, err := strconv.ParseInt(ss[1], 10, 64)
+func (ms *MessageService) checkDetail(toolID int64) error {
+	tool, err := ms.toolService.FindByID(toolID)
 	if err != nil {
-		return 0, false
+		log.Println("[ERR][checkDetail][FindByID]", err)
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
 	}
 
-	return i, true
-}
-
-func (ms *MessageService) borrowMechanism() error {
-	var message string
-	var reqBody types.MessageRequest
-
-	message = fmt.Sprintf(`*Mekanisme Peminjaman*
+	if (tool.Stock < 1 || tool.Status != types.ToolStatusActive) && !ms.canTool(tool, types.PermissionToolView) {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
+	}
 
-	1\. Cek ketersediaan alat dengan mengetik /%s
-	2\. Ketik perintah "*/%s \[id\]*", dimana *id* adalah nomor unik alat yang akan dipinjam
+	b := format.New(format.MarkdownV2)
+	b.Title(tool.Name)
+	if tool.Status != types.ToolStatusActive {
+		b.Field(ms.printer.Text("tool_field.status"), ms.printer.Text(fmt.Sprintf("maintenance.status.%s", tool.Status)))
+	}
+	b.Field(ms.printer.Text("tool_field.brand"), tool.Brand)
+	b.Field(ms.printer.Text("tool_field.tipe"), tool.ProductType)
+	b.Field(ms.printer.Text("tool_field.berat"), ms.printer.Text("unit.grams", tool.Weight))
+	b.Field(ms.printer.Text("tool_field.stok"), strconv.FormatInt(tool.Stock, 10))
+	if tool.IsConsumable() {
+		b.Field(ms.printer.Text("tool_field.jenis"), ms.printer.Text(fmt.Sprintf("tool_kind.%s", tool.Kind)))
+		if ms.canTool(tool, types.PermissionToolView) {
+			b.Field(ms.printer.Text("tool_field.stok_minimum"), strconv.FormatInt(tool.MinStock, 10))
+		}
+	}
+	if lab, err := ms.labService.FindByID(tool.LabID); err == nil && ms.lab.ID == 0 {
+		b.Field(ms.printer.Text("tool_field.lab"), lab.Name)
+	}
+	if tool.CategoryID > 0 {
+		if path, err := ms.toolCategoryService.GetPath(tool.CategoryID); err == nil && len(path) > 0 {
+			b.Field(ms.printer.Text("tool_field.kategori"), helper.BuildToolCategoryPath(path))
+		}
+	}
+	if location := helper.BuildToolLocation(ms.printer, tool.Location); len(location) > 0 {
+		b.Field(ms.printer.Text("tool_field.lokasi"), location)
+	}
+	if len(tool.Tags) > 0 {
+		b.Field(ms.printer.Text("tool_field.tag"), strings.Join(tool.Tags, ", "))
+	}
+	b.Line()
+	b.Block(ms.printer.Text("tool_field.keterangan"), tool.AdditionalInformation)
 
-	Contoh : "*/%s 321*"`, types.CommandCheck, types.CommandBorrow, types.CommandBorrow)
-	message = helper.RemoveTab(message)
+	var inlineKeyboard [][]types.InlineKeyboardButton
+	if ms.canTool(tool, types.PermissionToolManage) {
+		inlineKeyboard = [][]types.InlineKeyboardButton{
+			{{
+				Text:         ms.printer.Text("button.view_photo"),
+				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
+			}},
+			{{
+				Text:         ms.printer.Text("button.edit_data"),
+				CallbackData: fmt.Sprintf("/%s %s %d", types.CommandManage, types.ManageTypeEdit, tool.ID),
+			}},
+			{{
+				Text:         ms.printer.Text("button.maintenance"),
+				CallbackData: fmt.Sprintf("/%s %d", types.CommandMaintenance, tool.ID),
+			}},
+			{{
+				Text:         ms.printer.Text("button.delete"),
+				CallbackData: fmt.Sprintf("/%s %s %d", types.CommandManage, types.ManageTypeDelete, tool.ID),
+			}},
+		}
+	} else {
+		borrowText := ms.printer.Text("button.borrow")
+		if tool.IsConsumable() {
+			borrowText = ms.printer.Text("button.request")
+		}
 
-	reqBody = types.MessageRequest{
-		ParseMode: "MarkdownV2",
-		Text:      message,
+		inlineKeyboard = [][]types.InlineKeyboardButton{
+			{{
+				Text:         ms.printer.Text("button.view_photo"),
+				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
+			}},
+			{{
+				Text:         borrowText,
+				CallbackData: fmt.Sprintf("/%s %d", types.CommandBorrow, tool.ID),
+			}},
+		}
 	}
 
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("[ERR][borrowMechanism][sendMessage]", err)
-		return err
+	reqBody := b.Request()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: inlineKeyboard,
 	}
 
-	return ms.Check()
+	return ms.sendMessage(reqBody)
 }
 
-func (ms *MessageService) borrowInit(toolID int64) error {
+func (ms *MessageService) checkDetailPhoto(toolID int64) error {
 	tool, err := ms.toolService.FindByID(toolID)
 	if err != nil {
-		log.Println("[ERR][borrowInit][FindByID]", err)
+		log.Println("[ERR][checkDetailPhoto][FindByID]", err)
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, nomor alat yang Anda pilih tidak tersedia.",
+			Text: ms.printer.Text("tool.not_available"),
 		})
 	}
 
-	if tool.Stock < 1 {
+	if err == sql.ErrNoRows {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Stok barang sudah habis. Tidak dapat melakukan pengajuan peminjaman.",
+			Text: ms.printer.Text("tool.id_not_found"),
 		})
 	}
 
-	borrows, err := ms.borrowService.GetCurrentlyBeingBorrowedAndRequestedByUserID(ms.user.ID)
+	if tool.Stock < 1 && !ms.canTool(tool, types.PermissionToolView) {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
+	}
+
+	photos, err := ms.toolService.GetPhotos(toolID)
 	if err != nil {
-		log.Println("[ERR][borrowInit][GetCurrentlyBeingBorrowedAndRequestedByUserID]", err)
+		log.Println("[ERR][checkDetailPhoto][GetPhotos]", err)
 		return ms.Error()
 	}
 
-	borrowStatus, same := helper.GetSameBorrow(borrows, toolID)
-
-	if same {
-		var message string
-		if borrowStatus == types.GetBorrowStatus("request") {
-			message = "Maaf, Anda sudah mengajukan peminjaman barang yang sama, silahkan tunggu hingga pengurus menanggapi pengajuan tersebut."
-		} else {
-			message = "Maaf, Anda sedang meminjam barang yang sama sehingga tidak dapat mengajukan peminjaman.\n"
-			message += fmt.Sprintf(`Untuk melakukan pengembalian silahkan ketik "/%s"`, types.CommandReturn)
+	if len(photos) > 1 {
+		var media []types.InputMediaPhoto
+		for _, photo := range photos {
+			temp := types.InputMediaPhoto{
+				Type:  "photo",
+				Media: photo.FileID,
+			}
+			media = append(media, temp)
 		}
 
-		return ms.sendMessage(types.MessageRequest{
-			Text: message,
+		return ms.sendPhotoGroup(types.PhotoGroupRequest{
+			Media: media,
 		})
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.BorrowInit(tool.ID)
-
-	if err = ms.saveChatSessionDetail(types.Topic["borrow_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][borrowInit][saveChatSessionDetail]", err)
-		return err
+	if len(photos) == 1 {
+		return ms.sendPhoto(types.PhotoRequest{
+			Photo: photos[0].FileID,
+		})
 	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Berapa jumlah yang ingin dipinjam?\n\nJika tidak ada dalam pilihan, maka sebutkan dalam angka (min. 1).",
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "1",
-						CallbackData: "1",
-					},
-					{
-						Text:         "2",
-						CallbackData: "2",
-					},
-					{
-						Text:         "3",
-						CallbackData: "3",
-					},
-				},
-			},
-		},
+		Text: ms.printer.Text("check.no_photo"),
 	})
 }
 
-func (ms *MessageService) borrowAmount() error {
-	amount, err := strconv.Atoi(ms.messageText)
-	if err != nil || amount < 1 {
-		log.Println("[ERR][borrowAmount][Atoi]", err)
+func (ms *MessageService) Register() error {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][Register][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err != sql.ErrNoRows {
+		message := ms.printer.Text("register.already", ms.printer.DateString(user.CreatedAt))
+
+		if user.UserType == types.UserTypeAdmin {
+			message = ms.printer.Text("register.admin")
+		} else if user.Status == types.UserStatusRejected {
+			message = ms.printer.Text("register.rejected")
+		} else if user.Status == types.UserStatusPending && len(user.NIM) > 0 {
+			message = ms.printer.Text("register.waiting_approval")
+		}
+
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Mohon sebutkan jumlah barang dalam angka. Minimal 1.",
+			Text: message,
 		})
 	}
 
-	borrowSession := helper.GetBorrowFromChatSessionDetail(ms.chatSessionDetails)
-	tool, err := ms.toolService.FindByID(borrowSession.ToolID)
-	if err != nil {
-		log.Println("[ERR][borrowAmount][FindByID]", err)
-		return ms.Error()
-	}
+	if _, err := ms.nimCollisionService.FindPendingByUserID(ms.user.ID); err != sql.ErrNoRows {
+		if err != nil {
+			log.Println("[ERR][Register][FindPendingByUserID]", err)
+			return ms.Error()
+		}
 
-	if int64(amount) > tool.Stock {
 		return ms.sendMessage(types.MessageRequest{
-			Text: fmt.Sprintf("Tidak bisa meminjam barang melebihi stok yang ada. Stok saat ini %d", tool.Stock),
+			Text: ms.printer.Text("register.nim_review_pending"),
 		})
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.BorrowAmount(amount)
+	return ms.registerInit()
+}
 
-	if err = ms.saveChatSessionDetail(types.Topic["borrow_amount"], generatedSessionData); err != nil {
-		log.Println("[ERR][borrowAmount][saveChatSessionDetail]", err)
-		return ms.Error()
+func (ms *MessageService) registerInit() error {
+	ms.user.UserType = types.UserTypeStudent
+	ms.user.Language = ms.printer.Language()
+	ms.user.Status = types.UserStatusPending
+	if _, err := ms.userService.SaveUser(ms.user); err != nil {
+		log.Println("[ERR][registerInit][SaveUser]", err)
+		return err
 	}
 
-	reqBody := types.MessageRequest{
-		Text: fmt.Sprintf("Berapa lama waktu peminjaman?\n\nJika tidak ada dalam pilihan, maka sebutkan jumlah hari. Minimal durasi peminjaman adalah %d hari.", types.BorrowMinimalDuration),
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["register_init"],
+	})
+}
+
+func (ms *MessageService) registerAskName(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.ask_name"),
+	})
+	return nil
+}
+
+func (ms *MessageService) registerAcceptName(c *conversation.Context) (conversation.Transition, error) {
+	name := strings.TrimSpace(c.Input.Text)
+	if err := validateRegisterName(name); err != nil {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, err))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_name"],
+		Data:  gen.RegisterName(name),
+	}, nil
+}
+
+func (ms *MessageService) registerAskNIM(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.ask_nim"),
+	})
+	return nil
+}
+
+func (ms *MessageService) registerAcceptNIM(c *conversation.Context) (conversation.Transition, error) {
+	nim := strings.TrimSpace(c.Input.Text)
+	if _, ok := helper.ParseNIM(nim, getNIMPatterns()); !ok {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, errRegistrationNIM))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_nim"],
+		Data:  gen.RegisterNIM(nim),
+	}, nil
+}
+
+func (ms *MessageService) registerAskBatch(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.ask_batch"),
+	})
+	return nil
+}
+
+// registerAcceptBatch checks the batch against the year in the NIM answered
+// before, when the NIM pattern has one.
+func (ms *MessageService) registerAcceptBatch(c *conversation.Context) (conversation.Transition, error) {
+	batch, err := strconv.Atoi(strings.TrimSpace(c.Input.Text))
+	if err != nil {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, errRegistrationBatch))
+	}
+
+	if err := validateRegisterMessageBatch(batch); err != nil {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, err))
+	}
+
+	reg := helper.GetRegistrationFromChatSessionDetail(c.Details)
+	if err := validateRegisterNIMBatch(reg.NIM, batch); err != nil {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, err))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_batch"],
+		Data:  gen.RegisterBatch(batch),
+	}, nil
+}
+
+func (ms *MessageService) registerAskAddress(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.ask_address"),
+	})
+	return nil
+}
+
+func (ms *MessageService) registerAcceptAddress(c *conversation.Context) (conversation.Transition, error) {
+	address := strings.TrimSpace(c.Input.Text)
+	if len(address) < registrationAddressMinLength {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, errRegistrationAddressLength))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_address"],
+		Data:  gen.RegisterAddress(address),
+	}, nil
+}
+
+// registerAskPhone shows a reply keyboard sharing the contact of the user.
+// Telegram takes one keyboard per message, so the question comes without the
+// navigation buttons.
+func (ms *MessageService) registerAskPhone(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.ask_phone"),
+		ReplyMarkup: types.ReplyKeyboardMarkup{
+			Keyboard: [][]types.KeyboardButton{
 				{
 					{
-						Text:         "1 Minggu",
-						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["oneweek"]),
-					},
-					{
-						Text:         "2 Minggu",
-						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["twoweek"]),
+						Text:           ms.printer.Text("button.share_phone"),
+						RequestContact: true,
 					},
 				},
 				{
 					{
-						Text:         "1 Bulan",
-						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["onemonth"]),
-					},
-					{
-						Text:         "2 Bulan",
-						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["twomonth"]),
+						Text: ms.printer.Text("button.skip"),
 					},
 				},
 			},
+			ResizeKeyboard:  true,
+			OneTimeKeyboard: true,
 		},
+	})
+	return nil
+}
+
+func (ms *MessageService) registerAcceptPhone(c *conversation.Context) (conversation.Transition, error) {
+	var phone string
+
+	contact := c.Input.Message.Contact
+	switch {
+	case contact != nil:
+		if contact.UserID != ms.user.ID {
+			return conversation.Transition{}, conversation.Invalid(ms.printer.Text("register.phone_not_own"))
+		}
+		phone = contact.PhoneNumber
+	case strings.EqualFold(strings.TrimSpace(c.Input.Text), ms.printer.Text("button.skip")):
+		phone = ""
+	default:
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("register.phone_use_button"))
 	}
 
-	return ms.sendMessage(reqBody)
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_phone"],
+		Data:  gen.RegisterPhone(phone),
+	}, nil
 }
 
-func (ms *MessageService) borrowDuration() error {
-	duration, err := helper.GetDurationValue(ms.messageText)
-	if err != nil {
-		log.Println("[ERR][borrowDuration][GetDurationValue]", err)
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Mohon sebutkan jumlah hari.",
-		})
-	}
+// registerConfirm removes the contact keyboard and sums up the answers with a
+// button to change each of them.
+func (ms *MessageService) registerConfirm(c *conversation.Context) error {
+	reg := helper.GetRegistrationFromChatSessionDetail(c.Details)
 
-	if duration < types.BorrowMinimalDuration {
-		return ms.sendMessage(types.MessageRequest{
-			Text: fmt.Sprintf("Minimal durasi peminjaman adalah %d hari", types.BorrowMinimalDuration),
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.summary"),
+		ReplyMarkup: types.ReplyKeyboardRemove{
+			RemoveKeyboard: true,
+		},
+	})
+
+	phone := reg.Phone
+	if len(phone) == 0 {
+		phone = "-"
+	}
+
+	b := format.New(format.MarkdownV2)
+	b.Text(ms.printer.Text("register.confirm")).Line().Line()
+	b.Field(ms.printer.Text("field.name"), reg.Name)
+	b.Field(ms.printer.Text("field.nim"), reg.NIM)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(reg.Batch))
+	b.Field(ms.printer.Text("field.address"), reg.Address)
+	b.Field(ms.printer.Text("field.phone"), phone)
+
+	var keyboard [][]types.InlineKeyboardButton
+	for _, answer := range []struct {
+		label string
+		topic types.TopicType
+	}{
+		{"label.name", types.Topic["register_name"]},
+		{"label.nim", types.Topic["register_nim"]},
+		{"label.batch", types.Topic["register_batch"]},
+		{"label.address", types.Topic["register_address"]},
+		{"label.phone", types.Topic["register_phone"]},
+	} {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         ms.printer.Text("register.edit_field", ms.printer.Text(answer.label)),
+				CallbackData: fmt.Sprintf("%s %s", conversation.InputEdit, answer.topic),
+			},
 		})
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.BorrowDuration(duration)
+	markup := confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	markup.InlineKeyboard = append(keyboard, markup.InlineKeyboard...)
 
-	if err = ms.saveChatSessionDetail(types.Topic["borrow_date"], generatedSessionData); err != nil {
-		log.Println("[ERR][borrowDuration][saveChatSessionDetail]", err)
-		return ms.Error()
+	req := b.Request()
+	req.ReplyMarkup = markup
+	c.Reply(req)
+	return nil
+}
+
+// registerAcceptConfirmation validates the answers together once more, since
+// changing one of them may no longer match the others.
+func (ms *MessageService) registerAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	positive := isPositiveResponse(c.Input.Text)
+	if positive {
+		if err := validateRegisterConfirmation(helper.GetRegistrationFromChatSessionDetail(c.Details)); err != nil {
+			return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, err))
+		}
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Apa alasan Anda meminjam barang ini?",
-	})
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["register_complete"],
+		Data:  gen.RegisterComplete(positive),
+	}, nil
 }
 
-func (ms *MessageService) borrowReason() error {
-	borrow := helper.GetBorrowFromChatSessionDetail(ms.chatSessionDetails)
+func (ms *MessageService) registerComplete(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		return ms.registerCompleteNegative(c)
+	}
 
-	tool, err := ms.toolService.FindByID(borrow.ToolID)
-	if err != nil {
-		log.Println("[ERR][borrowReason][FindByID]", err)
-		return ms.Error()
+	reg := helper.GetRegistrationFromChatSessionDetail(c.Details)
+
+	existing, err := ms.userService.FindByNIM(reg.NIM)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][registerComplete][FindByNIM]", err)
+		return err
+	}
+
+	if err == nil && existing.ID != ms.user.ID {
+		return ms.registerNIMCollision(c, reg, existing)
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.BorrowReason(ms.messageText)
+	user := types.User{
+		ID:      ms.user.ID,
+		Name:    reg.Name,
+		NIM:     reg.NIM,
+		Batch:   uint16(reg.Batch),
+		Address: reg.Address,
+		Phone:   reg.Phone,
+	}
 
-	if err = ms.saveChatSessionDetail(types.Topic["borrow_reason"], generatedSessionData); err != nil {
-		log.Println("[ERR][borrowReason][saveChatSessionDetail]", err)
-		return ms.Error()
+	if _, err := ms.userService.UpdateUser(user); err != nil {
+		log.Println("[ERR][registerComplete][UpdateUser]", err)
+		return err
 	}
 
-	returnDate := time.Now().AddDate(0, 0, borrow.Duration).Format(types.BasicDateLayout)
+	go ms.notifyRegistrationToAdmin(user)
 
-	message := fmt.Sprintf(`Nama alat : %s
-		Jumlah : %d
-		Tanggal Pengembalian : %s (%d hari)
-		Alamat peminjam : %s
-		Alasan:
-		%s
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.waiting_approval"),
+	})
+	return nil
+}
 
-		Pastikan data sudah benar. Tekan "Lanjutkan" untuk mengajukan ke pengurus.
-	`, tool.Name, borrow.Amount, helper.TranslateDateStringToBahasa(returnDate), borrow.Duration, ms.user.Address, ms.messageText)
-	message = helper.RemoveTab(message)
+func (ms *MessageService) notifyRegistrationToAdmin(user types.User) error {
+	printer := ms.adminPrinter()
+	message := printer.Text("register.notify_admin", user.Name, user.NIM, user.Batch)
 
-	reqBody := types.MessageRequest{
+	return ms.sendToLabs(types.MessageRequest{
 		Text: message,
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
 				{
 					{
-						Text:         "Lanjutkan",
-						CallbackData: "yes",
+						Text:         printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeRegistration, user.ID),
 					},
 					{
-						Text:         "Batalkan",
-						CallbackData: "no",
+						Text:         printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeRegistration, user.ID),
 					},
 				},
 			},
 		},
-	}
-
-	return ms.sendMessage(reqBody)
+	})
 }
 
-func (ms *MessageService) borrowConfirm() error {
-	var userResponse bool
-	if ms.messageText == "yes" {
-		userResponse = true
-	} else {
-		userResponse = false
+// notApproved explains to a student whose registration is not approved why
+// they cannot borrow or return tools yet.
+func (ms *MessageService) notApproved(user types.User) error {
+	message := ms.printer.Text("register.waiting_approval")
+	if user.Status == types.UserStatusRejected {
+		message = ms.printer.Text("register.rejected")
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.BorrowConfirmation(userResponse)
+	return ms.sendMessage(types.MessageRequest{
+		Text: message,
+	})
+}
 
-	if err := ms.saveChatSessionDetail(types.Topic["borrow_confirm"], generatedSessionData); err != nil {
+// registerNIMCollision holds back a registration with the NIM of another
+// account until an admin reviews it, so one student cannot register several
+// Telegram accounts.
+func (ms *MessageService) registerNIMCollision(c *conversation.Context, reg types.QuestionRegistration, existing types.User) error {
+	collisionID, err := ms.nimCollisionService.SaveNIMCollision(types.NIMCollision{
+		UserID:         ms.user.ID,
+		ExistingUserID: existing.ID,
+		Name:           reg.Name,
+		NIM:            reg.NIM,
+		Batch:          uint16(reg.Batch),
+		Address:        reg.Address,
+		Phone:          reg.Phone,
+		Language:       ms.printer.Language(),
+	})
+	if err != nil {
+		log.Println("[ERR][registerNIMCollision][SaveNIMCollision]", err)
 		return err
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
+	if err := ms.chatSessionService.DeleteChatSessionDetailByChatSessionID(c.Session.ID); err != nil {
 		return err
 	}
 
-	if !userResponse {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Pengajuan dibatalkan",
-		})
-	}
-
-	borrowSession := helper.GetBorrowFromChatSessionDetail(ms.chatSessionDetails)
-
-	borrow := types.Borrow{
-		Amount:   borrowSession.Amount,
-		Duration: borrowSession.Duration,
-		Status:   types.GetBorrowStatus("request"),
-		UserID:   ms.user.ID,
-		ToolID:   borrowSession.ToolID,
-		Reason:   borrowSession.Reason,
+	if err := ms.chatSessionService.DeleteChatSession(c.Session.ID); err != nil {
+		return err
 	}
 
-	borrowID, err := ms.borrowService.SaveBorrow(borrow)
-	if err != nil {
-		log.Println("[ERR][borrowConfirm][SaveBorrow]", err)
-		return ms.Error()
+	if err := ms.userService.DeleteUser(ms.user.ID); err != nil {
+		return err
 	}
 
-	go ms.notifyBorrowRequestToAdmin(borrowID)
+	go ms.notifyNIMCollisionToAdmin(collisionID, reg, existing)
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Pengajuan peminjaman berhasil, silahkan tunggu hingga pengurus menanggapi pengajuan.",
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.nim_review"),
 	})
+	return nil
 }
 
-func (ms *MessageService) notifyBorrowRequestToAdmin(borrowID int64) error {
-	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
-	if err != nil {
-		log.Println("[ERR][notifyBorrowRequestToAdmin][FindBorrowByID]", err)
-		return ms.Error()
-	}
-
-	message := fmt.Sprintf(`Seseorang baru saja mengajukan peminjaman barang
+func (ms *MessageService) notifyNIMCollisionToAdmin(collisionID int64, reg types.QuestionRegistration, existing types.User) error {
+	printer := ms.adminPrinter()
+	message := printer.Text("register.nim_collision_admin", reg.Name, reg.NIM, existing.Name, printer.DateString(existing.CreatedAt))
 
-	Nama Pemohon: %s
-	Barang: %s`, borrow.User.Name, borrow.Tool.Name)
-	message = helper.RemoveTab(message)
-
-	return ms.sendMessage(types.MessageRequest{
-		ChatID: helper.GetAdminGroupID(),
-		Text:   message,
+	return ms.sendToLabs(types.MessageRequest{
+		Text: message,
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
 				{
 					{
-						Text:         "Tanggapi",
-						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+						Text:         printer.Text("button.respond"),
+						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeNIMCollision, collisionID),
 					},
 				},
 			},
@@ -1026,1581 +1421,4258 @@ func (ms *MessageService) notifyBorrowRequestToAdmin(borrowID int64) error {
 	})
 }
 
-func (ms *MessageService) ReturnTool() error {
-	user, err := ms.userService.FindByID(ms.user.ID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][ReturnTool][FindByID]", err)
+func (ms *MessageService) registerCompleteNegative(c *conversation.Context) error {
+	if err := ms.chatSessionService.DeleteChatSessionDetailByChatSessionID(c.Session.ID); err != nil {
 		return err
 	}
 
-	ms.user = user
-	if err == sql.ErrNoRows {
-		return ms.notRegistered()
-	}
-
-	if user.UserType == types.UserTypeAdmin {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Pengurus tidak dapat melakukan pengembalian barang.",
-		})
-	}
-
-	borrowID, ok := isIDWithinCommand(ms.messageText)
-	if ok && borrowID > 0 {
-		return ms.toolReturningInit(borrowID)
+	if err := ms.chatSessionService.DeleteChatSession(c.Session.ID); err != nil {
+		return err
 	}
 
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["tool_returning_init"]:
-			return ms.toolReturningConfirm()
-		case types.Topic["tool_returning_confirm"]:
-			return ms.toolReturningComplete()
-		}
+	if err := ms.userService.DeleteUser(ms.user.ID); err != nil {
+		return err
 	}
 
-	return ms.currentlyBorrowedTools()
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("register.cancelled"),
+	})
+	return nil
 }
 
-/* func (ms *MessageService) borrowedTools() error {
-	var message string
+const (
+	registrationNameMinLength    = 4
+	registrationAddressMinLength = 5
+)
 
-	borrows, err := ms.borrowService.FindByUserID(ms.user.ID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println(err)
-		return err
-	}
+var (
+	errRegistrationBatch         = errors.New("registration: invalid batch")
+	errRegistrationBatchRange    = errors.New("registration: batch out of range")
+	errRegistrationNameTooShort  = errors.New("registration: name too short")
+	errRegistrationNIM           = errors.New("registration: invalid NIM")
+	errRegistrationAddressLength = errors.New("registration: address too short")
+	errRegistrationNIMBatch      = errors.New("registration: NIM does not match the batch")
+	errRegistrationNIMTaken      = errors.New("registration: NIM used by another account")
+)
 
-	borrowGroup := helper.GroupBorrowStatus(borrows)
-	progressLen := len(borrowGroup[types.GetBorrowStatus("progress")])
-	returnedLen := len(borrowGroup[types.GetBorrowStatus("returned")])
+var (
+	nimPatternsInit sync.Once
+	nimPatterns     []*regexp.Regexp
+)
 
-	if progressLen > 0 || returnedLen > 0 {
-		message += "Alat yang sedang Anda pinjam:\n"
-		if progressLen > 0 {
-			message += helper.BuildBorrowedMessage(borrowGroup[types.GetBorrowStatus("progress")])
-		} else {
-			message += "Saat ini tidak ada alat yang sedang Anda pinjam."
+// getNIMPatterns compiles the NIM patterns once. An invalid pattern is logged
+// and left out.
+func getNIMPatterns() []*regexp.Regexp {
+	nimPatternsInit.Do(func() {
+		patterns, err := helper.CompileNIMPatterns(config.NIMPatterns())
+		if err != nil {
+			log.Println("[ERR][getNIMPatterns][CompileNIMPatterns]", err)
 		}
+		nimPatterns = patterns
+	})
 
-		message += "\n\nAlat yang sudah pernah Anda pinjam:\n"
-		if returnedLen > 0 {
-			message += helper.BuildBorrowedMessage(borrowGroup[types.GetBorrowStatus("returned")])
-		} else {
-			message += "Belum ada alat yang pernah Anda kembalikan."
-		}
-	} else {
-		message += "Anda belum pernah meminjam alat."
-	}
+	return nimPatterns
+}
 
-	reqBody := types.MessageRequest{
-		Text: message,
+// registrationErrorMessage explains a registration validation error to the user.
+func registrationErrorMessage(p i18n.Printer, err error) string {
+	switch err {
+	case errRegistrationBatch:
+		return p.Text("register.invalid_batch")
+	case errRegistrationBatchRange:
+		return p.Text("register.batch_out_of_range")
+	case errRegistrationNameTooShort:
+		return p.Plural("register.name_too_short", registrationNameMinLength, registrationNameMinLength)
+	case errRegistrationNIM:
+		return p.Text("register.invalid_nim")
+	case errRegistrationAddressLength:
+		return p.Plural("register.address_too_short", registrationAddressMinLength, registrationAddressMinLength)
+	case errRegistrationNIMBatch:
+		return p.Text("register.nim_batch_mismatch")
+	case errRegistrationNIMTaken:
+		return p.Text("register.nim_taken")
+	default:
+		return p.Text("error")
 	}
+}
 
-	return ms.sendMessage(reqBody)
-} */
+func validateRegisterConfirmation(reg types.QuestionRegistration) error {
+	if err := validateRegisterMessageBatch(reg.Batch); err != nil {
+		return err
+	}
 
-func (ms *MessageService) currentlyBorrowedTools() error {
-	borrows, err := ms.borrowService.GetCurrentlyBeingBorrowedByUserID(ms.user.ID)
-	if err != nil {
-		log.Println("[ERR][currentlyBorrowedTools][GetCurrentlyBeingBorrowedByUserID]", err)
+	if err := validateRegisterName(reg.Name); err != nil {
 		return err
 	}
 
-	if len(borrows) == 0 {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Saat ini tidak ada alat yang sedang Anda pinjam.",
-		})
+	if err := validateRegisterNIMBatch(reg.NIM, reg.Batch); err != nil {
+		return err
 	}
 
-	message := "Berikut ini daftar alat yang sedang Anda pinjam.\n\n"
-	message += helper.BuildBorrowedMessage(borrows)
-	message += fmt.Sprintf("\nUntuk mengajukan pengembalian ketik perintah\n\"/%s [id_peminjaman]\"\n\n", types.CommandReturn)
+	if len(reg.Address) < registrationAddressMinLength {
+		return errRegistrationAddressLength
+	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
-	})
+	return nil
 }
 
-func (ms *MessageService) toolReturningInit(borrowID int64) error {
-	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][toolReturningInit][FindBorrowByID]", err)
-		return ms.Error()
+func validateRegisterName(name string) error {
+	if len(name) < registrationNameMinLength {
+		return errRegistrationNameTooShort
 	}
+	return nil
+}
 
-	if err == sql.ErrNoRows || borrow.Status != types.GetBorrowStatus("progress") || borrow.UserID != ms.user.ID {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "ID peminjaman tidak ditemukan.",
-		})
+// validateRegisterNIMBatch checks the NIM and, when the pattern captures the
+// year, that it matches the batch.
+func validateRegisterNIMBatch(nim string, batch int) error {
+	parsed, ok := helper.ParseNIM(nim, getNIMPatterns())
+	if !ok {
+		return errRegistrationNIM
 	}
 
-	rets, err := ms.toolReturningService.GetCurrentlyBeingRequested(ms.user.ID, borrowID)
-	if err != nil {
-		log.Println("[ERR][toolReturningInit][GetCurrentlyBeingRequested]", err)
-		return ms.Error()
+	if parsed.Year > 0 && parsed.Year != batch {
+		return errRegistrationNIMBatch
 	}
 
-	if len(rets) > 0 {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, Anda sudah mengajukan pengembalian barang yang sama. Silahkan tunggu hingga pengurus menanggapi pengajuan tersebut.",
-		})
-	}
+	return nil
+}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.ToolReturningInit(borrowID)
-
-	if err := ms.saveChatSessionDetail(types.Topic["tool_returning_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][toolReturningInit][saveChatSessionDetail]", err)
-		return err
+func validateRegisterMessageBatch(batch int) error {
+	currentYear := time.Now().Year()
+	if batch < 2008 || batch > currentYear {
+		return errRegistrationBatchRange
 	}
-
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Tulis keterangan pengembalian. Dapat berupa kondisi barang, alasan pengembalian, dsb.",
-	})
+	return nil
 }
 
-func (ms *MessageService) toolReturningConfirm() error {
-	toolReturningSession, found := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["tool_returning_init"])
-	if !found {
-		return ms.Unknown()
+func (ms *MessageService) notRegistered() error {
+	reqBody := types.MessageRequest{
+		Text: ms.printer.Text("register.not_registered", types.CommandRegister),
 	}
+	return ms.sendMessage(reqBody)
+}
 
-	dataParsed, err := gabs.ParseJSON([]byte(toolReturningSession.Data))
-	if err != nil {
-		log.Println("[ERR][toolReturningConfirm][ParseJSON]", err)
+// Profile shows the data the user registered with. A field is changed with
+// "/profil [kolom]", which the buttons below the profile send.
+func (ms *MessageService) Profile() error {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][Profile][FindByID]", err)
 		return ms.Error()
 	}
 
-	var borrowID int64
-	bID, ok := dataParsed.Path("borrow_id").Data().(float64)
-	if !ok {
-		log.Println("[ERR][toolReturningConfirm][Path] borrow_id not found")
-		return ms.Error()
+	if err == sql.ErrNoRows {
+		return ms.notRegistered()
 	}
+	ms.user = user
 
-	borrowID = int64(bID)
-
-	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
-	if err != nil {
-		log.Println("[ERR][toolReturningConfirm][FindBorrowByID]", err)
-		return ms.Error()
+	if user.UserType == types.UserTypeAdmin {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("profile.admin"),
+		})
 	}
 
-	message := fmt.Sprintf(`Nama peminjam: %s
-		Nama barang: %s
-		Jumlah: %d
-		Dipinjam sejak: %s
-		Tanggal pengembalian: %s
-		Keterangan:
-		%s
-	
-	
-		Pastikan data sudah benar kemudian tekan "Lanjutkan".`,
-		ms.user.Name, borrow.Tool.Name, borrow.Amount, helper.TranslateDateStringToBahasa(borrow.ConfirmedAt.Time.Format(types.BasicDateLayout)), helper.TranslateDateToBahasa(time.Now()), ms.messageText)
-	message = helper.RemoveTab(message)
-
-	errs, _ := errgroup.WithContext(context.Background())
-	errs.Go(func() error {
-		sessionDataGenerator := helper.NewSessionDataGenerator()
-		generatedSessionData := sessionDataGenerator.ToolReturningConfirm(ms.messageText)
-		return ms.saveChatSessionDetail(types.Topic["tool_returning_confirm"], generatedSessionData)
-	})
-
-	reqBody := types.MessageRequest{
-		Text: message,
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Lanjutkan",
-						CallbackData: "yes",
-					},
-					{
-						Text:         "Batalkan",
-						CallbackData: "no",
-					},
-				},
-			},
-		},
+	splittedText := strings.Fields(ms.messageText)
+	if len(splittedText) > 1 {
+		return ms.profileInit(splittedText[1])
 	}
 
-	err = errs.Wait()
-	if err != nil {
-		log.Println("[ERR][toolReturningConfirm][saveChatSessionDetail]", err)
-		return err
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("profile.title"))
+	b.Field(ms.printer.Text("field.name"), user.Name)
+	b.Field(ms.printer.Text("field.nim"), user.NIM)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(int(user.Batch)))
+	b.Field(ms.printer.Text("field.address"), user.Address)
+	if len(user.Phone) > 0 {
+		b.Field(ms.printer.Text("field.phone"), user.Phone)
 	}
+	b.Line().Text(ms.printer.Text("profile.how_to"))
 
-	return ms.sendMessage(reqBody)
+	req := b.Request()
+	req.ReplyMarkup = ms.profileKeyboard()
+	return ms.sendMessage(req)
 }
 
-func (ms *MessageService) toolReturningComplete() error {
-	var userResponse bool
-
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-
-	if ms.messageText == "yes" {
-		userResponse = true
-	} else {
-		userResponse = false
-	}
-
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.ToolReturningComplete(userResponse)
-	err := ms.saveChatSessionDetail(types.Topic["tool_returning_complete"], generatedSessionData)
-	if err != nil {
-		log.Println("[ERR][toolReturningComplete[saveChatSessionDetail]", err)
-		return err
+func (ms *MessageService) profileKeyboard() types.InlineKeyboardMarkup {
+	button := func(field types.UserField) types.InlineKeyboardButton {
+		return types.InlineKeyboardButton{
+			Text:         ms.printer.Text(fmt.Sprintf("user_field.%s", field)),
+			CallbackData: fmt.Sprintf("/%s %s", types.CommandProfile, field),
+		}
 	}
 
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][toolReturningComplete][UpdateChatSessionStatus]", err)
-		return err
+	return types.InlineKeyboardMarkup{
+		InlineKeyboard: [][]types.InlineKeyboardButton{
+			{button(types.UserFieldName), button(types.UserFieldNIM)},
+			{button(types.UserFieldBatch), button(types.UserFieldAddress)},
+		},
 	}
+}
 
-	errs, _ := errgroup.WithContext(context.Background())
-	if userResponse {
-		errs.Go(func() error {
-			return ms.toolReturningCompletePositive()
-		})
-	} else {
-		errs.Go(func() error {
-			return ms.toolReturningCompleteNegative()
+func (ms *MessageService) profileInit(field string) error {
+	if !helper.IsUserFieldExists(field) {
+		return ms.sendMessage(types.MessageRequest{
+			Text:        ms.printer.Text("profile.field_not_available"),
+			ReplyMarkup: ms.profileKeyboard(),
 		})
 	}
 
-	err = errs.Wait()
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["profile_init"],
+		Data:  gen.ProfileInit(field),
+	})
+}
+
+func (ms *MessageService) profileAskValue(c *conversation.Context) error {
+	user, err := ms.userService.FindByID(ms.user.ID)
 	if err != nil {
-		log.Println("[ERR][toolReturningComplete][toolReturningComplete]", err)
+		log.Println("[ERR][profileAskValue][FindByID]", err)
 		return err
 	}
 
+	field := helper.GetProfileFieldFromChatSessionDetail(c.Details)
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("profile.ask_value", ms.printer.Text(fmt.Sprintf("user_field.%s", field)), helper.GetUserValueByField(user, field)),
+	})
 	return nil
 }
 
-func (ms *MessageService) toolReturningCompletePositive() error {
-	toolReturningSession, found := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["tool_returning_init"])
-	if !found {
-		return errors.New("session not found")
+// changeProfile applies the new value to the stored user, validated the same
+// way as the registration form.
+func (ms *MessageService) changeProfile(user types.User, field, value string) (types.User, error) {
+	updatedUser, err := helper.ChangeUserValueByField(user, field, strings.TrimSpace(value))
+	if err == helper.ErrUserBatchNotNumber {
+		return updatedUser, errRegistrationBatch
 	}
-
-	dataParsed, err := gabs.ParseJSON([]byte(toolReturningSession.Data))
 	if err != nil {
-		return err
+		return updatedUser, err
 	}
 
-	var borrowID int64
-	bID, ok := dataParsed.Path("borrow_id").Data().(float64)
-	if !ok {
-		return errors.New("borrow_id not found")
-	}
+	return updatedUser, validateRegisterConfirmation(helper.RegistrationFromUser(updatedUser))
+}
 
-	borrowID = int64(bID)
+func (ms *MessageService) profileAcceptValue(c *conversation.Context) (conversation.Transition, error) {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil {
+		log.Println("[ERR][profileAcceptValue][FindByID]", err)
+		return conversation.Transition{}, err
+	}
 
-	_, err = ms.borrowService.FindBorrowByID(borrowID)
+	field := helper.GetProfileFieldFromChatSessionDetail(c.Details)
+	updatedUser, err := ms.changeProfile(user, field, c.Input.Text)
 	if err != nil {
-		return err
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, err))
 	}
 
-	var additionalInfo string
-	confirmationChatSessionDetail, found := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["tool_returning_confirm"])
-	if found {
-		dataParsed, err := gabs.ParseJSON([]byte(confirmationChatSessionDetail.Data))
-		if err != nil {
-			return err
-		}
-		value, ok := dataParsed.Path("additional_info").Data().(string)
-		if ok {
-			additionalInfo = value
-		}
+	existing, err := ms.userService.FindByNIM(updatedUser.NIM)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][profileAcceptValue][FindByNIM]", err)
+		return conversation.Transition{}, err
 	}
 
-	toolReturning := types.ToolReturning{
-		BorrowID:       borrowID,
-		Status:         types.GetToolReturningStatus("request"),
-		AdditionalInfo: additionalInfo,
+	if err == nil && existing.ID != user.ID {
+		return conversation.Transition{}, conversation.Invalid(registrationErrorMessage(ms.printer, errRegistrationNIMTaken))
 	}
 
-	toolReturning, err = ms.toolReturningService.SaveToolReturning(toolReturning)
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["profile_complete"],
+		Data:  gen.ProfileComplete(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) profileComplete(c *conversation.Context) error {
+	user, err := ms.userService.FindByID(ms.user.ID)
 	if err != nil {
+		log.Println("[ERR][profileComplete][FindByID]", err)
 		return err
 	}
 
-	// get all value along with tools and users value in ToolReturning
-	toolReturning, err = ms.toolReturningService.FindToolReturningByID(toolReturning.ID)
+	field := helper.GetProfileFieldFromChatSessionDetail(c.Details)
+	updatedUser, err := ms.changeProfile(user, field, c.Input.Text)
 	if err != nil {
 		return err
 	}
 
-	go ms.notifyToolReturningRequestToAdmin(toolReturning)
-
-	reqBody := types.MessageRequest{
-		Text: "Pengajuan pengembalian berhasil, silahkan tunggu hingga pengurus menanggapi pengajuan tersebut.",
+	if _, err := ms.userService.UpdateUser(updatedUser); err != nil {
+		log.Println("[ERR][profileComplete][UpdateUser]", err)
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("profile.failed"),
+		})
+		return nil
 	}
 
-	return ms.sendMessage(reqBody)
-}
-
-func (ms *MessageService) toolReturningCompleteNegative() error {
-	reqBody := types.MessageRequest{
-		Text: "Pengajuan pengembalian dibatalkan.",
+	oldValue := helper.GetUserValueByField(user, field)
+	newValue := helper.GetUserValueByField(updatedUser, field)
+	if user.Status == types.UserStatusPending && len(user.NIM) == 0 && len(updatedUser.NIM) > 0 {
+		// the NIM of the account was moved to another one, the new NIM needs
+		// the approval of an admin again
+		go ms.notifyRegistrationToAdmin(updatedUser)
+	} else if helper.IsUserIdentityField(field) && oldValue != newValue {
+		go ms.notifyProfileChangeToAdmin(user, field, newValue)
 	}
 
-	return ms.sendMessage(reqBody)
-}
-
-func (ms *MessageService) notifyToolReturningRequestToAdmin(toolReturning types.ToolReturning) error {
-	message := fmt.Sprintf(`Seseorang baru saja mengajukan pengembalian barang
-	
-	Nama Pemohon: %s
-	Barang: %s`, toolReturning.Borrow.User.Name, toolReturning.Borrow.Tool.Name)
-
-	return ms.sendMessage(types.MessageRequest{
-		ChatID: helper.GetAdminGroupID(),
-		Text:   message,
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("profile.success", ms.printer.Text(fmt.Sprintf("user_field.%s", field))),
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
 				{
 					{
-						Text:         "Tanggapi",
-						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
+						Text:         ms.printer.Text("button.profile"),
+						CallbackData: fmt.Sprintf("/%s", types.CommandProfile),
 					},
 				},
 			},
 		},
 	})
+	return nil
 }
 
-/**
-*
-* Admin handlers
-*
- */
+// notifyProfileChangeToAdmin tells the admins that a student changed the name
+// or NIM they are known by.
+func (ms *MessageService) notifyProfileChangeToAdmin(user types.User, field, newValue string) error {
+	printer := ms.adminPrinter()
+	message := printer.Text("profile.notify_admin", user.Name, user.NIM, printer.Text(fmt.Sprintf("user_field.%s", field)), helper.GetUserValueByField(user, field), newValue)
 
-func (ms *MessageService) isEligibleAdmin() bool {
-	if ms.requestType != types.RequestTypeGroup {
-		return false
-	}
+	return ms.sendToLabs(types.MessageRequest{
+		Text: message,
+	})
+}
 
-	if ms.chatID != helper.GetAdminGroupID() {
-		return false
+func (ms *MessageService) Borrow() error {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][Borrow][FindByID]", err)
+		return err
 	}
 
-	user, err := ms.userService.FindByID(ms.user.ID)
-	if err != nil {
-		return false
+	ms.user = user
+	if err == sql.ErrNoRows {
+		return ms.notRegistered()
 	}
 
-	for _, t := range []types.UserType{types.UserTypeAdmin, types.UserTypeBoth} {
-		if user.UserType == t {
-			return true
-		}
+	if user.UserType == types.UserTypeAdmin {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("borrow.admin"),
+		})
 	}
 
-	return false
-}
+	if !user.IsApproved() {
+		return ms.notApproved(user)
+	}
 
-func (ms *MessageService) BeAdmin() error {
-	if ms.requestType != types.RequestTypeGroup {
-		return ms.Unknown()
+	toolID, ok := isIDWithinCommand(ms.messageText)
+	if ok && toolID > 0 {
+		return ms.borrowInit(toolID)
 	}
 
-	splittedText := strings.Split(ms.messageText, " ")
+	return ms.borrowMechanism()
+}
 
-	if len(splittedText) != 1 {
-		return ms.Unknown()
+func isIDWithinCommand(s string) (int64, bool) {
+	ss := strings.Split(s, " ")
+	if len(ss) != 2 {
+		return 0, false
 	}
 
-	user, err := ms.userService.FindByID(ms.user.ID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][BeAdmin][FindByID]", err)
-		return ms.Error()
+	i, err := strconv.ParseInt(ss[1], 10, 64)
+	if err != nil {
+		return 0, false
 	}
 
-	fullName := ms.message.From.FirstName
-	if len(ms.message.From.LastName) > 0 {
-		fullName = fmt.Sprintf("%s %s", ms.message.From.FirstName, ms.message.From.LastName)
-	}
+	return i, true
+}
 
-	if err == sql.ErrNoRows {
-		newUser := types.User{
-			ID:       ms.user.ID,
-			Name:     fullName,
-			UserType: types.UserTypeAdmin,
-		}
-		if _, err = ms.userService.SaveUser(newUser); err != nil {
-			log.Println("[ERR][BeAdmin][SaveUser]", err)
-			return ms.Error()
-		}
-	} else {
-		if user.UserType != types.UserTypeStudent {
-			return ms.sendMessage(types.MessageRequest{
-				Text: "Anda sudah terdaftar menjadi pengurus sebelumnya.",
-			})
-		}
+func (ms *MessageService) borrowMechanism() error {
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("borrow.mechanism.title"))
+	b.NumberedItem(1, ms.printer.Text("borrow.mechanism.step_check", types.CommandCheck))
+	b.NumberedItem(2, ms.printer.Text("borrow.mechanism.step_borrow", types.CommandBorrow))
+	b.Line()
+	b.Text(ms.printer.Text("borrow.mechanism.example")).Code(fmt.Sprintf("/%s 321", types.CommandBorrow))
 
-		if err = ms.userService.UpdateUserType(ms.user.ID, types.UserTypeBoth); err != nil {
-			log.Println("[ERR][BeAdmin][UpdateUserType]", err)
-			return ms.Error()
-		}
+	reqBody := b.Request()
+
+	if err := ms.sendMessage(reqBody); err != nil {
+		log.Println("[ERR][borrowMechanism][sendMessage]", err)
+		return err
 	}
 
-	message := fmt.Sprintf(`@%s berhasil menjadi pengurus.`, ms.message.From.Username)
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
-	})
+	return ms.Check()
 }
 
-func (ms *MessageService) Respond() error {
-	if !ms.isEligibleAdmin() {
-		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
-		return ms.Unknown()
+func (ms *MessageService) borrowInit(toolID int64) error {
+	if ms.user.IsSuspended() {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("borrow.suspended", ms.user.SuspensionReason),
+		})
 	}
 
-	respCommands, ok := helper.GetRespondCommandOrder(ms.messageText)
-	if !ok {
-		return ms.ListToRespond()
+	tool, err := ms.toolService.FindByID(toolID)
+	if err != nil {
+		log.Println("[ERR][borrowInit][FindByID]", err)
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
 	}
 
-	if respCommands.Text != "yes" && respCommands.Text != "no" && respCommands.Text != "" {
+	if tool.Status != types.ToolStatusActive {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Maaf, perintah tidak dikenali. Pilihan yang tersedia adalah \"yes\" dan \"no\"",
+			Text: ms.printer.Text("tool.not_available"),
 		})
 	}
 
-	if respCommands.Type == types.RespondTypeBorrow {
-		return ms.respondBorrowInit(respCommands)
-	} else if respCommands.Type == types.RespondTypeToolReturning {
-		return ms.respondToolReturningInit(respCommands)
+	if tool.Stock < 1 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("borrow.out_of_stock"),
+		})
 	}
 
-	return ms.Unknown()
-}
-
-func (ms *MessageService) ListToRespond() error {
-	var message string
-
-	borrows, err := ms.borrowService.GetBorrowRequests()
+	borrows, err := ms.borrowService.GetCurrentlyBeingBorrowedAndRequestedByUserID(ms.user.ID)
 	if err != nil {
-		log.Println("[ERR][ListToRespond][GetBorrowRequests]", err)
+		log.Println("[ERR][borrowInit][GetCurrentlyBeingBorrowedAndRequestedByUserID]", err)
 		return ms.Error()
 	}
 
-	toolRets, err := ms.toolReturningService.GetToolReturningRequests()
-	if err != nil {
-		log.Println("[ERR][ListToRespond][GetToolReturningRequests]", err)
-		return ms.Error()
-	}
+	borrowStatus, same := helper.GetSameBorrow(borrows, toolID)
 
-	message += "Daftar Pengajuan Peminjaman\n"
-	if len(borrows) > 0 {
-		message += helper.BuildBorrowRequestListMessage(borrows)
-	} else {
-		message += "- tidak ada\n"
+	if same {
+		var message string
+		if borrowStatus == types.GetBorrowStatus("request") {
+			message = ms.printer.Text("borrow.already_requested")
+		} else {
+			message = ms.printer.Text("borrow.already_borrowed", types.CommandReturn)
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: message,
+		})
 	}
 
-	message += "\nDaftar Pengajuan Pengembalian\n"
-	if len(toolRets) > 0 {
-		message += helper.BuildToolReturningRequestListMessage(toolRets)
-	} else {
-		message += "- tidak ada\n"
+	gen := helper.NewSessionDataGenerator()
+	if tool.IsConsumable() {
+		return ms.startConversation(conversation.Transition{
+			Topic: types.Topic["consume_init"],
+			Data:  gen.ConsumeInit(tool.ID),
+		})
 	}
 
-	message += fmt.Sprintf("\n\nUntuk menanggapi pengajuan ketik perintah \"/%s [pinjam/kembali] [id]\"", types.CommandRespond)
-	message += fmt.Sprintf("\ncontoh: \"/%s pinjam 173\"", types.CommandRespond)
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["borrow_init"],
+		Data:  gen.BorrowInit(tool.ID),
+	})
+}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
+func (ms *MessageService) borrowAskAmount(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text:        ms.printer.Text("borrow.ask_amount"),
+		ReplyMarkup: amountKeyboard(),
 	})
+	return nil
 }
 
-func (ms *MessageService) RespondBorrow() error {
-	if !ms.isEligibleAdmin() {
-		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
-		return ms.Unknown()
-	}
+func amountKeyboard() types.InlineKeyboardMarkup {
+	return types.InlineKeyboardMarkup{
+		InlineKeyboard: [][]types.InlineKeyboardButton{
+			{
+				{
+					Text:         "1",
+					CallbackData: "1",
+				},
+				{
+					Text:         "2",
+					CallbackData: "2",
+				},
+				{
+					Text:         "3",
+					CallbackData: "3",
+				},
+			},
+		},
+	}
+}
+
+// acceptRequestAmount reads the amount of the requested tool, which cannot be
+// more than its stock.
+func (ms *MessageService) acceptRequestAmount(c *conversation.Context, exceedsStockKey string) (int, error) {
+	amount, err := strconv.Atoi(c.Input.Text)
+	if err != nil || amount < 1 {
+		return 0, conversation.Invalid(ms.printer.Text("borrow.invalid_amount"))
+	}
+
+	borrowSession := helper.GetBorrowFromChatSessionDetail(c.Details)
+	tool, err := ms.toolService.FindByID(borrowSession.ToolID)
+	if err != nil {
+		return 0, err
+	}
+
+	if int64(amount) > tool.Stock {
+		return 0, conversation.Invalid(ms.printer.Text(exceedsStockKey, tool.Stock))
+	}
+
+	return amount, nil
+}
+
+func (ms *MessageService) borrowAcceptAmount(c *conversation.Context) (conversation.Transition, error) {
+	amount, err := ms.acceptRequestAmount(c, "borrow.exceeds_stock")
+	if err != nil {
+		return conversation.Transition{}, err
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["borrow_amount"],
+		Data:  gen.BorrowAmount(amount),
+	}, nil
+}
+
+func (ms *MessageService) borrowAskDuration(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("borrow.ask_duration", ms.printer.Plural("unit.days", types.BorrowMinimalDuration, types.BorrowMinimalDuration)),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("borrow.duration.one_week"),
+						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["oneweek"]),
+					},
+					{
+						Text:         ms.printer.Text("borrow.duration.two_week"),
+						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["twoweek"]),
+					},
+				},
+				{
+					{
+						Text:         ms.printer.Text("borrow.duration.one_month"),
+						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["onemonth"]),
+					},
+					{
+						Text:         ms.printer.Text("borrow.duration.two_month"),
+						CallbackData: strconv.Itoa(types.BorrowTimeRangeMap["twomonth"]),
+					},
+				},
+			},
+		},
+	})
+	return nil
+}
+
+func (ms *MessageService) borrowAcceptDuration(c *conversation.Context) (conversation.Transition, error) {
+	duration, err := helper.GetDurationValue(c.Input.Text)
+	if err != nil {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("borrow.invalid_duration"))
+	}
+
+	if duration < types.BorrowMinimalDuration {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("borrow.duration_too_short", ms.printer.Plural("unit.days", types.BorrowMinimalDuration, types.BorrowMinimalDuration)))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["borrow_date"],
+		Data:  gen.BorrowDuration(duration),
+	}, nil
+}
+
+func (ms *MessageService) borrowAskReason(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("borrow.ask_reason"),
+	})
+	return nil
+}
+
+func (ms *MessageService) borrowAcceptReason(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["borrow_reason"],
+		Data:  gen.BorrowReason(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) borrowSummary(c *conversation.Context) error {
+	borrow := helper.GetBorrowFromChatSessionDetail(c.Details)
+
+	tool, err := ms.toolService.FindByID(borrow.ToolID)
+	if err != nil {
+		log.Println("[ERR][borrowSummary][FindByID]", err)
+		return err
+	}
+
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil {
+		log.Println("[ERR][borrowSummary][FindByID]", err)
+		return err
+	}
+
+	returnDate := time.Now().AddDate(0, 0, borrow.Duration)
+
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("borrow.summary.title"))
+	b.Field(ms.printer.Text("field.tool_name"), tool.Name)
+	b.Field(ms.printer.Text("field.amount"), strconv.Itoa(borrow.Amount))
+	b.Field(ms.printer.Text("field.return_date"), fmt.Sprintf("%s (%s)", ms.printer.Date(returnDate), ms.printer.Plural("unit.days", borrow.Duration, borrow.Duration)))
+	b.Field(ms.printer.Text("field.borrower_address"), user.Address)
+	b.Block(ms.printer.Text("field.reason"), borrow.Reason.String)
+	b.Line()
+	b.Italic(ms.printer.Text("borrow.summary.confirm"))
+
+	req := b.Request()
+	req.ReplyMarkup = confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	c.Reply(req)
+	return nil
+}
+
+func (ms *MessageService) borrowAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["borrow_confirm"],
+		Data:  gen.BorrowConfirmation(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
+
+func (ms *MessageService) borrowConfirm(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("borrow.cancelled"),
+		})
+		return nil
+	}
+
+	borrowSession := helper.GetBorrowFromChatSessionDetail(c.Details)
+
+	borrow := types.Borrow{
+		Amount:   borrowSession.Amount,
+		Duration: borrowSession.Duration,
+		Status:   types.GetBorrowStatus("request"),
+		UserID:   ms.user.ID,
+		ToolID:   borrowSession.ToolID,
+		Reason:   borrowSession.Reason,
+	}
+
+	borrowID, err := ms.borrowService.SaveBorrow(borrow)
+	if err != nil {
+		log.Println("[ERR][borrowConfirm][SaveBorrow]", err)
+		return err
+	}
+
+	go ms.notifyBorrowRequestToAdmin(borrowID)
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("borrow.requested"),
+	})
+	return nil
+}
+
+func (ms *MessageService) notifyBorrowRequestToAdmin(borrowID int64) error {
+	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
+	if err != nil {
+		log.Println("[ERR][notifyBorrowRequestToAdmin][FindBorrowByID]", err)
+		return ms.Error()
+	}
+
+	printer := ms.adminPrinter()
+	message := printer.Text("borrow.notify_admin", borrow.User.Name, borrow.Tool.Name)
+
+	return ms.sendToLab(borrow.Tool.LabID, types.MessageRequest{
+		Text: message,
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         printer.Text("button.respond"),
+						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) consumeAskAmount(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text:        ms.printer.Text("consume.ask_amount"),
+		ReplyMarkup: amountKeyboard(),
+	})
+	return nil
+}
+
+func (ms *MessageService) consumeAcceptAmount(c *conversation.Context) (conversation.Transition, error) {
+	amount, err := ms.acceptRequestAmount(c, "consume.exceeds_stock")
+	if err != nil {
+		return conversation.Transition{}, err
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["consume_amount"],
+		Data:  gen.ConsumeAmount(amount),
+	}, nil
+}
+
+func (ms *MessageService) consumeAskReason(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("consume.ask_reason"),
+	})
+	return nil
+}
+
+func (ms *MessageService) consumeAcceptReason(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["consume_reason"],
+		Data:  gen.ConsumeReason(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) consumeSummary(c *conversation.Context) error {
+	borrow := helper.GetBorrowFromChatSessionDetail(c.Details)
+
+	tool, err := ms.toolService.FindByID(borrow.ToolID)
+	if err != nil {
+		log.Println("[ERR][consumeSummary][FindByID]", err)
+		return err
+	}
+
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("consume.summary.title"))
+	b.Field(ms.printer.Text("field.tool_name"), tool.Name)
+	b.Field(ms.printer.Text("field.amount"), strconv.Itoa(borrow.Amount))
+	b.Block(ms.printer.Text("field.reason"), borrow.Reason.String)
+	b.Line()
+	b.Text(ms.printer.Text("consume.summary.notice")).Line()
+	b.Italic(ms.printer.Text("borrow.summary.confirm"))
+
+	req := b.Request()
+	req.ReplyMarkup = confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	c.Reply(req)
+	return nil
+}
+
+func (ms *MessageService) consumeAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["consume_confirm"],
+		Data:  gen.ConsumeConfirmation(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
+
+// consumeConfirm saves the request of a consumable as a borrow without duration.
+func (ms *MessageService) consumeConfirm(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("borrow.cancelled"),
+		})
+		return nil
+	}
+
+	borrowSession := helper.GetBorrowFromChatSessionDetail(c.Details)
+
+	borrow := types.Borrow{
+		Amount: borrowSession.Amount,
+		Status: types.GetBorrowStatus("request"),
+		UserID: ms.user.ID,
+		ToolID: borrowSession.ToolID,
+		Reason: borrowSession.Reason,
+	}
+
+	borrowID, err := ms.borrowService.SaveBorrow(borrow)
+	if err != nil {
+		log.Println("[ERR][consumeConfirm][SaveBorrow]", err)
+		return err
+	}
+
+	go ms.notifyConsumeRequestToAdmin(borrowID)
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("consume.requested"),
+	})
+	return nil
+}
+
+func (ms *MessageService) notifyConsumeRequestToAdmin(borrowID int64) error {
+	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
+	if err != nil {
+		log.Println("[ERR][notifyConsumeRequestToAdmin][FindBorrowByID]", err)
+		return ms.Error()
+	}
+
+	printer := ms.adminPrinter()
+	message := printer.Text("consume.notify_admin", borrow.User.Name, borrow.Tool.Name, printer.Plural("unit.pieces", borrow.Amount, borrow.Amount))
+
+	return ms.sendToLab(borrow.Tool.LabID, types.MessageRequest{
+		Text: message,
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         printer.Text("button.respond"),
+						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) ReturnTool() error {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][ReturnTool][FindByID]", err)
+		return err
+	}
+
+	ms.user = user
+	if err == sql.ErrNoRows {
+		return ms.notRegistered()
+	}
+
+	if user.UserType == types.UserTypeAdmin {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("return.admin"),
+		})
+	}
+
+	if !user.IsApproved() {
+		return ms.notApproved(user)
+	}
+
+	borrowID, ok := isIDWithinCommand(ms.messageText)
+	if ok && borrowID > 0 {
+		return ms.toolReturningInit(borrowID)
+	}
+
+	return ms.currentlyBorrowedTools()
+}
+
+// History lists every borrow of the user page by page, "/riwayat [halaman]".
+func (ms *MessageService) History() error {
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][History][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows {
+		return ms.notRegistered()
+	}
+	ms.user = user
+
+	borrows, err := ms.borrowService.FindByUserID(ms.user.ID)
+	if err != nil {
+		log.Println("[ERR][History][FindByUserID]", err)
+		return ms.Error()
+	}
+
+	if len(borrows) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("history.empty"),
+		})
+	}
+
+	page, _ := isIDWithinCommand(ms.messageText)
+	borrows, current, pages := helper.PageBorrows(borrows, int(page), types.HistoryPageSize)
+
+	b := format.New(format.MarkdownV2)
+	b.Bold(ms.printer.Text("history.title", current, pages)).Line()
+	b.Raw(helper.BuildBorrowHistoryMessage(ms.printer, b.Mode(), borrows))
+
+	buttons := []types.InlineKeyboardButton{}
+	if current > 1 {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         ms.printer.Text("button.previous"),
+			CallbackData: fmt.Sprintf("/%s %d", types.CommandHistory, current-1),
+		})
+	}
+	if current < pages {
+		buttons = append(buttons, types.InlineKeyboardButton{
+			Text:         ms.printer.Text("button.next"),
+			CallbackData: fmt.Sprintf("/%s %d", types.CommandHistory, current+1),
+		})
+	}
+
+	req := b.Request()
+	if len(buttons) > 0 {
+		req.ReplyMarkup = types.InlineKeyboardMarkup{InlineKeyboard: [][]types.InlineKeyboardButton{buttons}}
+	}
+	return ms.sendMessage(req)
+}
+
+func (ms *MessageService) currentlyBorrowedTools() error {
+	borrows, err := ms.borrowService.GetCurrentlyBeingBorrowedByUserID(ms.user.ID)
+	if err != nil {
+		log.Println("[ERR][currentlyBorrowedTools][GetCurrentlyBeingBorrowedByUserID]", err)
+		return err
+	}
+
+	if len(borrows) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("return.nothing_borrowed"),
+		})
+	}
+
+	message := ms.printer.Text("return.borrowed_list", helper.BuildBorrowedMessage(borrows), types.CommandReturn)
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: message,
+	})
+}
+
+func (ms *MessageService) toolReturningInit(borrowID int64) error {
+	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][toolReturningInit][FindBorrowByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || borrow.Status != types.GetBorrowStatus("progress") || borrow.UserID != ms.user.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("return.borrow_not_found"),
+		})
+	}
+
+	rets, err := ms.toolReturningService.GetCurrentlyBeingRequested(ms.user.ID, borrowID)
+	if err != nil {
+		log.Println("[ERR][toolReturningInit][GetCurrentlyBeingRequested]", err)
+		return ms.Error()
+	}
+
+	if len(rets) > 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("return.already_requested"),
+		})
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["tool_returning_init"],
+		Data:  gen.ToolReturningInit(borrowID),
+	})
+}
+
+func (ms *MessageService) toolReturningAskInfo(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("return.ask_info"),
+	})
+	return nil
+}
+
+func (ms *MessageService) toolReturningAcceptInfo(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["tool_returning_confirm"],
+		Data:  gen.ToolReturningConfirm(c.Input.Text),
+	}, nil
+}
+
+// getToolReturningSession reads the borrow ID and the additional info written during the tool returning flow.
+func getToolReturningSession(details []types.ChatSessionDetail) (int64, string, error) {
+	toolReturningSession, found := helper.GetChatSessionDetailByTopic(details, types.Topic["tool_returning_init"])
+	if !found {
+		return 0, "", errors.New("session not found")
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(toolReturningSession.Data))
+	if err != nil {
+		return 0, "", err
+	}
+
+	bID, ok := dataParsed.Path("borrow_id").Data().(float64)
+	if !ok {
+		return 0, "", errors.New("borrow_id not found")
+	}
+
+	var additionalInfo string
+	confirmationChatSessionDetail, found := helper.GetChatSessionDetailByTopic(details, types.Topic["tool_returning_confirm"])
+	if found {
+		dataParsed, err := gabs.ParseJSON([]byte(confirmationChatSessionDetail.Data))
+		if err != nil {
+			return 0, "", err
+		}
+		value, ok := dataParsed.Path("additional_info").Data().(string)
+		if ok {
+			additionalInfo = value
+		}
+	}
+
+	return int64(bID), additionalInfo, nil
+}
+
+func (ms *MessageService) toolReturningSummary(c *conversation.Context) error {
+	borrowID, additionalInfo, err := getToolReturningSession(c.Details)
+	if err != nil {
+		log.Println("[ERR][toolReturningSummary][getToolReturningSession]", err)
+		return err
+	}
+
+	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
+	if err != nil {
+		log.Println("[ERR][toolReturningSummary][FindBorrowByID]", err)
+		return err
+	}
+
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("return.summary.title"))
+	b.Field(ms.printer.Text("field.borrower"), borrow.User.Name)
+	b.Field(ms.printer.Text("field.tool"), borrow.Tool.Name)
+	b.Field(ms.printer.Text("field.amount"), strconv.Itoa(borrow.Amount))
+	b.Field(ms.printer.Text("field.borrowed_since"), ms.printer.Date(borrow.ConfirmedAt.Time))
+	b.Field(ms.printer.Text("field.return_date"), ms.printer.Date(time.Now()))
+	b.Block(ms.printer.Text("field.description"), additionalInfo)
+	b.Line()
+	b.Italic(ms.printer.Text("summary.confirm"))
+
+	req := b.Request()
+	req.ReplyMarkup = confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	c.Reply(req)
+	return nil
+}
+
+func (ms *MessageService) toolReturningAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["tool_returning_complete"],
+		Data:  gen.ToolReturningComplete(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
+
+func (ms *MessageService) toolReturningComplete(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("return.cancelled"),
+		})
+		return nil
+	}
+
+	borrowID, additionalInfo, err := getToolReturningSession(c.Details)
+	if err != nil {
+		return err
+	}
+
+	if _, err = ms.borrowService.FindBorrowByID(borrowID); err != nil {
+		return err
+	}
+
+	toolReturning := types.ToolReturning{
+		BorrowID:       borrowID,
+		Status:         types.GetToolReturningStatus("request"),
+		AdditionalInfo: additionalInfo,
+	}
+
+	toolReturning, err = ms.toolReturningService.SaveToolReturning(toolReturning)
+	if err != nil {
+		return err
+	}
+
+	// get all value along with tools and users value in ToolReturning
+	toolReturning, err = ms.toolReturningService.FindToolReturningByID(toolReturning.ID)
+	if err != nil {
+		return err
+	}
+
+	go ms.notifyToolReturningRequestToAdmin(toolReturning)
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("return.requested"),
+	})
+	return nil
+}
+
+func (ms *MessageService) notifyToolReturningRequestToAdmin(toolReturning types.ToolReturning) error {
+	printer := ms.adminPrinter()
+	message := printer.Text("return.notify_admin", toolReturning.Borrow.User.Name, toolReturning.Borrow.Tool.Name)
+
+	return ms.sendToLab(toolReturning.Borrow.Tool.LabID, types.MessageRequest{
+		Text: message,
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         printer.Text("button.respond"),
+						CallbackData: fmt.Sprintf("/%s %s %d", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+/**
+*
+* Admin handlers
+*
+ */
+
+func (ms *MessageService) isEligibleAdmin() bool {
+	_, ok := ms.eligibleAdmin()
+	return ok
+}
+
+// eligibleAdmin reads the sender when it is an admin of the lab writing in the
+// admin group of the lab.
+func (ms *MessageService) eligibleAdmin() (types.User, bool) {
+	if ms.requestType != types.RequestTypeGroup || ms.lab.ID == 0 {
+		return types.User{}, false
+	}
+
+	user, err := ms.userService.FindByID(ms.user.ID)
+	if err != nil || user.AdminLabID != ms.lab.ID {
+		return types.User{}, false
+	}
+
+	for _, t := range []types.UserType{types.UserTypeAdmin, types.UserTypeBoth} {
+		if user.UserType == t {
+			return user, true
+		}
+	}
+
+	return types.User{}, false
+}
+
+// can tells whether the sender is an admin whose role has the permission.
+func (ms *MessageService) can(permission types.Permission) bool {
+	user, ok := ms.eligibleAdmin()
+	return ok && user.Role.Can(permission)
+}
+
+// canTool tells whether the sender may use the permission on a tool, which
+// must belong to the lab of the admin group.
+func (ms *MessageService) canTool(tool types.Tool, permission types.Permission) bool {
+	return tool.LabID == ms.lab.ID && ms.can(permission)
+}
+
+// errAuditFailed tells that an action has been saved without its entry in
+// the audit log.
+var errAuditFailed = errors.New("audit: entry not saved")
+
+// audit records an action of the sender in the audit log of the lab, with the
+// entity before and after the action. It is called once the action is saved,
+// so on a failure the caller stops before telling anyone about the action and
+// reports the missing entry with auditFailed instead.
+func (ms *MessageService) audit(action types.AuditAction, entity types.AuditEntity, entityID int64, before, after interface{}) error {
+	entry := types.AuditEntry{
+		LabID:       ms.lab.ID,
+		ActorUserID: ms.user.ID,
+		Action:      action,
+		Entity:      entity,
+		EntityID:    entityID,
+		Before:      helper.AuditSnapshot(before),
+		After:       helper.AuditSnapshot(after),
+	}
+
+	if _, err := ms.auditService.SaveAuditEntry(entry); err != nil {
+		log.Println("[ERR][audit][SaveAuditEntry]", err)
+		return errAuditFailed
+	}
+
+	return nil
+}
+
+// auditFailed tells the admin that the action has been saved but is missing
+// from the audit log.
+func (ms *MessageService) auditFailed() error {
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("audit.failed"),
+	})
+}
+
+// findLabTool reads a tool of the lab of the admin group. The tools of the
+// other labs are not found.
+func (ms *MessageService) findLabTool(toolID int64) (types.Tool, error) {
+	tool, err := ms.toolService.FindByID(toolID)
+	if err != nil {
+		return types.Tool{}, err
+	}
+
+	if tool.LabID != ms.lab.ID {
+		return types.Tool{}, sql.ErrNoRows
+	}
+
+	return tool, nil
+}
+
+// permissionDenied tells an admin that the role does not allow the command.
+func (ms *MessageService) permissionDenied() error {
+	log.Println("[INFO] Admin without permission accessing command", ms.messageText)
+
+	role := "-"
+	if user, ok := ms.eligibleAdmin(); ok && user.Role.IsValid() {
+		role = ms.printer.Text(fmt.Sprintf("role.%s", user.Role))
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("permission.denied", role),
+	})
+}
+
+// BeAdmin admits the sender as an admin with an invitation code, or asks the
+// admins to approve the sender otherwise. Admins use it to issue invitation
+// codes, list the admins and revoke them. It is only heard in the admin group
+// of a lab.
+func (ms *MessageService) BeAdmin() error {
+	if ms.requestType != types.RequestTypeGroup || ms.lab.ID == 0 {
+		return ms.Unknown()
+	}
+
+	order, ok := helper.GetAdminCommandOrder(ms.messageText)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.usage", types.CommandAdmin, types.CommandAdmin),
+		})
+	}
+
+	isAdmin := ms.isEligibleAdmin()
+
+	if len(order.Type) > 0 && !isAdmin {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
+
+	if (order.Type == types.AdminTypeInvite || order.Type == types.AdminTypeRevoke) && !ms.can(types.PermissionAdminManage) {
+		return ms.permissionDenied()
+	}
+
+	switch order.Type {
+	case types.AdminTypeInvite:
+		return ms.adminInvite()
+	case types.AdminTypeList:
+		return ms.adminList()
+	case types.AdminTypeRevoke:
+		return ms.adminRevoke(order.Text)
+	}
+
+	if isAdmin {
+		if len(order.Text) == 0 {
+			return ms.adminList()
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.already"),
+		})
+	}
+
+	if len(order.Text) == 0 {
+		return ms.adminRequest()
+	}
+
+	return ms.adminRedeem(order.Text)
+}
+
+func (ms *MessageService) adminInvite() error {
+	invitation, err := ms.adminService.CreateInvitation(ms.lab.ID, ms.user.ID)
+	if err != nil {
+		log.Println("[ERR][adminInvite][CreateInvitation]", err)
+		return ms.Error()
+	}
+	if err := ms.audit(types.AuditActionCreate, types.AuditEntityInvitation, invitation.ID, nil,
+		map[string]interface{}{"lab_id": invitation.LabID, "expires_at": invitation.ExpiresAt}); err != nil {
+		return ms.auditFailed()
+	}
+
+	// The code is sent privately so that no one else in the group can redeem
+	// it before the intended admin does.
+	hours := int(types.AdminInvitationValidity.Hours())
+	err = ms.sendMessage(types.MessageRequest{
+		ChatID: ms.user.ID,
+		Text:   i18n.NewPrinter(ms.user.Language).Text("admin.invitation_created", ms.lab.Name, invitation.Code, hours, types.CommandAdmin, invitation.Code),
+	})
+	if err != nil {
+		log.Println("[ERR][adminInvite][sendMessage]", err)
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.invitation_unsent"),
+		})
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("admin.invitation_sent"),
+	})
+}
+
+func (ms *MessageService) adminList() error {
+	admins, err := ms.userService.GetAdmins(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][adminList][GetAdmins]", err)
+		return ms.Error()
+	}
+
+	requests, err := ms.adminService.GetPendingAdminRequests(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][adminList][GetPendingAdminRequests]", err)
+		return ms.Error()
+	}
+
+	adminList := ms.printer.Text("respond.none")
+	if len(admins) > 0 {
+		adminList = helper.BuildAdminListMessage(ms.printer, admins)
+	}
+
+	requestList := ms.printer.Text("respond.none")
+	if len(requests) > 0 {
+		requestList = helper.BuildAdminRequestListMessage(requests)
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("admin.list", adminList, requestList, types.CommandAdmin, types.AdminTypeInvite, types.CommandAdmin, types.AdminTypeRevoke),
+	})
+}
+
+// adminRevoke takes the admin access away. A registered student stays a
+// student, an account made only to be an admin is removed.
+func (ms *MessageService) adminRevoke(target string) error {
+	id, username, ok := helper.GetAdminTarget(target)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.revoke_not_found"),
+		})
+	}
+
+	var user types.User
+	var err error
+	if len(username) > 0 {
+		user, err = ms.userService.FindByUsername(username)
+	} else {
+		user, err = ms.userService.FindByID(id)
+	}
+
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][adminRevoke][FindUser]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || user.UserType == types.UserTypeStudent || user.AdminLabID != ms.lab.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.revoke_not_found"),
+		})
+	}
+
+	if user.ID == ms.user.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.revoke_self"),
+		})
+	}
+
+	if user.UserType == types.UserTypeBoth {
+		err = ms.userService.UpdateUserType(user.ID, types.UserTypeStudent)
+		if err == nil {
+			err = ms.userService.UpdateRole(user.ID, "")
+		}
+		if err == nil {
+			err = ms.userService.UpdateAdminLab(user.ID, 0)
+		}
+	} else {
+		err = ms.userService.DeleteUserWithChatSessions(user.ID)
+	}
+
+	if err != nil {
+		log.Println("[ERR][adminRevoke][RevokeUser]", err)
+		return ms.Error()
+	}
+
+	var after interface{}
+	if user.UserType == types.UserTypeBoth {
+		after = adminAuditState(types.User{UserType: types.UserTypeStudent})
+	}
+	if err := ms.audit(types.AuditActionRevoke, types.AuditEntityAdmin, user.ID, adminAuditState(user), after); err != nil {
+		return ms.auditFailed()
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("admin.revoked", user.Name),
+	})
+}
+
+// Role lists the roles with their permissions, or changes the role of an admin
+// on "/peran [@username|id] [peran]".
+func (ms *MessageService) Role() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
+
+	order, ok := helper.GetRoleCommandOrder(ms.messageText)
+	if !ok || len(order.Target) == 0 {
+		return ms.roleList()
+	}
+
+	if !ms.can(types.PermissionAdminManage) {
+		return ms.permissionDenied()
+	}
+
+	if !order.Role.IsValid() {
+		roles := make([]string, len(types.Roles))
+		for i, role := range types.Roles {
+			roles[i] = string(role)
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("role.not_found", strings.Join(roles, ", ")),
+		})
+	}
+
+	id, username, ok := helper.GetAdminTarget(order.Target)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.revoke_not_found"),
+		})
+	}
+
+	var user types.User
+	var err error
+	if len(username) > 0 {
+		user, err = ms.userService.FindByUsername(username)
+	} else {
+		user, err = ms.userService.FindByID(id)
+	}
+
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][Role][FindUser]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || user.UserType == types.UserTypeStudent || user.AdminLabID != ms.lab.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.revoke_not_found"),
+		})
+	}
+
+	if user.ID == ms.user.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("role.self"),
+		})
+	}
+
+	if err := ms.userService.UpdateRole(user.ID, order.Role); err != nil {
+		log.Println("[ERR][Role][UpdateRole]", err)
+		return ms.Error()
+	}
+	if err := ms.audit(types.AuditActionRole, types.AuditEntityAdmin, user.ID,
+		map[string]interface{}{"role": user.Role},
+		map[string]interface{}{"role": order.Role}); err != nil {
+		return ms.auditFailed()
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("role.changed", user.Name, ms.printer.Text(fmt.Sprintf("role.%s", order.Role))),
+	})
+}
+
+func (ms *MessageService) roleList() error {
+	admins, err := ms.userService.GetAdmins(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][roleList][GetAdmins]", err)
+		return ms.Error()
+	}
+
+	adminList := ms.printer.Text("respond.none")
+	if len(admins) > 0 {
+		adminList = helper.BuildAdminListMessage(ms.printer, admins)
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("role.list", helper.BuildRoleListMessage(ms.printer), adminList, types.CommandRole, types.CommandRole),
+	})
+}
+
+// adminRequest asks the admins of the lab to approve the sender, once at a
+// time. The first admin of a lab has no one to ask and is admitted directly.
+func (ms *MessageService) adminRequest() error {
+	admins, err := ms.userService.GetAdmins(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][adminRequest][GetAdmins]", err)
+		return ms.Error()
+	}
+
+	name := resolverName(ms.message.From.FirstName, ms.message.From.LastName)
+
+	if len(admins) == 0 {
+		before, err := ms.promoteToAdmin(ms.user.ID, ms.lab.ID, name, ms.message.From.Username, ms.printer.Language(), types.RoleHead)
+		if err != nil {
+			log.Println("[ERR][adminRequest][promoteToAdmin]", err)
+			return ms.Error()
+		}
+		if err := ms.auditPromotion(ms.user.ID, before); err != nil {
+			return ms.auditFailed()
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.success", name),
+		})
+	}
+
+	_, err = ms.adminService.FindPendingAdminRequestByUserID(ms.user.ID, ms.lab.ID)
+	if err == nil {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.request_pending"),
+		})
+	}
+
+	if err != sql.ErrNoRows {
+		log.Println("[ERR][adminRequest][FindPendingAdminRequestByUserID]", err)
+		return ms.Error()
+	}
+
+	request := types.AdminRequest{
+		UserID:   ms.user.ID,
+		LabID:    ms.lab.ID,
+		Name:     name,
+		Username: ms.message.From.Username,
+		Language: ms.printer.Language(),
+	}
+
+	request.ID, err = ms.adminService.SaveAdminRequest(request)
+	if err != nil {
+		log.Println("[ERR][adminRequest][SaveAdminRequest]", err)
+		return ms.Error()
+	}
+
+	return ms.adminRequestDetail(request)
+}
+
+func (ms *MessageService) adminRequestDetail(request types.AdminRequest) error {
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("admin.request_sent", request.Name),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeAdminRequest, request.ID),
+					},
+					{
+						Text:         ms.printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeAdminRequest, request.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) adminRedeem(code string) error {
+	err := ms.adminService.RedeemInvitation(code, ms.lab.ID, ms.user.ID)
+	if err == sql.ErrNoRows {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.invitation_invalid"),
+		})
+	}
+
+	if err != nil {
+		log.Println("[ERR][adminRedeem][RedeemInvitation]", err)
+		return ms.Error()
+	}
+
+	name := resolverName(ms.message.From.FirstName, ms.message.From.LastName)
+	before, err := ms.promoteToAdmin(ms.user.ID, ms.lab.ID, name, ms.message.From.Username, ms.printer.Language(), types.RoleAssistant)
+	if err != nil {
+		log.Println("[ERR][adminRedeem][promoteToAdmin]", err)
+		return ms.Error()
+	}
+	if err := ms.auditPromotion(ms.user.ID, before); err != nil {
+		return ms.auditFailed()
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("admin.success", name),
+	})
+}
+
+// promoteToAdmin makes the user an admin of the lab with the role, keeping the
+// data of a registered student. An existing admin of the lab keeps the current
+// role, an admin of another lab moves to this one. The admin state of the user
+// before is returned for the audit log.
+func (ms *MessageService) promoteToAdmin(id, labID int64, name, username string, language types.Language, role types.Role) (interface{}, error) {
+	user, err := ms.userService.FindByID(id)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	var before interface{}
+	if err == nil {
+		before = adminAuditState(user)
+	}
+
+	if err == sql.ErrNoRows {
+		newUser := types.User{
+			ID:       id,
+			Name:     name,
+			UserType: types.UserTypeAdmin,
+			Language: language,
+			Status:   types.UserStatusApproved,
+		}
+		if _, err := ms.userService.SaveUser(newUser); err != nil {
+			return nil, err
+		}
+		if err := ms.userService.UpdateRole(id, role); err != nil {
+			return nil, err
+		}
+	} else if user.UserType == types.UserTypeStudent {
+		if err := ms.userService.UpdateUserType(id, types.UserTypeBoth); err != nil {
+			return nil, err
+		}
+		if err := ms.userService.UpdateRole(id, role); err != nil {
+			return nil, err
+		}
+	} else if user.AdminLabID != labID {
+		if err := ms.userService.UpdateRole(id, role); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ms.userService.UpdateAdminLab(id, labID); err != nil {
+		return nil, err
+	}
+
+	if err := ms.userService.UpdateUsername(id, username); err != nil {
+		return nil, err
+	}
+
+	return before, nil
+}
+
+// auditPromotion records that the user has joined the admins, once the
+// promotion is done.
+func (ms *MessageService) auditPromotion(id int64, before interface{}) error {
+	promoted, err := ms.userService.FindByID(id)
+	if err != nil {
+		log.Println("[ERR][auditPromotion][FindByID]", err)
+		return errAuditFailed
+	}
+
+	return ms.audit(types.AuditActionCreate, types.AuditEntityAdmin, id, before, adminAuditState(promoted))
+}
+
+// adminAuditState is the part of a user kept in the audit log when the user
+// joins or leaves the admins.
+func adminAuditState(user types.User) map[string]interface{} {
+	return map[string]interface{}{
+		"user_type":    user.UserType,
+		"role":         user.Role,
+		"admin_lab_id": user.AdminLabID,
+	}
+}
+
+// respondPermissions names the permission needed to answer each kind of
+// request.
+var respondPermissions = map[types.RespondType]types.Permission{
+	types.RespondTypeBorrow:        types.PermissionBorrowApprove,
+	types.RespondTypeToolReturning: types.PermissionBorrowApprove,
+	types.RespondTypeRegistration:  types.PermissionUserApprove,
+	types.RespondTypeNIMCollision:  types.PermissionUserApprove,
+	types.RespondTypeAdminRequest:  types.PermissionAdminManage,
+}
+
+func (ms *MessageService) Respond() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
+
+	respCommands, ok := helper.GetRespondCommandOrder(ms.messageText)
+	if !ok {
+		if !ms.can(types.PermissionBorrowApprove) && !ms.can(types.PermissionUserApprove) {
+			return ms.permissionDenied()
+		}
+		return ms.ListToRespond()
+	}
+
+	if permission, ok := respondPermissions[respCommands.Type]; ok && !ms.can(permission) {
+		return ms.permissionDenied()
+	}
+
+	if respCommands.Text != "yes" && respCommands.Text != "no" && respCommands.Text != "" {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.invalid_option"),
+		})
+	}
+
+	if respCommands.Type == types.RespondTypeBorrow {
+		return ms.respondBorrowInit(respCommands)
+	} else if respCommands.Type == types.RespondTypeToolReturning {
+		return ms.respondToolReturningInit(respCommands)
+	} else if respCommands.Type == types.RespondTypeNIMCollision {
+		return ms.respondNIMCollision(respCommands)
+	} else if respCommands.Type == types.RespondTypeRegistration {
+		return ms.respondRegistration(respCommands)
+	} else if respCommands.Type == types.RespondTypeAdminRequest {
+		return ms.respondAdminRequest(respCommands)
+	}
+
+	return ms.Unknown()
+}
+
+func (ms *MessageService) ListToRespond() error {
+	borrows, err := ms.borrowService.GetBorrowRequests(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][ListToRespond][GetBorrowRequests]", err)
+		return ms.Error()
+	}
+
+	toolRets, err := ms.toolReturningService.GetToolReturningRequests(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][ListToRespond][GetToolReturningRequests]", err)
+		return ms.Error()
+	}
+
+	borrowList := ms.printer.Text("respond.none")
+	if len(borrows) > 0 {
+		borrowList = helper.BuildBorrowRequestListMessage(borrows)
+	}
+
+	collisions, err := ms.nimCollisionService.GetPendingNIMCollisions()
+	if err != nil {
+		log.Println("[ERR][ListToRespond][GetPendingNIMCollisions]", err)
+		return ms.Error()
+	}
+
+	toolRetList := ms.printer.Text("respond.none")
+	if len(toolRets) > 0 {
+		toolRetList = helper.BuildToolReturningRequestListMessage(toolRets)
+	}
+
+	registrations, err := ms.userService.GetPendingRegistrations()
+	if err != nil {
+		log.Println("[ERR][ListToRespond][GetPendingRegistrations]", err)
+		return ms.Error()
+	}
+
+	collisionList := ms.printer.Text("respond.none")
+	if len(collisions) > 0 {
+		collisionList = helper.BuildNIMCollisionListMessage(collisions)
+	}
+
+	registrationList := ms.printer.Text("respond.none")
+	if len(registrations) > 0 {
+		registrationList = helper.BuildRegistrationListMessage(registrations)
+	}
+
+	message := ms.printer.Text("respond.list", borrowList, toolRetList, registrationList, collisionList, types.CommandRespond, types.CommandRespond)
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: message,
+	})
+}
+
+func (ms *MessageService) respondBorrowInit(commands types.RespondCommandOrder) error {
+	borrow, err := ms.borrowService.FindBorrowByID(commands.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][respondBorrowInit][FindBorrowByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || borrow.Status != types.GetBorrowStatus("request") || borrow.Tool.LabID != ms.lab.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.not_found"),
+		})
+	}
+
+	if commands.Text == "" {
+		return ms.respondBorrowDetail(borrow)
+	}
+
+	if commands.Text == "yes" && borrow.Tool.Stock < int64(borrow.Amount) {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.exceeds_stock", borrow.Tool.Stock),
+		})
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["respond_borrow_init"],
+		Data:  gen.RespondBorrowInit(borrow.ID, commands.Text),
+	})
+}
+
+func (ms *MessageService) askRespondDescription(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.ask_description"),
+	})
+	return nil
+}
+
+func (ms *MessageService) respondBorrowAcceptDescription(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["respond_borrow_complete"],
+		Data:  gen.RespondBorrowComplete(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) respondBorrowComplete(c *conversation.Context) error {
+	respondBorrowSession, ok := helper.GetChatSessionDetailByTopic(c.Details, types.Topic["respond_borrow_init"])
+	if !ok {
+		return errors.New("session not found")
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(respondBorrowSession.Data))
+	if err != nil {
+		log.Println("[ERR][respondBorrowComplete][ParseJSON]", err)
+		return err
+	}
+
+	var borrowID int64
+	bID, ok := dataParsed.Path("borrow_id").Data().(float64)
+	if ok {
+		borrowID = int64(bID)
+	}
+
+	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
+	if err != nil {
+		log.Println("[ERR][respondBorrowComplete][FindBorrowByID]", err)
+		return err
+	}
+
+	userResponse, _ := dataParsed.Path("user_response").Data().(string)
+
+	if err := ms.borrowService.UpdateBorrowConfirm(borrow.ID, time.Now(), ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName, c.Input.Text); err != nil {
+		log.Println("[ERR][respondBorrowComplete][UpdateBorrowConfirmedAt]", err)
+		return err
+	}
+
+	if userResponse == "yes" {
+		return ms.respondBorrowPositive(c, borrow)
+	}
+
+	return ms.respondBorrowNegative(c, borrow)
+}
+
+func (ms *MessageService) respondRegistration(commands types.RespondCommandOrder) error {
+	user, err := ms.userService.FindByID(commands.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][respondRegistration][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || user.Status != types.UserStatusPending || len(user.NIM) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.not_found"),
+		})
+	}
+
+	switch commands.Text {
+	case "yes":
+		if err := ms.userService.UpdateUserStatus(user.ID, types.UserStatusApproved); err != nil {
+			log.Println("[ERR][respondRegistration][UpdateUserStatus]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionApprove, types.AuditEntityUser, user.ID,
+			map[string]interface{}{"status": user.Status},
+			map[string]interface{}{"status": types.UserStatusApproved}); err != nil {
+			return ms.auditFailed()
+		}
+
+		ms.sendMessage(types.MessageRequest{
+			ChatID: user.ID,
+			Text:   i18n.NewPrinter(user.Language).Text("register.complete", types.CommandHelp),
+		})
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.registration_approved", user.Name),
+		})
+	case "no":
+		if err := ms.userService.UpdateUserStatus(user.ID, types.UserStatusRejected); err != nil {
+			log.Println("[ERR][respondRegistration][UpdateUserStatus]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionReject, types.AuditEntityUser, user.ID,
+			map[string]interface{}{"status": user.Status},
+			map[string]interface{}{"status": types.UserStatusRejected}); err != nil {
+			return ms.auditFailed()
+		}
+
+		ms.sendMessage(types.MessageRequest{
+			ChatID: user.ID,
+			Text:   i18n.NewPrinter(user.Language).Text("register.rejected"),
+		})
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.registration_rejected", user.Name),
+		})
+	}
+
+	return ms.respondRegistrationDetail(user)
+}
+
+func (ms *MessageService) respondAdminRequest(commands types.RespondCommandOrder) error {
+	request, err := ms.adminService.FindAdminRequestByID(commands.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][respondAdminRequest][FindAdminRequestByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || request.Status != types.AdminRequestStatusPending || request.LabID != ms.lab.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.not_found"),
+		})
+	}
+
+	switch commands.Text {
+	case "yes":
+		before, err := ms.promoteToAdmin(request.UserID, request.LabID, request.Name, request.Username, request.Language, types.RoleAssistant)
+		if err != nil {
+			log.Println("[ERR][respondAdminRequest][promoteToAdmin]", err)
+			return ms.Error()
+		}
+
+		if err := ms.adminService.ApproveAdminRequest(request.ID, ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName); err != nil {
+			log.Println("[ERR][respondAdminRequest][ApproveAdminRequest]", err)
+			return ms.Error()
+		}
+		if err := ms.auditPromotion(request.UserID, before); err != nil {
+			return ms.auditFailed()
+		}
+		if err := ms.audit(types.AuditActionApprove, types.AuditEntityAdminRequest, request.ID,
+			map[string]interface{}{"status": request.Status},
+			map[string]interface{}{"status": types.AdminRequestStatusApproved, "user_id": request.UserID, "role": types.RoleAssistant}); err != nil {
+			return ms.auditFailed()
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.success", request.Name),
+		})
+	case "no":
+		if err := ms.adminService.RejectAdminRequest(request.ID, ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName); err != nil {
+			log.Println("[ERR][respondAdminRequest][RejectAdminRequest]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionReject, types.AuditEntityAdminRequest, request.ID,
+			map[string]interface{}{"status": request.Status},
+			map[string]interface{}{"status": types.AdminRequestStatusRejected, "user_id": request.UserID}); err != nil {
+			return ms.auditFailed()
+		}
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("admin.request_rejected", request.Name),
+		})
+	}
+
+	return ms.adminRequestDetail(request)
+}
+
+func (ms *MessageService) respondRegistrationDetail(user types.User) error {
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("respond.registration_detail.title", user.ID))
+	b.Field(ms.printer.Text("field.name"), user.Name)
+	b.Field(ms.printer.Text("field.nim"), user.NIM)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(int(user.Batch)))
+	b.Field(ms.printer.Text("field.address"), user.Address)
+	if len(user.Phone) > 0 {
+		b.Field(ms.printer.Text("field.phone"), user.Phone)
+	}
+	b.Field(ms.printer.Text("field.registered_at"), ms.printer.DateString(user.CreatedAt))
+
+	return ms.sendMessage(types.MessageRequest{
+		Text:      b.String(),
+		ParseMode: string(b.Mode()),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeRegistration, user.ID),
+					},
+					{
+						Text:         ms.printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeRegistration, user.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) respondNIMCollision(commands types.RespondCommandOrder) error {
+	collision, err := ms.nimCollisionService.FindByID(commands.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][respondNIMCollision][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || collision.Status != types.NIMCollisionStatusPending {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.not_found"),
+		})
+	}
+
+	switch commands.Text {
+	case "yes":
+		if err := ms.nimCollisionService.ApproveNIMCollision(collision.ID, ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName); err != nil {
+			log.Println("[ERR][respondNIMCollision][ApproveNIMCollision]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionApprove, types.AuditEntityNIMCollision, collision.ID,
+			map[string]interface{}{"status": collision.Status},
+			map[string]interface{}{"status": types.NIMCollisionStatusApproved, "user_id": collision.UserID, "existing_user_id": collision.ExistingUserID, "nim": collision.NIM}); err != nil {
+			return ms.auditFailed()
+		}
+
+		printer := i18n.NewPrinter(collision.Language)
+		ms.sendMessage(types.MessageRequest{
+			ChatID: collision.UserID,
+			Text:   printer.Text("register.nim_approved", types.CommandHelp),
+		})
+
+		printer = ms.userPrinter(collision.ExistingUserID)
+		ms.sendMessage(types.MessageRequest{
+			ChatID: collision.ExistingUserID,
+			Text:   printer.Text("register.nim_moved", collision.NIM, types.CommandProfile),
+		})
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.nim_approved"),
+		})
+	case "no":
+		if err := ms.nimCollisionService.RejectNIMCollision(collision.ID, ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName); err != nil {
+			log.Println("[ERR][respondNIMCollision][RejectNIMCollision]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionReject, types.AuditEntityNIMCollision, collision.ID,
+			map[string]interface{}{"status": collision.Status},
+			map[string]interface{}{"status": types.NIMCollisionStatusRejected, "user_id": collision.UserID, "existing_user_id": collision.ExistingUserID, "nim": collision.NIM}); err != nil {
+			return ms.auditFailed()
+		}
+
+		printer := i18n.NewPrinter(collision.Language)
+		ms.sendMessage(types.MessageRequest{
+			ChatID: collision.UserID,
+			Text:   printer.Text("register.nim_rejected", collision.NIM),
+		})
+
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.nim_rejected"),
+		})
+	}
+
+	return ms.respondNIMCollisionDetail(collision)
+}
+
+func (ms *MessageService) respondNIMCollisionDetail(collision types.NIMCollision) error {
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("respond.nim_detail.title", collision.ID))
+	b.Field(ms.printer.Text("field.nim"), collision.NIM)
+	if nim, ok := helper.ParseNIM(collision.NIM, getNIMPatterns()); ok && len(nim.Faculty)+len(nim.Program) > 0 {
+		b.Field(ms.printer.Text("field.nim_program"), strings.Trim(nim.Faculty+"/"+nim.Program, "/"))
+	}
+	b.Line()
+	b.Bold(ms.printer.Text("respond.nim_detail.registrant")).Line()
+	b.Field(ms.printer.Text("field.name"), collision.Name)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(int(collision.Batch)))
+	b.Field(ms.printer.Text("field.address"), collision.Address)
+	b.Field(ms.printer.Text("field.requested_at"), ms.printer.DateString(collision.CreatedAt))
+	b.Line()
+	b.Bold(ms.printer.Text("respond.nim_detail.existing")).Line()
+	b.Field(ms.printer.Text("field.name"), collision.ExistingUser.Name)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(int(collision.ExistingUser.Batch)))
+	b.Field(ms.printer.Text("field.address"), collision.ExistingUser.Address)
+	b.Field(ms.printer.Text("field.registered_at"), ms.printer.DateString(collision.ExistingUser.CreatedAt))
+	b.Line()
+	b.Text(ms.printer.Text("respond.nim_detail.hint"))
+
+	return ms.sendMessage(types.MessageRequest{
+		Text:      b.String(),
+		ParseMode: string(b.Mode()),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeNIMCollision, collision.ID),
+					},
+					{
+						Text:         ms.printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeNIMCollision, collision.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) respondBorrowDetail(borrow types.Borrow) error {
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("respond.borrow_detail.title", borrow.ID))
+	b.Field(ms.printer.Text("field.requester"), fmt.Sprintf("%s (%s)", borrow.User.Name, borrow.User.NIM))
+	b.Field(ms.printer.Text("field.tool"), borrow.Tool.Name)
+	b.Field(ms.printer.Text("field.amount"), strconv.Itoa(borrow.Amount))
+	b.Field(ms.printer.Text("field.requested_at"), ms.printer.DateString(borrow.CreatedAt))
+	if borrow.Tool.IsConsumable() {
+		b.Field(ms.printer.Text("tool_field.jenis"), ms.printer.Text(fmt.Sprintf("tool_kind.%s", borrow.Tool.Kind)))
+	} else {
+		b.Field(ms.printer.Text("field.duration"), ms.printer.Plural("unit.days", borrow.Duration, borrow.Duration))
+	}
+	b.Line()
+	b.Block(ms.printer.Text("field.requester_address"), borrow.User.Address)
+	b.Line()
+	b.Block(ms.printer.Text("field.reason"), borrow.Reason.String)
+
+	return ms.sendMessage(types.MessageRequest{
+		Text:      b.String(),
+		ParseMode: string(b.Mode()),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+					},
+					{
+						Text:         ms.printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) respondBorrowPositive(c *conversation.Context, borrow types.Borrow) error {
+	if borrow.Tool.IsConsumable() {
+		return ms.respondConsumePositive(c, borrow)
+	}
+
+	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("progress")); err != nil {
+		log.Println("[ERR][respondBorrowPositive][UpdateBorrowStatus]", err)
+		return err
+	}
+
+	if err := ms.toolService.DecreaseStock(borrow.ToolID, borrow.Amount); err != nil {
+		log.Println("[ERR][respondBorrowPositive][DecreaseStock]", err)
+		return err
+	}
+
+	if err := ms.audit(types.AuditActionApprove, types.AuditEntityBorrow, borrow.ID,
+		map[string]interface{}{"status": borrow.Status},
+		map[string]interface{}{"status": types.GetBorrowStatus("progress"), "admin_notes": c.Input.Text}); err != nil {
+		return err
+	}
+
+	printer := ms.userPrinter(borrow.UserID)
+	returnDate := time.Now().AddDate(0, 0, borrow.Duration)
+	message := printer.Text("respond.borrow_approved_user",
+		borrow.Tool.Name,
+		printer.Date(returnDate),
+		printer.Plural("unit.days", borrow.Duration, borrow.Duration),
+		c.Input.Text,
+	)
+
+	c.Reply(types.MessageRequest{
+		ChatID: borrow.UserID,
+		Text:   message,
+	})
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.borrow_approved"),
+	})
+	return nil
+}
+
+// respondConsumePositive hands out a consumable for good. Its stock is never
+// increased back as nothing is returned.
+func (ms *MessageService) respondConsumePositive(c *conversation.Context, borrow types.Borrow) error {
+	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("consumed")); err != nil {
+		log.Println("[ERR][respondConsumePositive][UpdateBorrowStatus]", err)
+		return err
+	}
+
+	if err := ms.toolService.DecreaseStock(borrow.ToolID, borrow.Amount); err != nil {
+		log.Println("[ERR][respondConsumePositive][DecreaseStock]", err)
+		return err
+	}
+
+	if err := ms.audit(types.AuditActionApprove, types.AuditEntityBorrow, borrow.ID,
+		map[string]interface{}{"status": borrow.Status},
+		map[string]interface{}{"status": types.GetBorrowStatus("consumed"), "admin_notes": c.Input.Text}); err != nil {
+		return err
+	}
+
+	printer := ms.userPrinter(borrow.UserID)
+	c.Reply(types.MessageRequest{
+		ChatID: borrow.UserID,
+		Text:   printer.Text("respond.consume_approved_user", printer.Plural("unit.pieces", borrow.Amount, borrow.Amount), borrow.Tool.Name, c.Input.Text),
+	})
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.consume_approved"),
+	})
+
+	tool, err := ms.toolService.FindByID(borrow.ToolID)
+	if err != nil {
+		log.Println("[ERR][respondConsumePositive][FindByID]", err)
+		return nil
+	}
+
+	if tool.IsLowStock() {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("respond.low_stock", tool.Name, tool.Stock, tool.MinStock),
+		})
+	}
+	return nil
+}
+
+func (ms *MessageService) respondBorrowNegative(c *conversation.Context, borrow types.Borrow) error {
+	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("reject")); err != nil {
+		log.Println("[ERR][respondBorrowNegative][UpdateBorrowStatus]", err)
+		return err
+	}
+
+	if err := ms.audit(types.AuditActionReject, types.AuditEntityBorrow, borrow.ID,
+		map[string]interface{}{"status": borrow.Status},
+		map[string]interface{}{"status": types.GetBorrowStatus("reject"), "admin_notes": c.Input.Text}); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		ChatID: borrow.UserID,
+		Text:   ms.userPrinter(borrow.UserID).Text("respond.borrow_rejected_user", borrow.Tool.Name, c.Input.Text),
+	})
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.borrow_rejected"),
+	})
+	return nil
+}
+
+func (ms *MessageService) respondToolReturningInit(commands types.RespondCommandOrder) error {
+	toolReturning, err := ms.toolReturningService.FindToolReturningByID(commands.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][respondToolReturning][FindToolReturningByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows || toolReturning.Status != types.GetToolReturningStatus("request") || toolReturning.Borrow.Tool.LabID != ms.lab.ID {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("respond.not_found"),
+		})
+	}
+
+	if commands.Text == "" {
+		return ms.respondToolReturningDetail(toolReturning)
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["respond_tool_returning_init"],
+		Data:  gen.RespondToolReturningInit(toolReturning.ID, commands.Text),
+	})
+}
+
+func (ms *MessageService) respondToolReturningAcceptDescription(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["respond_tool_returning_complete"],
+		Data:  gen.RespondToolReturningComplete(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) respondToolReturningComplete(c *conversation.Context) error {
+	respondToolReturningSession, ok := helper.GetChatSessionDetailByTopic(c.Details, types.Topic["respond_tool_returning_init"])
+	if !ok {
+		return errors.New("session not found")
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(respondToolReturningSession.Data))
+	if err != nil {
+		log.Println("[ERR][respondToolReturningComplete][ParseJSON]", err)
+		return err
+	}
+
+	var toolReturningID int64
+	trID, ok := dataParsed.Path("tool_returning_id").Data().(float64)
+	if ok {
+		toolReturningID = int64(trID)
+	}
+
+	toolReturning, err := ms.toolReturningService.FindToolReturningByID(toolReturningID)
+	if err != nil {
+		log.Println("[ERR][respondToolReturningComplete][FindToolReturningByID]", err)
+		return err
+	}
+
+	userResponse, _ := dataParsed.Path("user_response").Data().(string)
+
+	if err := ms.toolReturningService.UpdateToolReturningConfirm(toolReturning.ID, time.Now(), ms.user.ID, ms.message.From.FirstName, ms.message.From.LastName); err != nil {
+		log.Println("[ERR][respondToolReturningComplete][UpdateToolReturningConfirmedAt]", err)
+		return err
+	}
+
+	if userResponse == "yes" {
+		return ms.respondToolReturningApprove(c, toolReturning)
+	}
+
+	return ms.respondToolReturningReject(c, toolReturning)
+}
+
+func (ms *MessageService) respondToolReturningDetail(toolReturning types.ToolReturning) error {
+	borrow := toolReturning.Borrow
+
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("respond.return_detail.title", toolReturning.ID))
+	b.Field(ms.printer.Text("field.requested_at"), ms.printer.DateString(toolReturning.CreatedAt))
+	b.Field(ms.printer.Text("field.requester"), fmt.Sprintf("%s (%s)", borrow.User.Name, borrow.User.NIM))
+	b.Field(ms.printer.Text("field.tool"), borrow.Tool.Name)
+	b.Field(ms.printer.Text("field.amount"), strconv.Itoa(borrow.Amount))
+	b.Field(ms.printer.Text("field.borrowed_since"), ms.printer.Date(borrow.ConfirmedAt.Time))
+	b.Field(ms.printer.Text("field.duration"), ms.printer.Plural("unit.days", borrow.Duration, borrow.Duration))
+	b.Line()
+	b.Block(ms.printer.Text("field.borrower_address"), borrow.User.Address)
+	b.Line()
+	b.Block(ms.printer.Text("field.description"), toolReturning.AdditionalInfo)
+
+	return ms.sendMessage(types.MessageRequest{
+		Text:      b.String(),
+		ParseMode: string(b.Mode()),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.approve"),
+						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
+					},
+					{
+						Text:         ms.printer.Text("button.reject"),
+						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
+					},
+				},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) respondToolReturningApprove(c *conversation.Context, toolReturning types.ToolReturning) error {
+	borrow, err := ms.borrowService.FindBorrowByID(toolReturning.BorrowID)
+	if err != nil {
+		log.Println("[ERR][respondToolReturningApprove][FindBorrowByID]", err)
+		return err
+	}
+
+	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("returned")); err != nil {
+		log.Println("[ERR][respondToolReturningApprove][UpdateBorrowStatus]", err)
+		return err
+	}
+
+	if err := ms.toolReturningService.UpdateToolReturningStatus(toolReturning.ID, types.GetToolReturningStatus("complete")); err != nil {
+		log.Println("[ERR][respondToolReturningToAdmin][UpdateToolReturningStatus]", err)
+		return err
+	}
+
+	if err := ms.toolService.IncreaseStock(toolReturning.Borrow.ToolID, borrow.Amount); err != nil {
+		log.Println("[ERR][respondToolReturningApprove][IncreaseStock]", err)
+		return err
+	}
+
+	if err := ms.audit(types.AuditActionApprove, types.AuditEntityToolReturning, toolReturning.ID,
+		map[string]interface{}{"status": toolReturning.Status, "borrow_status": borrow.Status},
+		map[string]interface{}{"status": types.GetToolReturningStatus("complete"), "borrow_status": types.GetBorrowStatus("returned"), "admin_notes": c.Input.Text}); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		ChatID: toolReturning.Borrow.UserID,
+		Text:   ms.userPrinter(toolReturning.Borrow.UserID).Text("respond.return_approved_user", toolReturning.Borrow.Tool.Name, c.Input.Text),
+	})
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.return_approved"),
+	})
+	return nil
+}
+
+func (ms *MessageService) respondToolReturningReject(c *conversation.Context, toolReturning types.ToolReturning) error {
+	if err := ms.toolReturningService.UpdateToolReturningStatus(toolReturning.ID, types.GetToolReturningStatus("reject")); err != nil {
+		log.Println("[ERR][respondToolReturningReject][UpdateToolReturningStatus]", err)
+		return err
+	}
+
+	if err := ms.audit(types.AuditActionReject, types.AuditEntityToolReturning, toolReturning.ID,
+		map[string]interface{}{"status": toolReturning.Status},
+		map[string]interface{}{"status": types.GetToolReturningStatus("reject"), "admin_notes": c.Input.Text}); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		ChatID: toolReturning.Borrow.UserID,
+		Text:   ms.userPrinter(toolReturning.Borrow.UserID).Text("respond.return_rejected_user", toolReturning.Borrow.Tool.Name, c.Input.Text),
+	})
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("respond.return_rejected"),
+	})
+	return nil
+}
+
+func (ms *MessageService) Manage() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
+
+	if !ms.can(types.PermissionToolManage) {
+		return ms.permissionDenied()
+	}
+
+	if path, ok := helper.GetManageCategoryPath(ms.messageText); ok {
+		return ms.manageCategory(path)
+	}
+
+	manageCommands, ok := helper.GetManageCommandOrder(ms.messageText)
+	if !ok {
+		return ms.manageMenu()
+	}
+
+	if manageCommands.ID == 0 {
+		if manageCommands.Type == types.ManageTypeEdit {
+			return ms.sendMessage(types.MessageRequest{
+				Text: ms.printer.Text("manage.edit_how_to",
+					types.CommandManage, types.ManageTypeEdit, types.CommandManage, types.ManageTypeEdit),
+			})
+		}
+
+		if manageCommands.Type == types.ManageTypeDelete {
+			return ms.sendMessage(types.MessageRequest{
+				Text: ms.printer.Text("manage.delete_how_to",
+					types.CommandManage, types.ManageTypeDelete, types.CommandManage, types.ManageTypeDelete),
+			})
+		}
+	}
+
+	switch manageCommands.Type {
+	case types.ManageTypeAdd:
+		return ms.manageAddInit()
+	case types.ManageTypeEdit:
+		return ms.manageEditInit(manageCommands.ID)
+	case types.ManageTypeDelete:
+		return ms.manageDeleteInit(manageCommands.ID)
+	case types.ManageTypePhoto:
+		return ms.managePhotoInit(manageCommands.ID)
+	}
+
+	return ms.Unknown()
+}
+
+func (ms *MessageService) manageMenu() error {
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("manage.menu"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{{
+					Text:         ms.printer.Text("manage.menu.add"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeAdd),
+				}},
+				{{
+					Text:         ms.printer.Text("manage.menu.edit"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeEdit),
+				}},
+				{{
+					Text:         ms.printer.Text("manage.menu.category"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeCategory),
+				}},
+			},
+		},
+	})
+}
+
+// manageCategory creates the categories of the path that do not exist yet.
+func (ms *MessageService) manageCategory(path string) error {
+	if len(helper.ParseToolCategoryPath(path)) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("manage.category.how_to", types.CommandManage, types.ManageTypeCategory, types.CommandManage, types.ManageTypeCategory),
+			ReplyMarkup: types.InlineKeyboardMarkup{
+				InlineKeyboard: [][]types.InlineKeyboardButton{
+					{{
+						Text:         ms.printer.Text("button.categories"),
+						CallbackData: fmt.Sprintf("/%s %s", types.CommandCheck, types.CheckTypeCategory),
+					}},
+				},
+			},
+		})
+	}
+
+	category, err := ms.toolCategoryService.SavePath(path)
+	if err != nil {
+		log.Println("[ERR][manageCategory][SavePath]", err)
+		return ms.Error()
+	}
+	if err := ms.audit(types.AuditActionCreate, types.AuditEntityCategory, category.ID, nil,
+		map[string]interface{}{"path": path, "parent_id": category.ParentID, "name": category.Name}); err != nil {
+		return ms.auditFailed()
+	}
+
+	categories, err := ms.toolCategoryService.GetPath(category.ID)
+	if err != nil {
+		log.Println("[ERR][manageCategory][GetPath]", err)
+		return ms.Error()
+	}
+
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("manage.category.saved", helper.BuildToolCategoryPath(categories)),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{{
+					Text:         ms.printer.Text("button.categories"),
+					CallbackData: fmt.Sprintf("/%s %s %d", types.CommandCheck, types.CheckTypeCategory, category.ID),
+				}},
+			},
+		},
+	})
+}
+
+func (ms *MessageService) manageAddInit() error {
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["manage_add_init"],
+	})
+}
+
+func (ms *MessageService) manageAddAskName(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_name"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptName(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_name"],
+		Data:  gen.ManageAddName(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) manageAddAskBrand(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_brand"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptBrand(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_brand"],
+		Data:  gen.ManageAddBrand(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) manageAddAskType(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_type"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptType(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_type"],
+		Data:  gen.ManageAddProductType(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) manageAddAskWeight(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_weight"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptWeight(c *conversation.Context) (conversation.Transition, error) {
+	i, err := strconv.ParseFloat(c.Input.Text, 10)
+	if err != nil || i < 0 {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.add.invalid_weight"))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_weight"],
+		Data:  gen.ManageAddWeight(float32(i)),
+	}, nil
+}
+
+func (ms *MessageService) manageAddAskStock(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_stock"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptStock(c *conversation.Context) (conversation.Transition, error) {
+	i, err := strconv.ParseInt(c.Input.Text, 10, 64)
+	if err != nil || i < 0 {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.add.invalid_stock"))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_stock"],
+		Data:  gen.ManageAddStock(i),
+	}, nil
+}
+
+func (ms *MessageService) manageAddAskInfo(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.ask_info"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptInfo(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_info"],
+		Data:  gen.ManageAddInfo(c.Input.Text),
+	}, nil
+}
+
+func (ms *MessageService) askToolPhoto(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.ask_photo"),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptPhoto(c *conversation.Context) (conversation.Transition, error) {
+	if len(c.Input.Message.Photo) == 0 {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.photo_only"))
+	}
+
+	pickedPhoto := helper.PickPhoto(c.Input.Message.Photo)
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_photo"],
+		Data:  gen.ManageAddPhoto(c.Input.Message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID),
+	}, nil
+}
+
+func (ms *MessageService) manageAddSummary(c *conversation.Context) error {
+	tool := helper.GetToolFromChatSessionDetail(types.ManageTypeAdd, c.Details)
+
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("manage.add.summary.title"))
+	b.Field(ms.printer.Text("label.name"), tool.Name)
+	b.Field(ms.printer.Text("label.brand"), tool.Brand)
+	b.Field(ms.printer.Text("label.product_type"), tool.ProductType)
+	b.Field(ms.printer.Text("label.weight"), ms.printer.Text("unit.grams", tool.Weight))
+	b.Field(ms.printer.Text("label.stock"), strconv.FormatInt(tool.Stock, 10))
+	b.Block(ms.printer.Text("label.description"), tool.AdditionalInformation)
+	b.Line()
+	b.Italic(ms.printer.Text("summary.confirm"))
+
+	req := b.Request()
+	req.ReplyMarkup = confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	c.Reply(req)
+	return nil
+}
+
+func (ms *MessageService) manageAddAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
 
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["respond_borrow_init"]:
-			return ms.respondBorrowComplete()
+	// the rest of the photos in an album are sent one by one
+	if len(c.Input.Message.MediaGroupID) != 0 {
+		pickedPhoto := helper.PickPhoto(c.Input.Message.Photo)
+		return conversation.Transition{
+			Topic:  types.Topic["manage_add_photo"],
+			Data:   gen.ManageAddPhoto(c.Input.Message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID),
+			Silent: true,
+		}, nil
+	}
+
+	return conversation.Transition{
+		Topic: types.Topic["manage_add_confirm"],
+		Data:  gen.ManageAddConfirm(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
+
+func (ms *MessageService) manageAddConfirm(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("manage.add.cancelled"),
+		})
+		return nil
+	}
+
+	tool := helper.GetToolFromChatSessionDetail(types.ManageTypeAdd, c.Details)
+	tool.LabID = ms.lab.ID
+	photos := helper.GetToolPhotosFromChatSessionDetails(c.Details)
+
+	toolID, err := ms.toolService.SaveTool(tool)
+	if err != nil {
+		log.Println("[ERR][manageAddConfirm][SaveTool]", err)
+		return err
+	}
+
+	if err = ms.toolService.SaveToolPhotos(toolID, photos); err != nil {
+		log.Println("[ERR][manageAddConfirm][SaveToolPhotos]", err)
+		return err
+	}
+
+	tool.ID = toolID
+	if err := ms.audit(types.AuditActionCreate, types.AuditEntityTool, toolID, nil, tool); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.add.success", toolID),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.check_tool"),
+						CallbackData: fmt.Sprintf("/%s %d", types.CommandCheck, toolID),
+					},
+				},
+			},
+		},
+	})
+	return nil
+}
+
+func (ms *MessageService) manageEditInit(toolID int64) error {
+	_, err := ms.findLabTool(toolID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][manageEditInit][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("manage.tool_not_found"),
+		})
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["manage_edit_init"],
+		Data:  gen.ManageEditInit(toolID),
+	})
+}
+
+func (ms *MessageService) manageEditAskField(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.edit.ask_field"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("tool_field.nama"),
+						CallbackData: "nama",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.brand"),
+						CallbackData: "brand",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.tipe"),
+						CallbackData: "tipe",
+					},
+				},
+				{
+					{
+						Text:         ms.printer.Text("tool_field.berat"),
+						CallbackData: "berat",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.stok"),
+						CallbackData: "stok",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.foto"),
+						CallbackData: "foto",
+					},
+				},
+				{
+					{
+						Text:         ms.printer.Text("tool_field.keterangan"),
+						CallbackData: "keterangan",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.kategori"),
+						CallbackData: "kategori",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.tag"),
+						CallbackData: "tag",
+					},
+				},
+				{
+					{
+						Text:         ms.printer.Text("tool_field.ruang"),
+						CallbackData: "ruang",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.lemari"),
+						CallbackData: "lemari",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.rak"),
+						CallbackData: "rak",
+					},
+				},
+				{
+					{
+						Text:         ms.printer.Text("tool_field.jenis"),
+						CallbackData: "jenis",
+					},
+					{
+						Text:         ms.printer.Text("tool_field.stok_minimum"),
+						CallbackData: "stok_minimum",
+					},
+				},
+			},
+		},
+	})
+	return nil
+}
+
+func (ms *MessageService) manageEditAcceptField(c *conversation.Context) (conversation.Transition, error) {
+	if ok := helper.IsToolFieldExists(c.Input.Text); !ok {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.edit.field_not_available"))
+	}
+
+	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeEdit, c.Details)
+	gen := helper.NewSessionDataGenerator()
+
+	if types.ToolField(c.Input.Text) == types.ToolFieldPhoto {
+		return conversation.Transition{
+			Topic: types.Topic["manage_photo_init"],
+			Data:  gen.ManagePhotoInit(sessionTool.ID),
+		}, nil
+	}
+
+	return conversation.Transition{
+		Topic: types.Topic["manage_edit_field"],
+		Data:  gen.ManageEditField(c.Input.Text),
+	}, nil
+}
+
+// getManageEditField returns the tool field chosen in the manage edit flow.
+func getManageEditField(details []types.ChatSessionDetail) (string, error) {
+	manageEditSession, found := helper.GetChatSessionDetailByTopic(details, types.Topic["manage_edit_field"])
+	if !found {
+		return "", nil
+	}
+
+	dataParsed, err := gabs.ParseJSON([]byte(manageEditSession.Data))
+	if err != nil {
+		return "", err
+	}
+
+	field, _ := dataParsed.Path("field").Data().(string)
+	return field, nil
+}
+
+func (ms *MessageService) manageEditAskValue(c *conversation.Context) error {
+	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeEdit, c.Details)
+	tool, err := ms.toolService.FindByID(sessionTool.ID)
+	if err != nil {
+		log.Println("[ERR][manageEditAskValue][FindByID]", err)
+		return err
+	}
+
+	field, err := getManageEditField(c.Details)
+	if err != nil {
+		return err
+	}
+
+	oldValue := helper.GetToolValueByField(tool, field)
+	if types.ToolField(field) == types.ToolFieldCategory && tool.CategoryID > 0 {
+		path, err := ms.toolCategoryService.GetPath(tool.CategoryID)
+		if err != nil {
+			log.Println("[ERR][manageEditAskValue][GetPath]", err)
+			return err
 		}
+		oldValue = helper.BuildToolCategoryPath(path)
+	}
+
+	message := ms.printer.Text("manage.edit.ask_value", oldValue)
+	switch types.ToolField(field) {
+	case types.ToolFieldCategory:
+		message += "\n\n" + ms.printer.Text("manage.edit.category_hint", types.CommandManage, types.ManageTypeCategory)
+	case types.ToolFieldTags:
+		message += "\n\n" + ms.printer.Text("manage.edit.tags_hint", types.ToolFieldEmptyValue)
+	case types.ToolFieldRoom, types.ToolFieldCabinet, types.ToolFieldShelf:
+		message += "\n\n" + ms.printer.Text("manage.edit.optional_hint", types.ToolFieldEmptyValue)
+	case types.ToolFieldMinStock:
+		message += "\n\n" + ms.printer.Text("manage.edit.min_stock_hint")
+	case types.ToolFieldKind:
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("manage.edit.kind_hint"),
+			ReplyMarkup: types.InlineKeyboardMarkup{
+				InlineKeyboard: [][]types.InlineKeyboardButton{
+					{
+						{
+							Text:         ms.printer.Text(fmt.Sprintf("tool_kind.%s", types.ToolKindAsset)),
+							CallbackData: helper.ToolKindValue(types.ToolKindAsset),
+						},
+						{
+							Text:         ms.printer.Text(fmt.Sprintf("tool_kind.%s", types.ToolKindConsumable)),
+							CallbackData: helper.ToolKindValue(types.ToolKindConsumable),
+						},
+					},
+				},
+			},
+		})
+		return nil
 	}
 
-	return ms.Unknown()
+	c.Reply(types.MessageRequest{
+		Text: message,
+	})
+	return nil
 }
 
-func (ms *MessageService) respondBorrowInit(commands types.RespondCommandOrder) error {
-	borrow, err := ms.borrowService.FindBorrowByID(commands.ID)
+// resolveToolValue turns the category path typed by the user into the category
+// ID expected by helper.ChangeToolValueByField. Other values are kept as is.
+func (ms *MessageService) resolveToolValue(field, value string) (string, error) {
+	if types.ToolField(field) != types.ToolFieldCategory || len(helper.ParseToolCategoryPath(value)) == 0 {
+		return value, nil
+	}
+
+	category, err := ms.toolCategoryService.FindByPath(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(category.ID, 10), nil
+}
+
+func (ms *MessageService) manageEditAcceptValue(c *conversation.Context) (conversation.Transition, error) {
+	field, err := getManageEditField(c.Details)
+	if err != nil {
+		return conversation.Transition{}, err
+	}
+
+	value, err := ms.resolveToolValue(field, c.Input.Text)
+	if err == sql.ErrNoRows {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.edit.category_not_found", types.CommandManage, types.ManageTypeCategory))
+	}
+	if err != nil {
+		log.Println("[ERR][manageEditAcceptValue][resolveToolValue]", err)
+		return conversation.Transition{}, err
+	}
+
+	if _, err := helper.ChangeToolValueByField(types.Tool{}, field, value); err != nil {
+		return conversation.Transition{}, conversation.Invalid(toolValueErrorMessage(ms.printer, err))
+	}
+
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_edit_complete"],
+		Data:  gen.ManageEditComplete(c.Input.Text),
+	}, nil
+}
+
+// toolValueErrorMessage explains why a new tool value cannot be used.
+func toolValueErrorMessage(p i18n.Printer, err error) string {
+	switch err {
+	case helper.ErrToolWeightNotNumber:
+		return p.Text("manage.edit.weight_not_number")
+	case helper.ErrToolStockNotNumber:
+		return p.Text("manage.edit.stock_not_number")
+	case helper.ErrToolCategoryInvalid:
+		return p.Text("manage.edit.category_not_found", types.CommandManage, types.ManageTypeCategory)
+	case helper.ErrToolKindInvalid:
+		return p.Text("manage.edit.kind_invalid")
+	case helper.ErrToolMinStockInvalid:
+		return p.Text("manage.edit.min_stock_not_number")
+	case helper.ErrToolTagTooLong:
+		return p.Text("manage.edit.tag_too_long", types.ToolTagMaxLength)
+	default:
+		return p.Text("error")
+	}
+}
+
+func (ms *MessageService) manageEditComplete(c *conversation.Context) error {
+	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeEdit, c.Details)
+	tool, err := ms.toolService.FindByID(sessionTool.ID)
+	if err != nil {
+		log.Println("[ERR][manageEditComplete][FindByID]", err)
+		return err
+	}
+
+	field, err := getManageEditField(c.Details)
+	if err != nil {
+		return err
+	}
+
+	value, err := ms.resolveToolValue(field, c.Input.Text)
+	if err != nil {
+		log.Println("[ERR][manageEditComplete][resolveToolValue]", err)
+		return err
+	}
+
+	updatedTool, err := helper.ChangeToolValueByField(tool, field, value)
+	if err != nil {
+		return err
+	}
+
+	if err := ms.toolService.UpdateTool(updatedTool); err != nil {
+		log.Println("[ERR][manageEditComplete][UpdateTool]", err)
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("manage.edit.failed", tool.ID),
+		})
+		return nil
+	}
+	if err := ms.audit(types.AuditActionUpdate, types.AuditEntityTool, tool.ID, tool, updatedTool); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.edit.success", tool.ID),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{
+					{
+						Text:         ms.printer.Text("button.check_tool"),
+						CallbackData: fmt.Sprintf("/%s %d", types.CommandCheck, tool.ID),
+					},
+				},
+			},
+		},
+	})
+	return nil
+}
+
+func (ms *MessageService) manageDeleteInit(toolID int64) error {
+	_, err := ms.findLabTool(toolID)
 	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][respondBorrowInit][FindBorrowByID]", err)
+		log.Println("[ERR][managedDeleteInit][FindByID]", err)
 		return ms.Error()
 	}
 
-	if err == sql.ErrNoRows || borrow.Status != types.GetBorrowStatus("request") {
+	if err == sql.ErrNoRows {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Gagal menanggapi, ID tidak ditemukan.",
+			Text: ms.printer.Text("manage.tool_not_found"),
 		})
 	}
 
-	if commands.Text == "" {
-		return ms.respondBorrowDetail(borrow)
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["manage_delete_init"],
+		Data:  gen.ManageDeleteInit(toolID),
+	})
+}
+
+func (ms *MessageService) manageDeleteAskConfirmation(c *conversation.Context) error {
+	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeDelete, c.Details)
+	tool, err := ms.toolService.FindByID(sessionTool.ID)
+	if err != nil {
+		log.Println("[ERR][manageDeleteAskConfirmation][FindByID]", err)
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		Text:        ms.printer.Text("manage.delete.ask_confirmation", tool.Name),
+		ReplyMarkup: confirmationKeyboard(ms.printer.Text("button.sure"), ms.printer.Text("button.cancel")),
+	})
+	return nil
+}
+
+func (ms *MessageService) manageDeleteAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_delete_complete"],
+		Data:  gen.ManageDeleteComplete(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
+
+func (ms *MessageService) manageDeleteComplete(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("manage.delete.cancelled"),
+		})
+		return nil
+	}
+
+	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeDelete, c.Details)
+	tool, err := ms.toolService.FindByID(sessionTool.ID)
+	if err != nil {
+		log.Println("[ERR][manageDeleteComplete][FindByID]", err)
+		return err
+	}
+
+	if err := ms.toolService.DeleteTool(tool.ID); err != nil {
+		log.Println("[ERR][manageDeleteComplete][DeleteTool]", err)
+		return err
+	}
+	if err := ms.audit(types.AuditActionDelete, types.AuditEntityTool, tool.ID, tool, nil); err != nil {
+		return err
+	}
+
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.delete.success", sessionTool.ID),
+	})
+	return nil
+}
+
+func (ms *MessageService) managePhotoInit(toolID int64) error {
+	_, err := ms.findLabTool(toolID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][managePhotoInit][FindByID]", err)
+		return ms.Error()
+	}
+
+	if err == sql.ErrNoRows {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("manage.tool_not_found"),
+		})
 	}
 
-	if commands.Text == "yes" && borrow.Tool.Stock < int64(borrow.Amount) {
-		return ms.sendMessage(types.MessageRequest{
-			Text: fmt.Sprintf("Jumlah yang dipinjam melebihi stok yang ada. Stok saat ini %d", borrow.Tool.Stock),
-		})
+	gen := helper.NewSessionDataGenerator()
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["manage_photo_init"],
+		Data:  gen.ManagePhotoInit(toolID),
+	})
+}
+
+func (ms *MessageService) managePhotoAcceptUpload(c *conversation.Context) (conversation.Transition, error) {
+	if len(c.Input.Message.Photo) == 0 {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("manage.photo_only"))
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.RespondBorrowInit(borrow.ID, commands.Text)
+	pickedPhoto := helper.PickPhoto(c.Input.Message.Photo)
 
-	if err = ms.saveChatSessionDetail(types.Topic["respond_borrow_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][respondBorrowInit][saveChatSessionDetail]", err)
-		return ms.Error()
-	}
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["manage_photo_upload"],
+		Data:  gen.ManagePhotoUpload(c.Input.Message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID),
+	}, nil
+}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Tuliskan keterangan tambahan.",
+func (ms *MessageService) managePhotoUploaded(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.photo.uploaded"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{{
+					Text:         ms.printer.Text("button.save_changes"),
+					CallbackData: "yes",
+				}},
+				{{
+					Text:         ms.printer.Text("button.cancel"),
+					CallbackData: "no",
+				}},
+			},
+		},
 	})
+	return nil
 }
 
-func (ms *MessageService) respondBorrowComplete() error {
-	respondBorrowSession, ok := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["respond_borrow_init"])
-	if !ok {
-		return ms.Unknown()
-	}
+func (ms *MessageService) managePhotoAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
 
-	dataParsed, err := gabs.ParseJSON([]byte(respondBorrowSession.Data))
-	if err != nil {
-		log.Println("[ERR][respondBorrowComplete][ParseJSON]", err)
-		return ms.Error()
+	// the rest of the photos in an album are sent one by one
+	if len(c.Input.Message.MediaGroupID) != 0 {
+		pickedPhoto := helper.PickPhoto(c.Input.Message.Photo)
+		return conversation.Transition{
+			Topic:  types.Topic["manage_photo_upload"],
+			Data:   gen.ManagePhotoUpload(c.Input.Message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID),
+			Silent: true,
+		}, nil
 	}
 
-	var borrowID int64
-	bID, ok := dataParsed.Path("borrow_id").Data().(float64)
-	if ok {
-		borrowID = int64(bID)
-	}
+	return conversation.Transition{
+		Topic: types.Topic["manage_photo_confirm"],
+		Data:  gen.ManagePhotoConfirm(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
 
-	borrow, err := ms.borrowService.FindBorrowByID(borrowID)
-	if err != nil {
-		log.Println("[ERR][respondBorrowComplete][FindBorrowByID]", err)
-		return ms.Error()
+func (ms *MessageService) managePhotoConfirm(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("manage.photo.cancelled"),
+		})
+		return nil
 	}
 
-	userResponse, _ := dataParsed.Path("user_response").Data().(string)
-
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.RespondBorrowComplete(ms.messageText)
+	tool := helper.GetToolFromChatSessionDetail(types.ManageTypePhoto, c.Details)
+	photos := helper.GetToolPhotosFromChatSessionDetails(c.Details)
 
-	if err := ms.saveChatSessionDetail(types.Topic["respond_borrow_complete"], generatedSessionData); err != nil {
-		log.Println("[ERR][respondBorrowComplete][saveChatSessionDetail]", err)
-		return ms.Error()
-	}
-
-	if err := ms.borrowService.UpdateBorrowConfirm(borrow.ID, time.Now(), ms.message.From.FirstName, ms.message.From.LastName); err != nil {
-		log.Println("[ERR][respondBorrowComplete][UpdateBorrowConfirmedAt]", err)
-		return ms.Error()
+	oldPhotos, err := ms.toolService.GetPhotos(tool.ID)
+	if err != nil {
+		log.Println("[ERR][managePhotoConfirm][GetPhotos]", err)
+		return err
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][respondBorrowComplete][UpdateChatSessionStatus]", err)
-		return ms.Error()
+	if err := ms.toolService.UpdatePhotos(tool.ID, photos); err != nil {
+		log.Println("[ERR][managePhotoConfirm][UpdatePhotos]", err)
+		return err
 	}
-
-	if userResponse == "yes" {
-		return ms.respondBorrowPositive(borrow)
+	if err := ms.audit(types.AuditActionUpdate, types.AuditEntityTool, tool.ID,
+		map[string]interface{}{"photos": helper.GetPhotoUniqueIDs(oldPhotos)},
+		map[string]interface{}{"photos": helper.GetPhotoUniqueIDs(photos)}); err != nil {
+		return err
 	}
 
-	return ms.respondBorrowNegative(borrow)
-}
-
-func (ms *MessageService) respondBorrowDetail(borrow types.Borrow) error {
-	message := fmt.Sprintf(`
-		ID: %d
-		Nama pemohon: %s (%s)
-		Barang: %s
-		Jumlah: %d
-		Diajukan pada: %s
-		Durasi peminjaman: %d hari
-		Alamat pemohon:
-		%s
-
-		Alasan peminjaman:
-		%s
-	`, borrow.ID, borrow.User.Name, borrow.User.NIM, borrow.Tool.Name, borrow.Amount, helper.TranslateDateStringToBahasa(borrow.CreatedAt), borrow.Duration, borrow.User.Address, borrow.Reason.String)
-	message = helper.RemoveTab(message)
-
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("manage.photo.success"),
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
 				{
 					{
-						Text:         "Setujui",
-						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
-					},
-					{
-						Text:         "Tolak",
-						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeBorrow, borrow.ID),
+						Text:         ms.printer.Text("button.view_photo"),
+						CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
 					},
 				},
 			},
 		},
 	})
+	return nil
 }
 
-func (ms *MessageService) respondBorrowPositive(borrow types.Borrow) error {
-	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("progress")); err != nil {
-		log.Println("[ERR][respondBorrowPositive][UpdateBorrowStatus]", err)
-		return ms.Error()
+func (ms *MessageService) Report() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
 	}
 
-	if err := ms.toolService.DecreaseStock(borrow.ToolID, borrow.Amount); err != nil {
-		log.Println("[ERR][respondBorrowPositive][DecreaseStock]", err)
-		return ms.Error()
+	if !ms.can(types.PermissionReportView) {
+		return ms.permissionDenied()
 	}
 
-	returnDate := time.Now().AddDate(0, 0, borrow.Duration)
-	message := fmt.Sprintf(`Pengajuan peminjaman "%s" telah disetujui oleh pengurus.
-		Batas akhir peminjaman: %s (%d hari)
-
-		Keterangan:
-		%s`, borrow.Tool.Name, helper.TranslateDateToBahasa(returnDate), borrow.Duration, ms.messageText)
-	message = helper.RemoveTab(message)
-
-	reqBody := types.MessageRequest{
-		ChatID: borrow.UserID,
-		Text:   message,
+	reportCommands, ok := helper.GetReportCommandOrder(ms.messageText)
+	if !ok {
+		return ms.reportMenu()
 	}
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("error in sending reply:", err)
-		return err
+
+	if reportCommands.Type == types.ReportTypeBorrow {
+		return ms.reportBorrow(reportCommands)
+	} else if reportCommands.Type == types.ReportTypeToolReturning {
+		return ms.reportToolReturning(reportCommands)
+	} else if reportCommands.Type == types.ReportTypeConsumption {
+		return ms.reportConsumption(reportCommands)
 	}
 
+	return ms.Unknown()
+}
+
+func (ms *MessageService) reportMenu() error {
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Pengajuan peminjaman berhasil disetujui.",
+		Text: ms.printer.Text("report.menu"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: [][]types.InlineKeyboardButton{
+				{{
+					Text:         ms.printer.Text("report.menu.borrow"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandReport, types.ReportTypeBorrow),
+				}},
+				{{
+					Text:         ms.printer.Text("report.menu.tool_returning"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandReport, types.ReportTypeToolReturning),
+				}},
+				{{
+					Text:         ms.printer.Text("report.menu.consumption"),
+					CallbackData: fmt.Sprintf("/%s %s", types.CommandReport, types.ReportTypeConsumption),
+				}},
+			},
+		},
 	})
 }
 
-func (ms *MessageService) respondBorrowNegative(borrow types.Borrow) error {
-	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("reject")); err != nil {
-		log.Println("[ERR][respondBorrowNegative][UpdateBorrowStatus]", err)
-		return ms.Error()
-	}
+// periodKeyboard offers the common report periods, appending each to the
+// command, e.g. "/laporan pinjam".
+func (ms *MessageService) periodKeyboard(command string) types.InlineKeyboardMarkup {
+	now := time.Now()
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	lastMonth := firstOfMonth.AddDate(0, -1, 0)
 
-	reqBody := types.MessageRequest{
-		ChatID: borrow.UserID,
-		Text:   fmt.Sprintf("Pengajuan peminjaman \"%s\" telah ditolak oleh pengurus.\n\nKeterangan:\n%s", borrow.Tool.Name, ms.messageText),
+	presets := []struct {
+		label  string
+		period types.ReportPeriod
+	}{
+		{"report.this_month", helper.MonthReportPeriod(now.Year(), int(now.Month()))},
+		{"report.last_month", helper.MonthReportPeriod(lastMonth.Year(), int(lastMonth.Month()))},
+		{"report.this_semester", helper.SemesterReportPeriod(now.Year(), helper.CurrentSemester(now))},
+		{"report.this_year", helper.YearReportPeriod(now.Year())},
 	}
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("error in sending reply:", err)
-		return err
+
+	keyboard := [][]types.InlineKeyboardButton{}
+	for _, preset := range presets {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{{
+			Text:         ms.printer.Text(preset.label),
+			CallbackData: fmt.Sprintf("%s %s", command, helper.ReportPeriodCommand(preset.period)),
+		}})
 	}
 
+	return types.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// reportHowTo explains the periods of the report type, or tells the period
+// given could not be read.
+func (ms *MessageService) reportHowTo(reportType types.ReportType, howToKey string) error {
+	message := ms.printer.Text(howToKey, types.CommandReport, reportType, types.CommandReport, reportType)
+	message += ms.printer.Text("report.period_hint", types.CommandReport, reportType, types.CommandReport, reportType, types.CommandReport, reportType)
+	message += ms.printer.Text("report.export_hint", types.CommandReport, reportType)
+
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Pengajuan peminjaman berhasil ditolak.",
+		Text:        message,
+		ReplyMarkup: ms.periodKeyboard(fmt.Sprintf("/%s %s", types.CommandReport, reportType)),
 	})
 }
 
-func (ms *MessageService) RespondToolReturning() error {
-	if !ms.isEligibleAdmin() {
-		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
-		return ms.Unknown()
+func (ms *MessageService) reportBorrow(commands types.ReportCommandOrder) error {
+	if len(commands.Text) == 0 {
+		return ms.reportHowTo(types.ReportTypeBorrow, "report.borrow_how_to")
 	}
 
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["respond_tool_returning_init"]:
-			return ms.respondToolReturningComplete()
-		}
+	period, ok := helper.GetReportPeriodFromCommand(commands.Text)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("report.invalid_time", types.CommandReport, types.ReportTypeBorrow),
+		})
 	}
 
-	return ms.Unknown()
-}
-
-func (ms *MessageService) respondToolReturningInit(commands types.RespondCommandOrder) error {
-	toolReturning, err := ms.toolReturningService.FindToolReturningByID(commands.ID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][respondToolReturning][FindToolReturningByID]", err)
+	borrows, err := ms.borrowService.GetBorrowReport(ms.lab.ID, period)
+	if err != nil {
+		log.Println("[ERR][reportBorrow][GetBorrowReport]", err)
 		return ms.Error()
 	}
 
-	if err == sql.ErrNoRows || toolReturning.Status != types.GetToolReturningStatus("request") {
+	if len(borrows) == 0 {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Gagal menanggapi, ID tidak ditemukan.",
+			Text: ms.printer.Text("report.borrow_empty"),
 		})
 	}
 
-	if commands.Text == "" {
-		return ms.respondToolReturningDetail(toolReturning)
+	title := ms.printer.Text("report.borrow_title", helper.ReportPeriodLabel(ms.printer, period))
+	if len(commands.Format) > 0 {
+		return ms.sendReportDocument(commands, period, title, helper.BuildBorrowReportTable(ms.printer, borrows))
+	}
+
+	b := format.New(format.MarkdownV2)
+	b.Title(title)
+	b.Raw(helper.BuildBorrowReportMessage(ms.printer, b.Mode(), borrows))
+
+	if err := ms.sendMessage(b.Request()); err != nil {
+		return err
 	}
 
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.RespondToolReturningInit(toolReturning.ID, commands.Text)
+	if !helper.IsMultiMonthPeriod(period) {
+		return nil
+	}
 
-	if err = ms.saveChatSessionDetail(types.Topic["respond_tool_returning_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][respondToolReturningInit][saveChatSessionDetail]", err)
+	months, err := ms.statisticsService.GetMonthlyUsage(ms.lab.ID, period)
+	if err != nil {
+		log.Println("[ERR][reportBorrow][GetMonthlyUsage]", err)
 		return ms.Error()
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Tuliskan keterangan tambahan.",
-	})
+	return ms.sendChart(helper.BuildMonthlyBorrowChart(ms.printer, months), period)
 }
 
-func (ms *MessageService) respondToolReturningComplete() error {
-	respondToolReturningSession, ok := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["respond_tool_returning_init"])
+func (ms *MessageService) reportToolReturning(commands types.ReportCommandOrder) error {
+	if len(commands.Text) == 0 {
+		return ms.reportHowTo(types.ReportTypeToolReturning, "report.tool_returning_how_to")
+	}
+
+	period, ok := helper.GetReportPeriodFromCommand(commands.Text)
 	if !ok {
-		return ms.Unknown()
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("report.invalid_time", types.CommandReport, types.ReportTypeToolReturning),
+		})
 	}
 
-	dataParsed, err := gabs.ParseJSON([]byte(respondToolReturningSession.Data))
+	toolReturnings, err := ms.toolReturningService.GetToolReturningReport(ms.lab.ID, period)
 	if err != nil {
-		log.Println("[ERR][respondToolReturningComplete][ParseJSON]", err)
+		log.Println("[ERR][reportToolReturning][GetToolReturningReport]", err)
 		return ms.Error()
 	}
 
-	var toolReturningID int64
-	trID, ok := dataParsed.Path("tool_returning_id").Data().(float64)
-	if ok {
-		toolReturningID = int64(trID)
+	if len(toolReturnings) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("report.tool_returning_empty"),
+		})
 	}
 
-	toolReturning, err := ms.toolReturningService.FindToolReturningByID(toolReturningID)
-	if err != nil {
-		log.Println("[ERR][respondToolReturningComplete][FindToolReturningByID]", err)
-		return ms.Error()
+	title := ms.printer.Text("report.tool_returning_title", helper.ReportPeriodLabel(ms.printer, period))
+	if len(commands.Format) > 0 {
+		return ms.sendReportDocument(commands, period, title, helper.BuildToolReturningReportTable(ms.printer, toolReturnings))
 	}
 
-	userResponse, _ := dataParsed.Path("user_response").Data().(string)
-
-	sessionDataGenerator := helper.NewSessionDataGenerator()
-	generatedSessionData := sessionDataGenerator.RespondToolReturningComplete(ms.messageText)
+	b := format.New(format.MarkdownV2)
+	b.Title(title)
+	b.Raw(helper.BuildToolReturningReportMessage(ms.printer, b.Mode(), toolReturnings))
 
-	if err := ms.saveChatSessionDetail(types.Topic["respond_tool_returning_complete"], generatedSessionData); err != nil {
-		log.Println("[ERR][respondToolReturningComplete][saveChatSessionDetail]", err)
-		return ms.Error()
+	if err := ms.sendMessage(b.Request()); err != nil {
+		return err
 	}
 
-	if err := ms.toolReturningService.UpdateToolReturningConfirm(toolReturning.ID, time.Now(), ms.message.From.FirstName, ms.message.From.LastName); err != nil {
-		log.Println("[ERR][respondToolReturningComplete][UpdateToolReturningConfirmedAt]", err)
-		return ms.Error()
+	if !helper.IsMultiMonthPeriod(period) {
+		return nil
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][respondToolReturningComplete][UpdateChatSessionStatus]", err)
+	months, err := ms.statisticsService.GetMonthlyUsage(ms.lab.ID, period)
+	if err != nil {
+		log.Println("[ERR][reportToolReturning][GetMonthlyUsage]", err)
 		return ms.Error()
 	}
 
-	if userResponse == "yes" {
-		return ms.respondToolReturningApprove(toolReturning)
-	}
-
-	return ms.respondToolReturningReject(toolReturning)
+	return ms.sendChart(helper.BuildMonthlyOverdueChart(ms.printer, months), period)
 }
 
-func (ms *MessageService) respondToolReturningDetail(toolReturning types.ToolReturning) error {
-	message := fmt.Sprintf(`
-		ID: %d
-		Diajukan pada: %s
-		Nama pemohon: %s (%s)
-		Barang: %s
-		Jumlah: %d
-		Dipinjam sejak: %s
-		Durasi peminjaman: %d hari
-		Alamat peminjam:
-		%s
-
-		Keterangan:
-		%s
-	`, toolReturning.ID, helper.TranslateDateStringToBahasa(toolReturning.CreatedAt), toolReturning.Borrow.User.Name, toolReturning.Borrow.User.NIM, toolReturning.Borrow.Tool.Name, toolReturning.Borrow.Amount, helper.TranslateDateToBahasa(toolReturning.Borrow.ConfirmedAt.Time), toolReturning.Borrow.Duration, toolReturning.Borrow.User.Address, toolReturning.AdditionalInfo)
-	message = helper.RemoveTab(message)
+// reportConsumption sums the consumables handed out in the period and warns
+// about the ones running low.
+func (ms *MessageService) reportConsumption(commands types.ReportCommandOrder) error {
+	if len(commands.Text) == 0 {
+		return ms.reportHowTo(types.ReportTypeConsumption, "report.consumption_how_to")
+	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Setujui",
-						CallbackData: fmt.Sprintf("/%s %s %d yes", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
-					},
-					{
-						Text:         "Tolak",
-						CallbackData: fmt.Sprintf("/%s %s %d no", types.CommandRespond, types.RespondTypeToolReturning, toolReturning.ID),
-					},
-				},
-			},
-		},
-	})
-}
+	period, ok := helper.GetReportPeriodFromCommand(commands.Text)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("report.invalid_time", types.CommandReport, types.ReportTypeConsumption),
+		})
+	}
 
-func (ms *MessageService) respondToolReturningApprove(toolReturning types.ToolReturning) error {
-	borrow, err := ms.borrowService.FindBorrowByID(toolReturning.BorrowID)
+	consumptions, err := ms.borrowService.GetConsumptionReport(ms.lab.ID, period)
 	if err != nil {
-		log.Println("[ERR][respondToolReturningApprove][FindBorrowByID]", err)
+		log.Println("[ERR][reportConsumption][GetConsumptionReport]", err)
 		return ms.Error()
 	}
 
-	if err := ms.borrowService.UpdateBorrowStatus(borrow.ID, types.GetBorrowStatus("returned")); err != nil {
-		log.Println("[ERR][respondToolReturningApprove][UpdateBorrowStatus]", err)
-		return ms.Error()
-	}
+	title := ms.printer.Text("report.consumption_title", helper.ReportPeriodLabel(ms.printer, period))
+	if len(commands.Format) > 0 {
+		if len(consumptions) == 0 {
+			return ms.sendMessage(types.MessageRequest{
+				Text: ms.printer.Text("report.consumption_empty"),
+			})
+		}
 
-	if err := ms.toolReturningService.UpdateToolReturningStatus(toolReturning.ID, types.GetToolReturningStatus("complete")); err != nil {
-		log.Println("[ERR][respondToolReturningToAdmin][UpdateToolReturningStatus]", err)
-		return ms.Error()
+		return ms.sendReportDocument(commands, period, title, helper.BuildConsumptionReportTable(ms.printer, consumptions))
 	}
 
-	if err := ms.toolService.IncreaseStock(toolReturning.Borrow.ToolID, borrow.Amount); err != nil {
-		log.Println("[ERR][respondToolReturningApprove][IncreaseStock]", err)
+	lowStock, err := ms.toolService.GetLowStock(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][reportConsumption][GetLowStock]", err)
 		return ms.Error()
 	}
 
-	reqBody := types.MessageRequest{
-		ChatID: toolReturning.Borrow.UserID,
-		Text:   fmt.Sprintf("Pengajuan pengembalian \"%s\" telah disetujui oleh pengurus.\n\nKeterangan:\n%s", toolReturning.Borrow.Tool.Name, ms.messageText),
+	b := format.New(format.MarkdownV2)
+	b.Title(title)
+	if len(consumptions) == 0 {
+		b.Text(ms.printer.Text("report.consumption_empty")).Line()
+	} else {
+		b.Raw(helper.BuildConsumptionReportMessage(ms.printer, b.Mode(), consumptions))
 	}
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("error in sending reply:", err)
-		return err
+
+	if len(lowStock) > 0 {
+		b.Line()
+		b.Bold(ms.printer.Text("report.low_stock_title")).Line()
+		b.Raw(helper.BuildLowStockMessage(ms.printer, b.Mode(), lowStock))
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Pengajuan pengembalian berhasil disetujui.",
-	})
+	return ms.sendMessage(b.Request())
 }
 
-func (ms *MessageService) respondToolReturningReject(toolReturning types.ToolReturning) error {
-	if err := ms.toolReturningService.UpdateToolReturningStatus(toolReturning.ID, types.GetToolReturningStatus("reject")); err != nil {
-		log.Println("[ERR][respondToolReturningReject][UpdateToolReturningStatus]", err)
+// sendChart sends the chart as a photo captioned with its title and period.
+func (ms *MessageService) sendChart(c chart.Chart, period types.ReportPeriod) error {
+	content, err := c.PNG()
+	if err != nil {
+		log.Println("[ERR][sendChart][PNG]", err)
 		return ms.Error()
 	}
 
-	reqBody := types.MessageRequest{
-		ChatID: toolReturning.Borrow.UserID,
-		Text:   fmt.Sprintf("Pengajuan pengembalian \"%s\" telah ditolak oleh pengurus.\n\nKeterangan:\n%s", toolReturning.Borrow.Tool.Name, ms.messageText),
-	}
-	if err := ms.sendMessage(reqBody); err != nil {
-		log.Println("error in sending reply:", err)
-		return err
+	return ms.sendPhotoUpload(types.PhotoUploadRequest{
+		Caption:  ms.printer.Text("chart.caption", c.Title, helper.ReportPeriodLabel(ms.printer, period)),
+		FileName: "chart.png",
+		Content:  content,
+	})
+}
+
+func (ms *MessageService) sendReportDocument(commands types.ReportCommandOrder, period types.ReportPeriod, title string, table export.Table) error {
+	fileName, content, err := helper.ExportReport(commands.Type, period, commands.Format, table)
+	if err != nil {
+		log.Println("[ERR][sendReportDocument][ExportReport]", err)
+		return ms.Error()
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Pengajuan pengembalian berhasil ditolak",
+	return ms.sendDocument(types.DocumentRequest{
+		Caption:  ms.printer.Text("report.export_caption", title, strings.ToUpper(string(commands.Format))),
+		FileName: fileName,
+		Content:  content,
 	})
 }
 
-func (ms *MessageService) Manage() error {
+// Statistics shows the usage statistics of the month, or of the period given
+// the same way as the reports.
+func (ms *MessageService) Statistics() error {
 	if !ms.isEligibleAdmin() {
 		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
 		return ms.Unknown()
 	}
 
-	manageCommands, ok := helper.GetManageCommandOrder(ms.messageText)
-	if !ok {
-		return ms.manageMenu()
-	}
-
-	if manageCommands.ID == 0 {
-		if manageCommands.Type == types.ManageTypeEdit {
-			return ms.sendMessage(types.MessageRequest{
-				Text: fmt.Sprintf(
-					"Untuk melakukan pengubahan data silahkan kirim perintah\n\"/%s %s [id_barang]\"\n\nContoh: \"/%s %s 5\"",
-					types.CommandManage, types.ManageTypeEdit, types.CommandManage, types.ManageTypeEdit),
-			})
-		}
-
-		if manageCommands.Type == types.ManageTypeDelete {
+	if !ms.can(types.PermissionReportView) {
+		return ms.permissionDenied()
+	}
+
+	now := time.Now()
+	period := helper.MonthReportPeriod(now.Year(), int(now.Month()))
+
+	splittedText := strings.Fields(ms.messageText)
+	if len(splittedText) > 1 {
+		var ok bool
+		period, ok = helper.GetReportPeriodFromCommand(splittedText[1])
+		if !ok {
 			return ms.sendMessage(types.MessageRequest{
-				Text: fmt.Sprintf(
-					"Untuk melakukan penghapusan barang silahkan kirim perintah\n\"/%s %s [id_barang]\"\n\nContoh: \"/%s %s 5\"",
-					types.CommandManage, types.ManageTypeDelete, types.CommandManage, types.ManageTypeDelete),
+				Text: ms.printer.Text("statistics.invalid_time", types.CommandStatistics, types.CommandStatistics, types.CommandStatistics, types.CommandStatistics),
 			})
 		}
 	}
 
-	switch manageCommands.Type {
-	case types.ManageTypeAdd:
-		return ms.manageAddInit()
-	case types.ManageTypeEdit:
-		return ms.manageEditInit(manageCommands.ID)
-	case types.ManageTypeDelete:
-		return ms.manageDeleteInit(manageCommands.ID)
-	case types.ManageTypePhoto:
-		return ms.managePhotoInit(manageCommands.ID)
+	statistics, err := ms.statisticsService.GetStatistics(ms.lab.ID, period)
+	if err != nil {
+		log.Println("[ERR][Statistics][GetStatistics]", err)
+		return ms.Error()
 	}
 
-	return ms.Unknown()
-}
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("statistics.title", helper.ReportPeriodLabel(ms.printer, period)))
+	if statistics.Summary.Borrows == 0 && statistics.Summary.Returns == 0 {
+		b.Text(ms.printer.Text("statistics.empty")).Line()
+	} else {
+		b.Raw(helper.BuildStatisticsMessage(ms.printer, b.Mode(), statistics))
+	}
+	b.Line().Italic(ms.printer.Text("statistics.how_to", types.CommandStatistics, types.CommandStatistics))
 
-func (ms *MessageService) manageMenu() error {
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Silahkan pilih menu pengelolaan.",
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{{
-					Text:         "Tambah Barang",
-					CallbackData: fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeAdd),
-				}},
-				{{
-					Text:         "Edit Barang",
-					CallbackData: fmt.Sprintf("/%s %s", types.CommandManage, types.ManageTypeEdit),
-				}},
-			},
-		},
-	})
-}
+	req := b.Request()
+	req.ReplyMarkup = ms.periodKeyboard("/" + types.CommandStatistics)
+	if err := ms.sendMessage(req); err != nil {
+		return err
+	}
 
-func (ms *MessageService) manageAddInit() error {
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_init"], ""); err != nil {
-		log.Println("[ERR][manageAddInit][saveChatSessionDetail]", err)
-		return ms.Error()
+	if statistics.Summary.Borrows == 0 && statistics.Summary.Returns == 0 {
+		return nil
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Memulai Sesi Penambahan Barang\n\nTulis nama barang",
-	})
-}
+	charts := []chart.Chart{}
+	if len(statistics.Utilizations) > 0 {
+		charts = append(charts, helper.BuildUtilizationChart(ms.printer, statistics.Utilizations))
+	}
+	if helper.IsMultiMonthPeriod(period) {
+		charts = append(charts, helper.BuildMonthlyBorrowChart(ms.printer, statistics.Months), helper.BuildMonthlyOverdueChart(ms.printer, statistics.Months))
+	}
 
-func (ms *MessageService) ManageAdd() error {
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["manage_add_init"]:
-			return ms.manageAddName()
-		case types.Topic["manage_add_name"]:
-			return ms.manageAddBrand()
-		case types.Topic["manage_add_brand"]:
-			return ms.manageAddType()
-		case types.Topic["manage_add_type"]:
-			return ms.manageAddWeight()
-		case types.Topic["manage_add_weight"]:
-			return ms.manageAddStock()
-		case types.Topic["manage_add_stock"]:
-			return ms.manageAddInfo()
-		case types.Topic["manage_add_info"]:
-			return ms.manageAddPhoto()
-		case types.Topic["manage_add_photo"]:
-			return ms.manageAddConfirm()
+	for _, c := range charts {
+		if err := ms.sendChart(c, period); err != nil {
+			return err
 		}
 	}
 
-	return ms.Unknown()
+	return nil
 }
 
-func (ms *MessageService) manageAddName() error {
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddName(ms.messageText)
+func (ms *MessageService) Maintenance() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
+	}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_name"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddName][saveChatSessionDetail]", err)
-		return ms.Error()
+	if !ms.can(types.PermissionToolManage) {
+		return ms.permissionDenied()
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Tuliskan merk/brand",
-	})
+	order, ok := helper.GetMaintenanceCommandOrder(ms.messageText)
+	if !ok {
+		return ms.maintenanceOverview()
+	}
+
+	switch order.Type {
+	case types.MaintenanceCommandRecord:
+		return ms.maintenanceInit(order.ToolID)
+	case types.MaintenanceCommandInterval:
+		return ms.maintenanceInterval(order)
+	case types.MaintenanceCommandBroken:
+		return ms.maintenanceStatus(order.ToolID, types.ToolStatusOutOfService)
+	case types.MaintenanceCommandActive:
+		return ms.maintenanceStatus(order.ToolID, types.ToolStatusActive)
+	}
+
+	return ms.maintenanceHistory(order.ToolID)
 }
 
-func (ms *MessageService) manageAddBrand() error {
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddBrand(ms.messageText)
+// maintenanceOverview lists the calibrations due soon and the tools that are
+// out of service.
+func (ms *MessageService) maintenanceOverview() error {
+	dues, err := ms.maintenanceService.GetCalibrationDue(ms.lab.ID, time.Now())
+	if err != nil {
+		log.Println("[ERR][maintenanceOverview][GetCalibrationDue]", err)
+		return ms.Error()
+	}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_brand"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddBrand][saveChatSessionDetail]", err)
+	page, err := ms.toolService.GetTools(types.ToolFilter{Status: types.ToolStatusOutOfService, LabID: ms.lab.ID}, types.Cursor{Limit: types.ToolPageSize})
+	if err != nil {
+		log.Println("[ERR][maintenanceOverview][GetTools]", err)
 		return ms.Error()
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Tuliskan tipe barang/alat",
-	})
-}
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("maintenance.overview.title"))
 
-func (ms *MessageService) manageAddType() error {
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddProductType(ms.messageText)
+	b.Bold(ms.printer.Text("maintenance.overview.calibration", types.CalibrationReminderDays)).Line()
+	if len(dues) == 0 {
+		b.Text(ms.printer.Text("maintenance.overview.no_calibration")).Line()
+	} else {
+		b.Raw(helper.BuildCalibrationDueMessage(ms.printer, b.Mode(), dues))
+	}
+	b.Line()
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_type"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddType][saveChatSessionDetail]", err)
-		return ms.Error()
+	b.Bold(ms.printer.Text("maintenance.overview.out_of_service")).Line()
+	if len(page.Tools) == 0 {
+		b.Text(ms.printer.Text("maintenance.overview.all_active")).Line()
+	}
+	for _, tool := range page.Tools {
+		b.Item(fmt.Sprintf("[%d] %s", tool.ID, tool.Name))
 	}
+	b.Line()
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Berapa berat alat tersebut? (dalam gram)",
-	})
+	b.Italic(ms.printer.Text("maintenance.overview.how_to", types.CommandMaintenance))
+
+	return ms.sendMessage(b.Request())
 }
 
-func (ms *MessageService) manageAddWeight() error {
-	i, err := strconv.ParseFloat(ms.messageText, 10)
-	if err != nil || i < 0 {
-		log.Println("[ERR][manageAddWeight][ParseFloat]", err)
+func (ms *MessageService) maintenanceHistory(toolID int64) error {
+	tool, err := ms.findLabTool(toolID)
+	if err != nil {
+		log.Println("[ERR][maintenanceHistory][FindByID]", err)
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Mohon sebutkan berat dalam angka. Minimal 0.1 gram.",
+			Text: ms.printer.Text("tool.not_available"),
 		})
 	}
 
-	weight := float32(i)
+	maintenances, err := ms.maintenanceService.GetHistory(tool.ID)
+	if err != nil {
+		log.Println("[ERR][maintenanceHistory][GetHistory]", err)
+		return ms.Error()
+	}
+
+	interval := ms.printer.Text("maintenance.history.no_interval")
+	if tool.CalibrationIntervalDays > 0 {
+		interval = ms.printer.Plural("unit.days", tool.CalibrationIntervalDays, tool.CalibrationIntervalDays)
+	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddWeight(weight)
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("maintenance.history.title", tool.Name))
+	b.Field(ms.printer.Text("tool_field.status"), ms.printer.Text(fmt.Sprintf("maintenance.status.%s", tool.Status)))
+	b.Field(ms.printer.Text("maintenance.history.interval"), interval)
+	b.Line()
+	b.Bold(ms.printer.Text("maintenance.history.latest")).Line()
+	if len(maintenances) == 0 {
+		b.Text(ms.printer.Text("maintenance.history.empty"))
+	} else {
+		b.Raw(helper.BuildMaintenanceHistoryMessage(ms.printer, b.Mode(), maintenances))
+	}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_weight"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddWeight][saveChatSessionDetail]", err)
-		return ms.Error()
+	statusButton := types.InlineKeyboardButton{
+		Text:         ms.printer.Text("maintenance.button.broken"),
+		CallbackData: fmt.Sprintf("/%s %d %s", types.CommandMaintenance, tool.ID, types.MaintenanceCommandBroken),
+	}
+	if tool.Status != types.ToolStatusActive {
+		statusButton = types.InlineKeyboardButton{
+			Text:         ms.printer.Text("maintenance.button.active"),
+			CallbackData: fmt.Sprintf("/%s %d %s", types.CommandMaintenance, tool.ID, types.MaintenanceCommandActive),
+		}
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Berapa banyak stok yang tersedia untuk dipinjamkan? Minimal 1",
-	})
+	reqBody := b.Request()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: [][]types.InlineKeyboardButton{
+			{{
+				Text:         ms.printer.Text("maintenance.button.record"),
+				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandMaintenance, tool.ID, types.MaintenanceCommandRecord),
+			}},
+			{{
+				Text:         ms.printer.Text("maintenance.button.interval"),
+				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandMaintenance, tool.ID, types.MaintenanceCommandInterval),
+			}},
+			{statusButton},
+		},
+	}
+
+	return ms.sendMessage(reqBody)
 }
 
-func (ms *MessageService) manageAddStock() error {
-	i, err := strconv.ParseInt(ms.messageText, 10, 64)
-	if err != nil || i < 0 {
-		log.Println("[ERR][manageAddStock][ParseInt]", err)
+func (ms *MessageService) maintenanceInterval(order types.MaintenanceCommandOrder) error {
+	days, ok := helper.ParseCalibrationInterval(order.Text)
+	if !ok {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Mohon sebutkan jumlah stok dalam angka. Minimal 1.",
+			Text: ms.printer.Text("maintenance.interval_how_to", types.CommandMaintenance, order.ToolID, types.MaintenanceCommandInterval),
 		})
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddStock(i)
+	tool, err := ms.findLabTool(order.ToolID)
+	if err != nil {
+		log.Println("[ERR][maintenanceInterval][FindByID]", err)
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
+	}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_stock"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddStock][saveChatSessionDetail]", err)
+	if err := ms.toolService.UpdateCalibrationInterval(tool.ID, days); err != nil {
+		log.Println("[ERR][maintenanceInterval][UpdateCalibrationInterval]", err)
 		return ms.Error()
 	}
+	if err := ms.audit(types.AuditActionUpdate, types.AuditEntityTool, tool.ID,
+		map[string]interface{}{"calibration_interval_days": tool.CalibrationIntervalDays},
+		map[string]interface{}{"calibration_interval_days": days}); err != nil {
+		return ms.auditFailed()
+	}
+
+	message := ms.printer.Text("maintenance.interval_saved", tool.Name, days)
+	if days == 0 {
+		message = ms.printer.Text("maintenance.interval_cleared", tool.Name)
+	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Tuliskan deskripsi lengkap mengenai alat ini",
+		Text: message,
 	})
 }
 
-func (ms *MessageService) manageAddInfo() error {
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddInfo(ms.messageText)
+func (ms *MessageService) maintenanceStatus(toolID int64, status types.ToolStatus) error {
+	tool, err := ms.findLabTool(toolID)
+	if err != nil {
+		log.Println("[ERR][maintenanceStatus][FindByID]", err)
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("tool.not_available"),
+		})
+	}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_info"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddInfo][saveChatSessionDetail]", err)
+	if err := ms.toolService.UpdateStatus(tool.ID, status); err != nil {
+		log.Println("[ERR][maintenanceStatus][UpdateStatus]", err)
 		return ms.Error()
 	}
+	if err := ms.audit(types.AuditActionUpdate, types.AuditEntityTool, tool.ID,
+		map[string]interface{}{"status": tool.Status},
+		map[string]interface{}{"status": status}); err != nil {
+		return ms.auditFailed()
+	}
+
+	message := ms.printer.Text("maintenance.marked_active", tool.Name)
+	if status == types.ToolStatusOutOfService {
+		message = ms.printer.Text("maintenance.marked_broken", tool.Name)
+	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Silahkan upload foto barang. (minimal 1, maksimal 10)",
+		Text: message,
 	})
 }
 
-func (ms *MessageService) manageAddPhoto() error {
-	if len(ms.message.Photo) == 0 {
+func (ms *MessageService) maintenanceInit(toolID int64) error {
+	tool, err := ms.findLabTool(toolID)
+	if err != nil {
+		log.Println("[ERR][maintenanceInit][FindByID]", err)
 		return ms.sendMessage(types.MessageRequest{
-			Text: "File selain foto tidak akan disimpan. Mohon upload foto barang.",
+			Text: ms.printer.Text("tool.not_available"),
 		})
 	}
 
-	pickedPhoto := helper.PickPhoto(ms.message.Photo)
-
 	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddPhoto(ms.message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID)
+	return ms.startConversation(conversation.Transition{
+		Topic: types.Topic["maintenance_init"],
+		Data:  gen.MaintenanceInit(tool.ID),
+	})
+}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_photo"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddPhoto][saveChatSessionDetail]", err)
-		return ms.Error()
+func (ms *MessageService) maintenanceAskType(c *conversation.Context) error {
+	var keyboard [][]types.InlineKeyboardButton
+	for _, maintenanceType := range types.MaintenanceTypes() {
+		keyboard = append(keyboard, []types.InlineKeyboardButton{
+			{
+				Text:         ms.printer.Text(fmt.Sprintf("maintenance.type.%s", maintenanceType)),
+				CallbackData: string(maintenanceType),
+			},
+		})
 	}
 
-	tool := helper.GetToolFromChatSessionDetail(types.ManageTypeAdd, ms.chatSessionDetails)
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("maintenance.ask_type"),
+		ReplyMarkup: types.InlineKeyboardMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+	return nil
+}
+
+func (ms *MessageService) maintenanceAcceptType(c *conversation.Context) (conversation.Transition, error) {
+	if !helper.IsMaintenanceTypeExists(c.Input.Text) {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("maintenance.invalid_type"))
+	}
 
-	message := fmt.Sprintf(`Nama : %s
-		Brand/Merk : %s
-		Tipe Produk : %s
-		Berat : %.2f gram
-		Stok : %d
-		Deskripsi :
-		%s
-	
-		Pastikan data sudah benar kemudian tekan "Lanjutkan".`, tool.Name, tool.Brand, tool.ProductType, tool.Weight, tool.Stock, tool.AdditionalInformation)
-	message = helper.RemoveTab(message)
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["maintenance_type"],
+		Data:  gen.MaintenanceType(types.MaintenanceType(c.Input.Text)),
+	}, nil
+}
 
-	reqBody := types.MessageRequest{
-		Text: message,
+func (ms *MessageService) maintenanceAskDate(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("maintenance.ask_date"),
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Lanjutkan",
-						CallbackData: "yes",
-					},
-					{
-						Text:         "Batalkan",
-						CallbackData: "no",
-					},
-				},
+				{{
+					Text:         ms.printer.Text("maintenance.button.today"),
+					CallbackData: time.Now().Format(helper.MaintenanceDateLayout),
+				}},
 			},
 		},
-	}
-
-	return ms.sendMessage(reqBody)
+	})
+	return nil
 }
 
-func (ms *MessageService) manageAddConfirm() error {
-	if len(ms.message.MediaGroupID) != 0 {
-		pickedPhoto := helper.PickPhoto(ms.message.Photo)
+func (ms *MessageService) maintenanceAcceptDate(c *conversation.Context) (conversation.Transition, error) {
+	date, ok := helper.ParseMaintenanceDate(c.Input.Text, time.Now())
+	if !ok {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("maintenance.invalid_date"))
+	}
 
-		gen := helper.NewSessionDataGenerator()
-		generatedSessionData := gen.ManageAddPhoto(ms.message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID)
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["maintenance_date"],
+		Data:  gen.MaintenanceDate(date.Format(helper.MaintenanceDateLayout)),
+	}, nil
+}
 
-		return ms.saveChatSessionDetail(types.Topic["manage_add_photo"], generatedSessionData)
-	}
+func (ms *MessageService) maintenanceAskTechnician(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("maintenance.ask_technician"),
+	})
+	return nil
+}
 
-	var userResponse bool
-	if ms.messageText == "yes" {
-		userResponse = true
-	} else {
-		userResponse = false
+func (ms *MessageService) maintenanceAcceptTechnician(c *conversation.Context) (conversation.Transition, error) {
+	technician := strings.TrimSpace(c.Input.Text)
+	if len(technician) == 0 {
+		return conversation.Transition{}, conversation.Invalid(ms.printer.Text("maintenance.invalid_technician"))
 	}
 
 	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageAddConfirm(userResponse)
+	return conversation.Transition{
+		Topic: types.Topic["maintenance_technician"],
+		Data:  gen.MaintenanceTechnician(technician),
+	}, nil
+}
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_add_confirm"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageAddConfirm][saveChatSessionDetail]", err)
-		return ms.Error()
-	}
+func (ms *MessageService) maintenanceAskNotes(c *conversation.Context) error {
+	c.Reply(types.MessageRequest{
+		Text: ms.printer.Text("maintenance.ask_notes", types.ToolFieldEmptyValue),
+	})
+	return nil
+}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][manageAddConfirm][UpdateChatSessionStatus]", err)
-		return ms.Error()
+func (ms *MessageService) maintenanceAcceptNotes(c *conversation.Context) (conversation.Transition, error) {
+	notes := strings.TrimSpace(c.Input.Text)
+	if notes == types.ToolFieldEmptyValue {
+		notes = ""
 	}
 
-	if !userResponse {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Penambahan barang dibatalkan.",
-		})
-	}
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["maintenance_notes"],
+		Data:  gen.MaintenanceNotes(notes),
+	}, nil
+}
 
-	tool := helper.GetToolFromChatSessionDetail(types.ManageTypeAdd, ms.chatSessionDetails)
-	photos := helper.GetToolPhotosFromChatSessionDetails(ms.chatSessionDetails)
+func (ms *MessageService) maintenanceSummary(c *conversation.Context) error {
+	maintenance := helper.GetMaintenanceFromChatSessionDetails(c.Details)
 
-	toolID, err := ms.toolService.SaveTool(tool)
+	tool, err := ms.toolService.FindByID(maintenance.ToolID)
 	if err != nil {
-		log.Println("[ERR][manageAddConfirm][SaveTool]", err)
-		return ms.Error()
+		log.Println("[ERR][maintenanceSummary][FindByID]", err)
+		return err
 	}
 
-	if err = ms.toolService.SaveToolPhotos(toolID, photos); err != nil {
-		log.Println("[ERR][manageAddConfirm][SaveToolPhotos]", err)
-		return ms.Error()
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("maintenance.summary.title", tool.Name))
+	b.Field(ms.printer.Text("label.maintenance"), ms.printer.Text(fmt.Sprintf("maintenance.type.%s", maintenance.Type)))
+	b.Field(ms.printer.Text("label.date"), ms.printer.DateString(maintenance.PerformedAt))
+	b.Field(ms.printer.Text("label.technician"), maintenance.Technician)
+	if len(maintenance.Notes) > 0 {
+		b.Block(ms.printer.Text("label.notes"), maintenance.Notes)
 	}
+	b.Line()
+	b.Italic(ms.printer.Text("summary.confirm"))
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: fmt.Sprintf("Barang berhasil ditambah dengan ID %d", toolID),
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Cek Barang",
-						CallbackData: fmt.Sprintf("/%s %d", types.CommandCheck, toolID),
-					},
-				},
-			},
-		},
-	})
+	req := b.Request()
+	req.ReplyMarkup = confirmationKeyboard(ms.printer.Text("button.continue"), ms.printer.Text("button.cancel"))
+	c.Reply(req)
+	return nil
 }
 
-func (ms *MessageService) manageEditInit(toolID int64) error {
-	_, err := ms.toolService.FindByID(toolID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][manageEditInit][FindByID]", err)
-		return ms.Error()
-	}
+func (ms *MessageService) maintenanceAcceptConfirmation(c *conversation.Context) (conversation.Transition, error) {
+	gen := helper.NewSessionDataGenerator()
+	return conversation.Transition{
+		Topic: types.Topic["maintenance_confirm"],
+		Data:  gen.MaintenanceConfirm(isPositiveResponse(c.Input.Text)),
+	}, nil
+}
 
-	if err == sql.ErrNoRows {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "ID barang tidak ditemukan.",
+func (ms *MessageService) maintenanceConfirm(c *conversation.Context) error {
+	if !isPositiveResponse(c.Input.Text) {
+		c.Reply(types.MessageRequest{
+			Text: ms.printer.Text("maintenance.cancelled"),
 		})
+		return nil
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageEditInit(toolID)
+	maintenance := helper.GetMaintenanceFromChatSessionDetails(c.Details)
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_edit_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageEditInit][saveChatSessionDetail]", err)
-		return ms.Error()
+	tool, err := ms.toolService.FindByID(maintenance.ToolID)
+	if err != nil {
+		log.Println("[ERR][maintenanceConfirm][FindByID]", err)
+		return err
 	}
 
-	reqBody := types.MessageRequest{
-		Text: "Memulai Sesi Pengubahan Barang\n\nSilahkan pilih kolom data yang ingin diubah",
+	maintenance.ID, err = ms.maintenanceService.SaveMaintenance(maintenance)
+	if err != nil {
+		log.Println("[ERR][maintenanceConfirm][SaveMaintenance]", err)
+		return err
+	}
+	if err := ms.audit(types.AuditActionCreate, types.AuditEntityMaintenance, maintenance.ID, nil, maintenance); err != nil {
+		return err
+	}
+
+	message := ms.printer.Text("maintenance.saved", tool.Name)
+	if tool.Status != types.ToolStatusActive {
+		message = ms.printer.Text("maintenance.saved_still_broken", tool.Name)
+	}
+
+	c.Reply(types.MessageRequest{
+		Text: message,
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Nama",
-						CallbackData: "nama",
-					},
-					{
-						Text:         "Brand",
-						CallbackData: "brand",
-					},
-					{
-						Text:         "Tipe",
-						CallbackData: "tipe",
-					},
-				},
-				{
-					{
-						Text:         "Berat",
-						CallbackData: "berat",
-					},
-					{
-						Text:         "Stok",
-						CallbackData: "stok",
-					},
-					{
-						Text:         "Foto",
-						CallbackData: "foto",
-					},
-				},
-				{
-					{
-						Text:         "Keterangan",
-						CallbackData: "keterangan",
-					},
-				},
+				{{
+					Text:         ms.printer.Text("maintenance.button.history"),
+					CallbackData: fmt.Sprintf("/%s %d", types.CommandMaintenance, tool.ID),
+				}},
 			},
 		},
+	})
+	return nil
+}
+
+// RemindCalibrationDue tells the admin group of every lab about the
+// calibrations of the lab that are due soon. Nothing is sent to a lab when
+// there is none.
+func RemindCalibrationDue() error {
+	labs, err := NewLabService().GetLabs()
+	if err != nil {
+		log.Println("[ERR][RemindCalibrationDue][GetLabs]", err)
+		return err
 	}
 
-	return ms.sendMessage(reqBody)
-}
+	for _, lab := range labs {
+		if !lab.HasAdminGroup() {
+			continue
+		}
 
-func (ms *MessageService) ManageEdit() error {
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["manage_edit_init"]:
-			return ms.manageEditField()
-		case types.Topic["manage_edit_field"]:
-			return ms.manageEditComplete()
+		if err := remindLabCalibrationDue(lab); err != nil {
+			log.Println("[ERR][RemindCalibrationDue][remindLabCalibrationDue]", err)
 		}
 	}
 
-	return ms.Unknown()
+	return nil
 }
 
-func (ms *MessageService) manageEditField() error {
-	if ok := helper.IsToolFieldExists(ms.messageText); !ok {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "Kolom data tidak tersedia. Silahkan pilih kolom data yang akan diubah melalui pilihan menu.",
-		})
-	}
-
-	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeEdit, ms.chatSessionDetails)
-	tool, err := ms.toolService.FindByID(sessionTool.ID)
+func remindLabCalibrationDue(lab types.Lab) error {
+	ms := NewMessageService(lab.AdminGroupID, 0, "", types.RequestTypeGroup, types.TeleMessage{}, "")
+
+	dues, err := ms.maintenanceService.GetCalibrationDue(lab.ID, time.Now())
 	if err != nil {
-		log.Println("[ERR][manageEditField][FindByID]", err)
-		return ms.Error()
+		log.Println("[ERR][remindLabCalibrationDue][GetCalibrationDue]", err)
+		return err
 	}
 
-	if types.ToolField(ms.messageText) == types.ToolFieldPhoto {
-		return ms.managePhotoInit(tool.ID)
+	if len(dues) == 0 {
+		return nil
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageEditField(ms.messageText)
-
-	if err := ms.saveChatSessionDetail(types.Topic["manage_edit_field"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageEditField][saveChatSessionDetail]", err)
-		return ms.Error()
-	}
+	ms.printer = ms.adminPrinter()
 
-	oldValue := helper.GetToolValueByField(tool, ms.messageText)
-	messsage := fmt.Sprintf("Data sebelumnya:\n%s\n\nSilahkan tulis data baru", oldValue)
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("maintenance.reminder.title"))
+	b.Raw(helper.BuildCalibrationDueMessage(ms.printer, b.Mode(), dues))
+	b.Line()
+	b.Italic(ms.printer.Text("maintenance.overview.how_to", types.CommandMaintenance))
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: messsage,
-	})
+	return ms.sendMessage(b.Request())
 }
 
-func (ms *MessageService) manageEditComplete() error {
-	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeEdit, ms.chatSessionDetails)
-	tool, err := ms.toolService.FindByID(sessionTool.ID)
-	if err != nil {
-		log.Println("[ERR][manageEditField][FindByID]", err)
-		return ms.Error()
+// User lets the admins look up the students, suspend their borrowing and
+// delete them.
+func (ms *MessageService) User() error {
+	if !ms.isEligibleAdmin() {
+		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
+		return ms.Unknown()
 	}
 
-	var choosenField string
-	manageEditSession, found := helper.GetChatSessionDetailByTopic(ms.chatSessionDetails, types.Topic["manage_edit_field"])
-	if found {
-		dataParsed, err := gabs.ParseJSON([]byte(manageEditSession.Data))
-		if err != nil {
-			return err
-		}
-		value, ok := dataParsed.Path("field").Data().(string)
-		if ok {
-			choosenField = value
-		}
+	if !ms.can(types.PermissionUserApprove) && !ms.can(types.PermissionUserManage) {
+		return ms.permissionDenied()
 	}
 
-	updatedTool, err := helper.ChangeToolValueByField(tool, choosenField, ms.messageText)
-	if err != nil {
-		return ms.sendMessage(types.MessageRequest{
-			Text: err.Error(),
-		})
+	order, ok := helper.GetUserCommandOrder(ms.messageText)
+	if !ok {
+		return ms.userOverview()
 	}
 
-	if err := ms.toolService.UpdateTool(updatedTool); err != nil {
-		log.Println("[ERR][manageEditComplete][UpdateTool]", err)
-		return ms.sendMessage(types.MessageRequest{
-			Text: fmt.Sprintf("Terjadi kesalahan. Barang dengan ID %d gagal diubah.", tool.ID),
-		})
+	if order.Type == types.UserCommandSearch {
+		return ms.userSearch(order.Text)
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][manageEditComplete][UpdateChatSessionStatus]", err)
+	user, err := ms.userService.FindByID(order.ID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("[ERR][User][FindByID]", err)
 		return ms.Error()
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: fmt.Sprintf("Barang dengan ID %d berhasil diubah", tool.ID),
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Cek Barang",
-						CallbackData: fmt.Sprintf("/%s %d", types.CommandCheck, tool.ID),
-					},
-				},
-			},
-		},
-	})
-}
-
-func (ms *MessageService) manageDeleteInit(toolID int64) error {
-	tool, err := ms.toolService.FindByID(toolID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][managedDeleteInit][FindByID]", err)
-		return ms.Error()
+	isLabStudent := false
+	if err == nil && user.UserType != types.UserTypeAdmin {
+		isLabStudent, err = ms.userService.IsLabStudent(user.ID, ms.lab.ID)
+		if err != nil {
+			log.Println("[ERR][User][IsLabStudent]", err)
+			return ms.Error()
+		}
 	}
 
-	if err == sql.ErrNoRows {
+	if !isLabStudent {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "ID barang tidak ditemukan.",
+			Text: ms.printer.Text("user.not_found"),
 		})
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageDeleteInit(toolID)
-
-	if err := ms.saveChatSessionDetail(types.Topic["manage_delete_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageDeleteInit][saveChatSessionDetail]", err)
-		return ms.Error()
+	if len(order.Type) > 0 && !ms.can(types.PermissionUserManage) {
+		return ms.permissionDenied()
 	}
 
-	reqBody := types.MessageRequest{
-		Text: fmt.Sprintf("Apakah Anda yakin ingin menghapus %s?", tool.Name),
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{
-					{
-						Text:         "Yakin",
-						CallbackData: "yes",
-					},
-					{
-						Text:         "Batalkan",
-						CallbackData: "no",
-					},
-				},
-			},
-		},
+	switch order.Type {
+	case types.UserCommandSuspend:
+		return ms.userSuspend(user, order.Text)
+	case types.UserCommandUnsuspend:
+		return ms.userUnsuspend(user)
+	case types.UserCommandDelete:
+		return ms.userDelete(user, order.Text)
 	}
 
-	return ms.sendMessage(reqBody)
+	return ms.userDetail(user)
 }
 
-func (ms *MessageService) ManageDelete() error {
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["manage_delete_init"]:
-			return ms.manageDeleteComplete()
-		}
+// userOverview lists the suspended students and how to find the others.
+func (ms *MessageService) userOverview() error {
+	users, err := ms.userService.GetSuspendedUsers(ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][userOverview][GetSuspendedUsers]", err)
+		return ms.Error()
 	}
 
-	return ms.Unknown()
-}
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("user.overview.title"))
 
-func (ms *MessageService) manageDeleteComplete() error {
-	var userResponse bool
-	if ms.messageText == "yes" {
-		userResponse = true
-	} else {
-		userResponse = false
+	b.Bold(ms.printer.Text("user.overview.suspended")).Line()
+	if len(users) == 0 {
+		b.Text(ms.printer.Text("user.overview.none_suspended")).Line()
 	}
+	for _, user := range users {
+		b.Item(fmt.Sprintf("[%d] %s - %s", user.ID, user.Name, user.NIM))
+	}
+	b.Line()
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManageDeleteComplete(userResponse)
+	b.Italic(ms.printer.Text("user.overview.how_to", types.CommandUser, types.UserCommandSearch, types.CommandUser))
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_delete_complete"], generatedSessionData); err != nil {
-		log.Println("[ERR][manageDeleteComplete][saveChatSessionDetail]", err)
-		return ms.Error()
+	return ms.sendMessage(b.Request())
+}
+
+func (ms *MessageService) userSearch(keyword string) error {
+	if len(keyword) == 0 {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("user.search_how_to", types.CommandUser, types.UserCommandSearch),
+		})
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][manageDeleteComplete][UpdateChatSessionStatus]", err)
+	users, err := ms.userService.SearchUsers(keyword, ms.lab.ID)
+	if err != nil {
+		log.Println("[ERR][userSearch][SearchUsers]", err)
 		return ms.Error()
 	}
 
-	if !userResponse {
+	if len(users) == 0 {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Penghapusan barang dibatalkan.",
+			Text: ms.printer.Text("user.search_empty", keyword),
 		})
 	}
 
-	sessionTool := helper.GetToolFromChatSessionDetail(types.ManageTypeDelete, ms.chatSessionDetails)
-
-	if err := ms.toolService.DeleteTool(sessionTool.ID); err != nil {
-		log.Println("[ERR][manageDeleteComplete][DeleteTool]", err)
-		return ms.Error()
-	}
-
 	return ms.sendMessage(types.MessageRequest{
-		Text: fmt.Sprintf("Barang dengan ID %d berhasil dihapus.", sessionTool.ID),
+		Text: ms.printer.Text("user.search_result", keyword, helper.BuildUserListMessage(ms.printer, users), types.CommandUser),
 	})
 }
 
-func (ms *MessageService) managePhotoInit(toolID int64) error {
-	_, err := ms.toolService.FindByID(toolID)
-	if err != nil && err != sql.ErrNoRows {
-		log.Println("[ERR][managePhotoInit][FindByID]", err)
+// userDetail shows the data of the student with the latest borrows and how
+// many were returned late.
+func (ms *MessageService) userDetail(user types.User) error {
+	borrows, err := ms.borrowService.FindByUserID(user.ID)
+	if err != nil {
+		log.Println("[ERR][userDetail][FindByUserID]", err)
 		return ms.Error()
 	}
 
-	if err == sql.ErrNoRows {
-		return ms.sendMessage(types.MessageRequest{
-			Text: "ID barang tidak ditemukan.",
-		})
+	late, err := ms.borrowService.CountLateByUserID(user.ID)
+	if err != nil {
+		log.Println("[ERR][userDetail][CountLateByUserID]", err)
+		return ms.Error()
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManagePhotoInit(toolID)
+	b := format.New(format.MarkdownV2)
+	b.Title(user.Name)
+	b.Field(ms.printer.Text("field.nim"), user.NIM)
+	b.Field(ms.printer.Text("field.batch"), strconv.Itoa(int(user.Batch)))
+	b.Field(ms.printer.Text("field.address"), user.Address)
+	if len(user.Phone) > 0 {
+		b.Field(ms.printer.Text("field.phone"), user.Phone)
+	}
+	b.Field(ms.printer.Text("field.registered_at"), ms.printer.DateString(user.CreatedAt))
+	b.Field(ms.printer.Text("user.detail.status"), ms.printer.Text(fmt.Sprintf("user.status.%s", user.Status)))
+	if user.IsSuspended() {
+		b.Field(ms.printer.Text("user.detail.suspended_at"), ms.printer.Date(user.SuspendedAt.Time))
+		b.Field(ms.printer.Text("field.reason"), user.SuspensionReason)
+	}
+	b.Field(ms.printer.Text("user.detail.borrows"), strconv.Itoa(len(borrows)))
+	b.Field(ms.printer.Text("user.detail.late"), strconv.Itoa(late))
+	b.Line()
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_photo_init"], generatedSessionData); err != nil {
-		log.Println("[ERR][managePhotoInit][saveChatSessionDetail]", err)
-		return ms.Error()
+	b.Bold(ms.printer.Text("user.detail.latest")).Line()
+	if len(borrows) == 0 {
+		b.Text(ms.printer.Text("user.detail.no_borrows"))
+	} else {
+		latest, _, _ := helper.PageBorrows(borrows, 1, types.UserHistoryLimit)
+		b.Raw(helper.BuildBorrowHistoryMessage(ms.printer, b.Mode(), latest))
 	}
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Silahkan upload foto barang. (minimal 1, maksimal 10)",
-	})
-}
+	reqBody := b.Request()
+	if !ms.can(types.PermissionUserManage) {
+		return ms.sendMessage(reqBody)
+	}
 
-func (ms *MessageService) ManagePhoto() error {
-	if len(ms.chatSessionDetails) > 0 {
-		switch ms.chatSessionDetails[0].Topic {
-		case types.Topic["manage_photo_init"]:
-			return ms.managePhotoUpload()
-		case types.Topic["manage_photo_upload"]:
-			return ms.managePhotoConfirm()
+	suspendButton := types.InlineKeyboardButton{
+		Text:         ms.printer.Text("user.button.suspend"),
+		CallbackData: fmt.Sprintf("/%s %d %s", types.CommandUser, user.ID, types.UserCommandSuspend),
+	}
+	if user.IsSuspended() {
+		suspendButton = types.InlineKeyboardButton{
+			Text:         ms.printer.Text("user.button.unsuspend"),
+			CallbackData: fmt.Sprintf("/%s %d %s", types.CommandUser, user.ID, types.UserCommandUnsuspend),
 		}
 	}
 
-	return ms.Unknown()
+	reqBody.ReplyMarkup = types.InlineKeyboardMarkup{
+		InlineKeyboard: [][]types.InlineKeyboardButton{
+			{suspendButton},
+			{{
+				Text:         ms.printer.Text("button.delete"),
+				CallbackData: fmt.Sprintf("/%s %d %s", types.CommandUser, user.ID, types.UserCommandDelete),
+			}},
+		},
+	}
+
+	return ms.sendMessage(reqBody)
 }
 
-func (ms *MessageService) managePhotoUpload() error {
-	if len(ms.message.Photo) == 0 {
+func (ms *MessageService) userSuspend(user types.User, reason string) error {
+	if len(reason) == 0 {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "File selain foto tidak akan disimpan. Mohon upload foto barang.",
+			Text: ms.printer.Text("user.suspend_how_to", user.Name, types.CommandUser, user.ID, types.UserCommandSuspend),
 		})
 	}
 
-	pickedPhoto := helper.PickPhoto(ms.message.Photo)
-
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManagePhotoUpload(ms.message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID)
-
-	if err := ms.saveChatSessionDetail(types.Topic["manage_photo_upload"], generatedSessionData); err != nil {
-		log.Println("[ERR][managePhotoUpload][saveChatSessionDetail]", err)
+	if err := ms.userService.SuspendUser(user.ID, reason); err != nil {
+		log.Println("[ERR][userSuspend][SuspendUser]", err)
 		return ms.Error()
 	}
-
-	reqBody := types.MessageRequest{
-		Text: "Foto berhasil diunggah.",
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{{
-					Text:         "Simpan Perubahan",
-					CallbackData: "yes",
-				}},
-				{{
-					Text:         "Batalkan",
-					CallbackData: "no",
-				}},
-			},
-		},
+	if err := ms.audit(types.AuditActionSuspend, types.AuditEntityUser, user.ID,
+		map[string]interface{}{"suspended": user.IsSuspended(), "suspension_reason": user.SuspensionReason},
+		map[string]interface{}{"suspended": true, "suspension_reason": reason}); err != nil {
+		return ms.auditFailed()
 	}
 
-	return ms.sendMessage(reqBody)
-}
-
-func (ms *MessageService) managePhotoConfirm() error {
-	if len(ms.message.MediaGroupID) != 0 {
-		pickedPhoto := helper.PickPhoto(ms.message.Photo)
+	ms.sendMessage(types.MessageRequest{
+		ChatID: user.ID,
+		Text:   i18n.NewPrinter(user.Language).Text("user.notify_suspended", reason),
+	})
 
-		gen := helper.NewSessionDataGenerator()
-		generatedSessionData := gen.ManagePhotoUpload(ms.message.MediaGroupID, pickedPhoto.FileID, pickedPhoto.FileUniqueID)
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("user.suspended", user.Name),
+	})
+}
 
-		return ms.saveChatSessionDetail(types.Topic["manage_photo_upload"], generatedSessionData)
+func (ms *MessageService) userUnsuspend(user types.User) error {
+	if !user.IsSuspended() {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("user.not_suspended", user.Name),
+		})
 	}
 
-	var userResponse bool
-	if ms.messageText == "yes" {
-		userResponse = true
-	} else {
-		userResponse = false
+	if err := ms.userService.UnsuspendUser(user.ID); err != nil {
+		log.Println("[ERR][userUnsuspend][UnsuspendUser]", err)
+		return ms.Error()
+	}
+	if err := ms.audit(types.AuditActionResume, types.AuditEntityUser, user.ID,
+		map[string]interface{}{"suspended": true, "suspension_reason": user.SuspensionReason},
+		map[string]interface{}{"suspended": false, "suspension_reason": ""}); err != nil {
+		return ms.auditFailed()
 	}
 
-	gen := helper.NewSessionDataGenerator()
-	generatedSessionData := gen.ManagePhotoConfirm(userResponse)
+	ms.sendMessage(types.MessageRequest{
+		ChatID: user.ID,
+		Text:   i18n.NewPrinter(user.Language).Text("user.notify_unsuspended"),
+	})
 
-	if err := ms.saveChatSessionDetail(types.Topic["manage_photo_confirm"], generatedSessionData); err != nil {
-		log.Println("[ERR][managePhotoConfirm][saveChatSessionDetail]", err)
-		return ms.Error()
+	return ms.sendMessage(types.MessageRequest{
+		Text: ms.printer.Text("user.unsuspended", user.Name),
+	})
+}
+
+// userDelete removes a student who has no unfinished borrows, after the admin
+// confirms it.
+func (ms *MessageService) userDelete(user types.User, answer string) error {
+	if user.UserType == types.UserTypeBoth {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("user.delete_admin", user.Name, types.CommandAdmin, types.AdminTypeRevoke),
+		})
 	}
 
-	chatSessionID := ms.chatSessionDetails[0].ChatSessionID
-	if err := ms.chatSessionService.UpdateChatSessionStatus(chatSessionID, types.ChatSessionStatus["complete"]); err != nil {
-		log.Println("[ERR][managePhotoConfirm][UpdateChatSessionStatus]", err)
+	borrows, err := ms.borrowService.GetCurrentlyBeingBorrowedAndRequestedByUserID(user.ID)
+	if err != nil {
+		log.Println("[ERR][userDelete][GetCurrentlyBeingBorrowedAndRequestedByUserID]", err)
 		return ms.Error()
 	}
 
-	if !userResponse {
+	if len(borrows) > 0 {
 		return ms.sendMessage(types.MessageRequest{
-			Text: "Perubahan foto barang dibatalkan.",
+			Text: ms.printer.Text("user.delete_active", user.Name),
 		})
 	}
 
-	tool := helper.GetToolFromChatSessionDetail(types.ManageTypePhoto, ms.chatSessionDetails)
-	photos := helper.GetToolPhotosFromChatSessionDetails(ms.chatSessionDetails)
+	switch answer {
+	case "yes":
+		if err := ms.userService.SoftDeleteUser(user.ID); err != nil {
+			log.Println("[ERR][userDelete][SoftDeleteUser]", err)
+			return ms.Error()
+		}
+		if err := ms.audit(types.AuditActionDelete, types.AuditEntityUser, user.ID, user, nil); err != nil {
+			return ms.auditFailed()
+		}
 
-	if err := ms.toolService.UpdatePhotos(tool.ID, photos); err != nil {
-		log.Println("[ERR][managePhotoConfirm][UpdatePhotos]", err)
-		return ms.Error()
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("user.deleted", user.Name),
+		})
+	case "no":
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.printer.Text("user.delete_cancelled", user.Name),
+		})
 	}
 
 	return ms.sendMessage(types.MessageRequest{
-		Text: "Foto barang berhasil diubah.",
+		Text: ms.printer.Text("user.delete_confirm", user.Name),
 		ReplyMarkup: types.InlineKeyboardMarkup{
 			InlineKeyboard: [][]types.InlineKeyboardButton{
 				{
 					{
-						Text:         "Lihat Foto",
-						CallbackData: fmt.Sprintf("/%s %d %s", types.CommandCheck, tool.ID, types.CheckTypePhoto),
+						Text:         ms.printer.Text("button.sure"),
+						CallbackData: fmt.Sprintf("/%s %d %s yes", types.CommandUser, user.ID, types.UserCommandDelete),
+					},
+					{
+						Text:         ms.printer.Text("button.cancel"),
+						CallbackData: fmt.Sprintf("/%s %d %s no", types.CommandUser, user.ID, types.UserCommandDelete),
 					},
 				},
 			},
@@ -2608,158 +5680,85 @@ func (ms *MessageService) managePhotoConfirm() error {
 	})
 }
 
-func (ms *MessageService) Report() error {
+// Audit shows the latest admin actions of the lab, the history of an entity on
+// "/audit [entitas] [id]" or the actions of an admin on
+// "/audit oleh [@username|id]".
+func (ms *MessageService) Audit() error {
 	if !ms.isEligibleAdmin() {
 		log.Println("[INFO] Not eligible user accessing admin command", ms.messageText)
 		return ms.Unknown()
 	}
 
-	reportCommands, ok := helper.GetReportCommandOrder(ms.messageText)
-	if !ok {
-		return ms.reportMenu()
+	if !ms.can(types.PermissionAuditView) {
+		return ms.permissionDenied()
 	}
 
-	if reportCommands.Type == types.ReportTypeBorrow {
-		return ms.reportBorrow(reportCommands)
-	} else if reportCommands.Type == types.ReportTypeToolReturning {
-		return ms.reportToolReturning(reportCommands)
+	order, ok := helper.GetAuditCommandOrder(ms.messageText)
+	if !ok {
+		return ms.sendMessage(types.MessageRequest{
+			Text: ms.auditUsage(),
+		})
 	}
 
-	return ms.Unknown()
-}
-
-func (ms *MessageService) reportMenu() error {
-	return ms.sendMessage(types.MessageRequest{
-		Text: "Silahkan pilih menu laporan.",
-		ReplyMarkup: types.InlineKeyboardMarkup{
-			InlineKeyboard: [][]types.InlineKeyboardButton{
-				{{
-					Text:         "Peminjaman",
-					CallbackData: fmt.Sprintf("/%s %s", types.CommandReport, types.ReportTypeBorrow),
-				}},
-				{{
-					Text:         "Pengembalian",
-					CallbackData: fmt.Sprintf("/%s %s", types.CommandReport, types.ReportTypeToolReturning),
-				}},
-			},
-		},
-	})
-}
-
-func (ms *MessageService) reportBorrow(commands types.ReportCommandOrder) error {
-	if len(commands.Text) == 0 {
-		message := fmt.Sprintf(`
-			Laporan Peminjaman Bulanan dapat dilihat dengan perintah
-			"/%s %s [tahun]-[bulan]"
-		
-			Contoh, laporan peminjaman pada bulan Agustus tahun 2021
-			"/%s %s 2021-8"		
-		`, types.CommandReport, types.ReportTypeBorrow, types.CommandReport, types.ReportTypeBorrow)
-		message = helper.RemoveTab(message)
+	var entries []types.AuditEntry
+	var err error
+	switch {
+	case len(order.Actor) > 0:
+		id, username, ok := helper.GetAdminTarget(order.Actor)
+		if !ok {
+			return ms.sendMessage(types.MessageRequest{
+				Text: ms.printer.Text("admin.revoke_not_found"),
+			})
+		}
 
-		currentTime := time.Now()
-		currentYear := currentTime.Year()
-		currentMonth := int(currentTime.Month())
+		// an ID is looked up as is, so the actions of a deleted admin can still
+		// be read
+		if len(username) > 0 {
+			user, err := ms.userService.FindByUsername(username)
+			if err != nil && err != sql.ErrNoRows {
+				log.Println("[ERR][Audit][FindByUsername]", err)
+				return ms.Error()
+			}
 
-		return ms.sendMessage(types.MessageRequest{
-			Text: message,
-			ReplyMarkup: types.InlineKeyboardMarkup{
-				InlineKeyboard: [][]types.InlineKeyboardButton{
-					{{
-						Text:         "Laporan Bulan Ini",
-						CallbackData: fmt.Sprintf("/%s %s %d-%d", types.CommandReport, types.ReportTypeBorrow, currentYear, currentMonth),
-					}},
-					{{
-						Text:         "Laporan Bulan Kemarin",
-						CallbackData: fmt.Sprintf("/%s %s %d-%d", types.CommandReport, types.ReportTypeBorrow, currentYear, currentMonth-1),
-					}},
-				},
-			},
-		})
-	}
+			if err == sql.ErrNoRows {
+				return ms.sendMessage(types.MessageRequest{
+					Text: ms.printer.Text("admin.revoke_not_found"),
+				})
+			}
+			id = user.ID
+		}
 
-	year, month, ok := helper.GetReportTimeFromCommand(commands.Text)
-	if !ok {
-		return ms.sendMessage(types.MessageRequest{
-			Text: helper.RemoveTab(fmt.Sprintf(`
-				Mohon isi tahun dan bulan dengan format dan nilai yang sesuai.
-				Contoh: "/%s %s 2021-8"`,
-				types.CommandReport, types.ReportTypeBorrow)),
-		})
+		entries, err = ms.auditService.GetAuditEntriesByActor(ms.lab.ID, id)
+	case order.EntityID > 0:
+		entries, err = ms.auditService.GetAuditEntriesByEntity(ms.lab.ID, order.Entity, order.EntityID)
+	default:
+		entries, err = ms.auditService.GetAuditEntries(ms.lab.ID)
 	}
 
-	borrows, err := ms.borrowService.GetBorrowReport(year, month)
 	if err != nil {
-		log.Println("[ERR][reportBorrow][GetBorrowReport]", err)
+		log.Println("[ERR][Audit][GetAuditEntries]", err)
 		return ms.Error()
 	}
 
-	message := "Tidak ada data peminjaman pada waktu yang dimaksud."
-	if len(borrows) > 0 {
-		message = fmt.Sprintf("Laporan Peminjaman Bulan %s Tahun %d\n\n", helper.MonthNameSwitcher(month), year)
-		message += helper.BuildBorrowReportMessage(borrows)
-	}
-
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
-	})
-}
-
-func (ms *MessageService) reportToolReturning(commands types.ReportCommandOrder) error {
-	if len(commands.Text) == 0 {
-		message := fmt.Sprintf(`
-			Laporan Pengembalian Bulanan dapat dilihat dengan perintah
-			"/%s %s [tahun]-[bulan]"
-
-			Contoh, laporan pengembalian pada bulan Agustus tahun 2021
-			"/%s %s 2021-8"
-		`, types.CommandReport, types.ReportTypeToolReturning, types.CommandReport, types.ReportTypeToolReturning)
-		message = helper.RemoveTab(message)
-
-		currentTime := time.Now()
-		currentYear := currentTime.Year()
-		currentMonth := int(currentTime.Month())
-
-		return ms.sendMessage(types.MessageRequest{
-			Text: message,
-			ReplyMarkup: types.InlineKeyboardMarkup{
-				InlineKeyboard: [][]types.InlineKeyboardButton{
-					{{
-						Text:         "Laporan Bulan Ini",
-						CallbackData: fmt.Sprintf("/%s %s %d-%d", types.CommandReport, types.ReportTypeToolReturning, currentYear, currentMonth),
-					}},
-					{{
-						Text:         "Laporan Bulan Kemarin",
-						CallbackData: fmt.Sprintf("/%s %s %d-%d", types.CommandReport, types.ReportTypeToolReturning, currentYear, currentMonth-1),
-					}},
-				},
-			},
-		})
+	b := format.New(format.MarkdownV2)
+	b.Title(ms.printer.Text("audit.title"))
+	if len(entries) == 0 {
+		b.Text(ms.printer.Text("audit.empty")).Line()
 	}
+	b.Raw(helper.BuildAuditMessage(ms.printer, b.Mode(), entries))
+	b.Line()
 
-	year, month, ok := helper.GetReportTimeFromCommand(commands.Text)
-	if !ok {
-		return ms.sendMessage(types.MessageRequest{
-			Text: helper.RemoveTab(fmt.Sprintf(`
-				Mohon isi tahun dan bulan dengan format dan nilai yang sesuai.
-				Contoh: "/%s %s 2021-8"`,
-				types.CommandReport, types.ReportTypeBorrow)),
-		})
-	}
+	b.Italic(ms.auditUsage())
 
-	toolReturnings, err := ms.toolReturningService.GetToolReturningReport(year, month)
-	if err != nil {
-		log.Println("[ERR][reportToolReturning][GetToolReturningReport]", err)
-		return ms.Error()
-	}
+	return ms.sendMessage(b.Request())
+}
 
-	message := "Tidak ada data pengembalian pada waktu yang dimaksud."
-	if len(toolReturnings) > 0 {
-		message = fmt.Sprintf("Laporan Pengembalian Bulan %s Tahun %d\n\n", helper.MonthNameSwitcher(month), year)
-		message += helper.BuildToolReturningReportMessage(toolReturnings)
+func (ms *MessageService) auditUsage() string {
+	entities := []string{}
+	for name := range types.AuditEntityCommands {
+		entities = append(entities, name)
 	}
+	sort.Strings(entities)
 
-	return ms.sendMessage(types.MessageRequest{
-		Text: message,
-	})
+	return ms.printer.Text("audit.usage", types.CommandAudit, types.CommandAudit, types.AuditCommandActor, strings.Join(entities, ", "))
 }
@@ -1,11 +1,11 @@
 package service
 
 import (
-	"fmt"
-	"strings"
 	"testing"
 	"time"
 
+	"github.com/fannyhasbi/lab-tools-lending/conversation"
+	"github.com/fannyhasbi/lab-tools-lending/i18n"
 	"github.com/fannyhasbi/lab-tools-lending/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,7 +20,7 @@ func TestCanChangeChatSessionDetails(t *testing.T) {
 		},
 		{
 			ID:    2,
-			Topic: types.Topic["register_confirm"],
+			Topic: types.Topic["register_name"],
 		},
 	}
 
@@ -41,53 +41,71 @@ func TestCanChangeChatSessionDetails(t *testing.T) {
 	assert.Equal(t, secondDetail, ms.chatSessionDetails)
 }
 
-func TestGetRegistrationMessage(t *testing.T) {
-	n := "testname"
-	nim := "211XXXXXXXXXXXX"
-	b := 2016
-	a := "jalan test message"
-
-	t.Run("can get message", func(t *testing.T) {
-		message := fmt.Sprintf(`%s
-			%s
-			%d
-			%s`, n, nim, b, a)
-		message = strings.Replace(message, "\t", "", -1)
-
-		r, err := getRegistrationMessage(message)
-
-		expect := types.QuestionRegistration{
-			Name:    n,
-			NIM:     nim,
-			Batch:   b,
-			Address: a,
+func TestValidateRegisterNIMBatch(t *testing.T) {
+	t.Run("valid and no error", func(t *testing.T) {
+		assert.NoError(t, validateRegisterNIMBatch("21120116130000", 2016))
+	})
+
+	t.Run("error invalid NIM", func(t *testing.T) {
+		assert.Equal(t, errRegistrationNIM, validateRegisterNIMBatch("2112", 2016))
+	})
+
+	t.Run("error year not matching the batch", func(t *testing.T) {
+		assert.Equal(t, errRegistrationNIMBatch, validateRegisterNIMBatch("21120116130000", 2017))
+	})
+}
+
+func TestRegisterAcceptPhone(t *testing.T) {
+	ms := &MessageService{
+		user:    types.User{ID: 123},
+		printer: i18n.NewPrinter(types.LanguageIndonesian),
+	}
+
+	t.Run("own contact", func(t *testing.T) {
+		c := &conversation.Context{
+			Input: conversation.Input{
+				Message: types.TeleMessage{
+					Contact: &types.TeleContact{PhoneNumber: "+6281234567890", UserID: 123},
+				},
+			},
 		}
 
+		tr, err := ms.registerAcceptPhone(c)
 		assert.NoError(t, err)
-		assert.Equal(t, expect, r)
+		assert.Equal(t, types.Topic["register_phone"], tr.Topic)
+		assert.Contains(t, tr.Data, "+6281234567890")
 	})
 
-	t.Run("error invalid format", func(t *testing.T) {
-		message := `hello`
-		r, err := getRegistrationMessage(message)
-		expect := types.QuestionRegistration{}
+	t.Run("contact of someone else", func(t *testing.T) {
+		c := &conversation.Context{
+			Input: conversation.Input{
+				Message: types.TeleMessage{
+					Contact: &types.TeleContact{PhoneNumber: "+6281234567890", UserID: 456},
+				},
+			},
+		}
 
-		assert.Error(t, err)
-		assert.Equal(t, expect, r)
+		_, err := ms.registerAcceptPhone(c)
+		assert.Equal(t, conversation.Invalid("nomor telepon yang dibagikan harus milik akun Telegram Anda sendiri"), err)
 	})
 
-	t.Run("error batch int conversion", func(t *testing.T) {
-		message := fmt.Sprintf(`%s
-			%s
-			thisiswrongintformat
-			%s`, n, nim, a)
-		message = strings.Replace(message, "\t", "", -1)
+	t.Run("skipped", func(t *testing.T) {
+		c := &conversation.Context{
+			Input: conversation.Input{Text: "lewati"},
+		}
+
+		tr, err := ms.registerAcceptPhone(c)
+		assert.NoError(t, err)
+		assert.Equal(t, types.Topic["register_phone"], tr.Topic)
+	})
 
-		r, err := getRegistrationMessage(message)
-		expect := types.QuestionRegistration{}
+	t.Run("typed text", func(t *testing.T) {
+		c := &conversation.Context{
+			Input: conversation.Input{Text: "081234567890"},
+		}
 
+		_, err := ms.registerAcceptPhone(c)
 		assert.Error(t, err)
-		assert.Equal(t, expect, r)
 	})
 }
 
@@ -117,7 +135,7 @@ func TestValidateRegisterMessageBatch(t *testing.T) {
 
 func TestValidateRegisterConfirmation(t *testing.T) {
 	testname := "testname"
-	testnim := "2112xxxxxxxxxx"
+	testnim := "21120116130000"
 	testbatch := 2016
 	testaddress := "jalan test message"
 
@@ -142,7 +160,7 @@ func TestValidateRegisterConfirmation(t *testing.T) {
 		}
 
 		err := validateRegisterConfirmation(r)
-		assert.Error(t, err)
+		assert.Equal(t, errRegistrationBatchRange, err)
 	})
 
 	t.Run("invalid name length", func(t *testing.T) {
@@ -154,7 +172,7 @@ func TestValidateRegisterConfirmation(t *testing.T) {
 		}
 
 		err := validateRegisterConfirmation(r)
-		assert.Error(t, err)
+		assert.Equal(t, errRegistrationNameTooShort, err)
 	})
 
 	t.Run("invalid NIM length", func(t *testing.T) {
@@ -173,8 +191,8 @@ func TestValidateRegisterConfirmation(t *testing.T) {
 
 		err := validateRegisterConfirmation(r)
 		err2 := validateRegisterConfirmation(r2)
-		assert.Error(t, err)
-		assert.Error(t, err2)
+		assert.Equal(t, errRegistrationNIM, err)
+		assert.Equal(t, errRegistrationNIM, err2)
 	})
 
 	t.Run("invalid address length", func(t *testing.T) {
@@ -186,7 +204,81 @@ func TestValidateRegisterConfirmation(t *testing.T) {
 		}
 
 		err := validateRegisterConfirmation(r)
-		assert.Error(t, err)
+		assert.Equal(t, errRegistrationAddressLength, err)
+	})
+
+	t.Run("NIM not matching a pattern", func(t *testing.T) {
+		r := types.QuestionRegistration{
+			Name:    testname,
+			NIM:     "2112xxxxxxxxxx",
+			Batch:   testbatch,
+			Address: testaddress,
+		}
+
+		err := validateRegisterConfirmation(r)
+		assert.Equal(t, errRegistrationNIM, err)
+	})
+
+	t.Run("NIM year not matching the batch", func(t *testing.T) {
+		r := types.QuestionRegistration{
+			Name:    testname,
+			NIM:     testnim,
+			Batch:   2017,
+			Address: testaddress,
+		}
+
+		err := validateRegisterConfirmation(r)
+		assert.Equal(t, errRegistrationNIMBatch, err)
+	})
+}
+
+func TestChangeProfile(t *testing.T) {
+	ms := &MessageService{}
+	user := types.User{
+		ID:      1,
+		Name:    "testname",
+		NIM:     "21120116130000",
+		Batch:   2016,
+		Address: "jalan test message",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		r, err := ms.changeProfile(user, string(types.UserFieldAddress), "  jalan baru  ")
+		assert.NoError(t, err)
+		assert.Equal(t, "jalan baru", r.Address)
+		assert.Equal(t, user.Name, r.Name)
+	})
+
+	t.Run("batch not a number", func(t *testing.T) {
+		_, err := ms.changeProfile(user, string(types.UserFieldBatch), "abc")
+		assert.Equal(t, errRegistrationBatch, err)
+	})
+
+	t.Run("validated like the registration", func(t *testing.T) {
+		_, err := ms.changeProfile(user, string(types.UserFieldBatch), "2007")
+		assert.Equal(t, errRegistrationBatchRange, err)
+
+		_, err = ms.changeProfile(user, string(types.UserFieldNIM), "123")
+		assert.Equal(t, errRegistrationNIM, err)
+
+		_, err = ms.changeProfile(user, string(types.UserFieldBatch), "2017")
+		assert.Equal(t, errRegistrationNIMBatch, err)
+	})
+}
+
+func TestRegistrationErrorMessage(t *testing.T) {
+	t.Run("indonesian", func(t *testing.T) {
+		p := i18n.NewPrinter(types.LanguageIndonesian)
+
+		assert.Equal(t, "data nama minimal 4 karakter", registrationErrorMessage(p, errRegistrationNameTooShort))
+		assert.Equal(t, "NIM tidak valid", registrationErrorMessage(p, errRegistrationNIM))
+	})
+
+	t.Run("english", func(t *testing.T) {
+		p := i18n.NewPrinter(types.LanguageEnglish)
+
+		assert.Equal(t, "the address must be at least 5 characters", registrationErrorMessage(p, errRegistrationAddressLength))
+		assert.Equal(t, "the year in the NIM does not match the batch", registrationErrorMessage(p, errRegistrationNIMBatch))
 	})
 }
 
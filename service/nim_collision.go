@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+// NIMCollisionService keeps the registrations whose NIM is already used by
+// another account until an admin reviews them.
+type NIMCollisionService struct {
+	Query      repository.NIMCollisionQuery
+	Repository repository.NIMCollisionRepository
+}
+
+func NewNIMCollisionService() *NIMCollisionService {
+	var nimCollisionQuery repository.NIMCollisionQuery
+	var nimCollisionRepository repository.NIMCollisionRepository
+
+	db := config.InitPostgresDB()
+	nimCollisionQuery = postgres.NewNIMCollisionQueryPostgres(db)
+	nimCollisionRepository = postgres.NewNIMCollisionRepositoryPostgres(db)
+
+	return &NIMCollisionService{
+		Query:      nimCollisionQuery,
+		Repository: nimCollisionRepository,
+	}
+}
+
+func (cs NIMCollisionService) SaveNIMCollision(collision types.NIMCollision) (int64, error) {
+	return cs.Repository.Save(&collision)
+}
+
+func (cs NIMCollisionService) FindByID(id int64) (types.NIMCollision, error) {
+	result := cs.Query.FindByID(id)
+	if result.Error != nil {
+		return types.NIMCollision{}, result.Error
+	}
+
+	return result.Result.(types.NIMCollision), nil
+}
+
+func (cs NIMCollisionService) GetPendingNIMCollisions() ([]types.NIMCollision, error) {
+	result := cs.Query.GetPending()
+	if result.Error != nil {
+		return []types.NIMCollision{}, result.Error
+	}
+
+	return result.Result.([]types.NIMCollision), nil
+}
+
+func (cs NIMCollisionService) FindPendingByUserID(userID int64) (types.NIMCollision, error) {
+	result := cs.Query.FindPendingByUserID(userID)
+	if result.Error != nil {
+		return types.NIMCollision{}, result.Error
+	}
+
+	return result.Result.(types.NIMCollision), nil
+}
+
+func (cs NIMCollisionService) ApproveNIMCollision(id, userID int64, firstName, lastName string) error {
+	return cs.Repository.Approve(id, userID, resolverName(firstName, lastName))
+}
+
+func (cs NIMCollisionService) RejectNIMCollision(id, userID int64, firstName, lastName string) error {
+	return cs.Repository.Reject(id, userID, resolverName(firstName, lastName))
+}
+
+func resolverName(firstName, lastName string) string {
+	if len(lastName) > 0 {
+		return fmt.Sprintf("%s %s", firstName, lastName)
+	}
+	return firstName
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type StatisticsService struct {
+	Query repository.StatisticsQuery
+}
+
+func NewStatisticsService() *StatisticsService {
+	var statisticsQuery repository.StatisticsQuery
+
+	db := config.InitPostgresDB()
+	statisticsQuery = postgres.NewStatisticsQueryPostgres(db)
+
+	return &StatisticsService{
+		Query: statisticsQuery,
+	}
+}
+
+// GetStatistics gathers every aggregate shown on the dashboard of the lab for
+// the period.
+func (ss StatisticsService) GetStatistics(labID int64, period types.ReportPeriod) (types.Statistics, error) {
+	statistics := types.Statistics{Period: period}
+	var err error
+
+	result := ss.Query.GetUsageSummary(labID, period.From, period.To)
+	if result.Error != nil {
+		return statistics, result.Error
+	}
+	statistics.Summary = result.Result.(types.UsageSummary)
+
+	result = ss.Query.GetToolUtilization(labID, period.From, period.To, types.StatisticsUtilizationLimit)
+	if result.Error != nil {
+		return statistics, result.Error
+	}
+	statistics.Utilizations = result.Result.([]types.ToolUtilization)
+
+	result = ss.Query.GetTopBorrowedTools(labID, period.From, period.To, types.StatisticsTopToolsLimit)
+	if result.Error != nil {
+		return statistics, result.Error
+	}
+	statistics.TopTools = result.Result.([]types.ToolBorrowCount)
+
+	result = ss.Query.GetBatchUsage(labID, period.From, period.To)
+	if result.Error != nil {
+		return statistics, result.Error
+	}
+	statistics.Batches = result.Result.([]types.BatchUsage)
+
+	statistics.Months, err = ss.GetMonthlyUsage(labID, period)
+	if err != nil {
+		return statistics, err
+	}
+
+	return statistics, nil
+}
+
+// GetMonthlyUsage counts the borrows of every month in the period, including
+// the months without any.
+func (ss StatisticsService) GetMonthlyUsage(labID int64, period types.ReportPeriod) ([]types.MonthlyUsage, error) {
+	result := ss.Query.GetMonthlyUsage(labID, period.From, period.To)
+	if result.Error != nil {
+		return []types.MonthlyUsage{}, result.Error
+	}
+
+	return helper.FillMonthlyUsage(period, result.Result.([]types.MonthlyUsage)), nil
+}
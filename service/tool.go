@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"time"
 
 	"github.com/fannyhasbi/lab-tools-lending/config"
@@ -67,6 +68,14 @@ func (ts ToolService) DecreaseStock(id int64, amount int) error {
 	return ts.Repository.DecreaseStock(id, amount)
 }
 
+func (ts ToolService) UpdateStatus(id int64, status types.ToolStatus) error {
+	return ts.Repository.UpdateStatus(id, status)
+}
+
+func (ts ToolService) UpdateCalibrationInterval(id int64, days int) error {
+	return ts.Repository.UpdateCalibrationInterval(id, days)
+}
+
 func (ts ToolService) FindByID(id int64) (types.Tool, error) {
 	result := ts.Query.FindByID(id)
 
@@ -77,8 +86,30 @@ func (ts ToolService) FindByID(id int64) (types.Tool, error) {
 	return result.Result.(types.Tool), nil
 }
 
-func (ts ToolService) GetTools() ([]types.Tool, error) {
-	result := ts.Query.Get()
+func (ts ToolService) GetTools(filter types.ToolFilter, cursor types.Cursor) (types.ToolPage, error) {
+	result := ts.Query.Get(filter, cursor)
+	if result.Error != nil {
+		return types.ToolPage{}, result.Error
+	}
+
+	return result.Result.(types.ToolPage), nil
+}
+
+func (ts ToolService) GetAvailableTools(filter types.ToolFilter, cursor types.Cursor) (types.ToolPage, error) {
+	result := ts.Query.GetAvailableTools(filter, cursor)
+
+	if result.Error != nil {
+		return types.ToolPage{}, result.Error
+	}
+
+	return result.Result.(types.ToolPage), nil
+}
+
+// SearchTools searches the tools of the lab, or of every lab when labID is
+// zero.
+func (ts ToolService) SearchTools(keyword string, labID int64, onlyAvailable bool) ([]types.Tool, error) {
+	result := ts.Query.Search(strings.ToLower(keyword), labID, onlyAvailable, types.ToolSearchLimit)
+
 	if result.Error != nil {
 		return []types.Tool{}, result.Error
 	}
@@ -86,8 +117,8 @@ func (ts ToolService) GetTools() ([]types.Tool, error) {
 	return result.Result.([]types.Tool), nil
 }
 
-func (ts ToolService) GetAvailableTools() ([]types.Tool, error) {
-	result := ts.Query.GetAvailableTools()
+func (ts ToolService) GetLowStock(labID int64) ([]types.Tool, error) {
+	result := ts.Query.GetLowStock(labID)
 
 	if result.Error != nil {
 		return []types.Tool{}, result.Error
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/fannyhasbi/lab-tools-lending/config"
+	"github.com/fannyhasbi/lab-tools-lending/helper"
+	"github.com/fannyhasbi/lab-tools-lending/repository"
+	"github.com/fannyhasbi/lab-tools-lending/repository/postgres"
+	"github.com/fannyhasbi/lab-tools-lending/types"
+)
+
+type ToolCategoryService struct {
+	Query      repository.ToolCategoryQuery
+	Repository repository.ToolCategoryRepository
+}
+
+func NewToolCategoryService() *ToolCategoryService {
+	var toolCategoryQuery repository.ToolCategoryQuery
+	var toolCategoryRepository repository.ToolCategoryRepository
+
+	db := config.InitPostgresDB()
+	toolCategoryQuery = postgres.NewToolCategoryQueryPostgres(db)
+	toolCategoryRepository = postgres.NewToolCategoryRepositoryPostgres(db)
+
+	return &ToolCategoryService{
+		Query:      toolCategoryQuery,
+		Repository: toolCategoryRepository,
+	}
+}
+
+func (cs ToolCategoryService) FindByID(id int64) (types.ToolCategory, error) {
+	result := cs.Query.FindByID(id)
+	if result.Error != nil {
+		return types.ToolCategory{}, result.Error
+	}
+
+	return result.Result.(types.ToolCategory), nil
+}
+
+// GetChildren returns the categories directly under the parent, or the top
+// level categories when parentID is zero.
+func (cs ToolCategoryService) GetChildren(parentID int64) ([]types.ToolCategory, error) {
+	result := cs.Query.GetChildren(parentID)
+	if result.Error != nil {
+		return []types.ToolCategory{}, result.Error
+	}
+
+	return result.Result.([]types.ToolCategory), nil
+}
+
+// GetPath returns the category and its ancestors, the top level category first.
+func (cs ToolCategoryService) GetPath(id int64) ([]types.ToolCategory, error) {
+	result := cs.Query.GetPath(id)
+	if result.Error != nil {
+		return []types.ToolCategory{}, result.Error
+	}
+
+	return result.Result.([]types.ToolCategory), nil
+}
+
+// FindByPath finds the category of a path such as "Elektronika/Alat Ukur". It
+// returns sql.ErrNoRows when a category of the path does not exist.
+func (cs ToolCategoryService) FindByPath(path string) (types.ToolCategory, error) {
+	names := helper.ParseToolCategoryPath(path)
+	if len(names) == 0 {
+		return types.ToolCategory{}, sql.ErrNoRows
+	}
+
+	category := types.ToolCategory{}
+	for _, name := range names {
+		result := cs.Query.FindByName(category.ID, name)
+		if result.Error != nil {
+			return types.ToolCategory{}, result.Error
+		}
+		category = result.Result.(types.ToolCategory)
+	}
+
+	return category, nil
+}
+
+// SavePath creates the categories of a path that do not exist yet and returns
+// the last one.
+func (cs ToolCategoryService) SavePath(path string) (types.ToolCategory, error) {
+	names := helper.ParseToolCategoryPath(path)
+	if len(names) == 0 {
+		return types.ToolCategory{}, sql.ErrNoRows
+	}
+
+	category := types.ToolCategory{}
+	for _, name := range names {
+		result := cs.Query.FindByName(category.ID, name)
+		if result.Error == nil {
+			category = result.Result.(types.ToolCategory)
+			continue
+		}
+
+		if result.Error != sql.ErrNoRows {
+			return types.ToolCategory{}, result.Error
+		}
+
+		child := types.ToolCategory{ParentID: category.ID, Name: name}
+		id, err := cs.Repository.Save(&child)
+		if err != nil {
+			return types.ToolCategory{}, err
+		}
+
+		child.ID = id
+		category = child
+	}
+
+	return category, nil
+}
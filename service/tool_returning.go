@@ -1,7 +1,6 @@
 package service
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/fannyhasbi/lab-tools-lending/config"
@@ -42,13 +41,10 @@ func (trs ToolReturningService) UpdateToolReturningStatus(id int64, status types
 	return trs.Repository.UpdateStatus(id, status)
 }
 
-func (trs ToolReturningService) UpdateToolReturningConfirm(id int64, datetime time.Time, firstName, lastName string) error {
-	confirmedBy := firstName
-	if len(lastName) > 0 {
-		confirmedBy = fmt.Sprintf("%s %s", firstName, lastName)
-	}
-
-	return trs.Repository.UpdateConfirm(id, datetime, confirmedBy)
+// UpdateToolReturningConfirm records the admin who responded to the returning
+// and when, see BorrowService.UpdateBorrowConfirm.
+func (trs ToolReturningService) UpdateToolReturningConfirm(id int64, datetime time.Time, userID int64, firstName, lastName string) error {
+	return trs.Repository.UpdateConfirm(id, datetime, userID, resolverName(firstName, lastName))
 }
 
 func (trs ToolReturningService) FindToolReturningByID(id int64) (types.ToolReturning, error) {
@@ -78,8 +74,8 @@ func (trs ToolReturningService) GetCurrentlyBeingRequested(userID, borrowID int6
 	return rets, nil
 }
 
-func (trs ToolReturningService) GetToolReturningRequests() ([]types.ToolReturning, error) {
-	result := trs.Query.GetByStatus(types.GetToolReturningStatus("request"))
+func (trs ToolReturningService) GetToolReturningRequests(labID int64) ([]types.ToolReturning, error) {
+	result := trs.Query.GetByStatus(labID, types.GetToolReturningStatus("request"))
 	if result.Error != nil {
 		return []types.ToolReturning{}, result.Error
 	}
@@ -87,8 +83,8 @@ func (trs ToolReturningService) GetToolReturningRequests() ([]types.ToolReturnin
 	return result.Result.([]types.ToolReturning), nil
 }
 
-func (trs ToolReturningService) GetToolReturningReport(year, month int) ([]types.ToolReturning, error) {
-	result := trs.Query.GetReport(year, month)
+func (trs ToolReturningService) GetToolReturningReport(labID int64, period types.ReportPeriod) ([]types.ToolReturning, error) {
+	result := trs.Query.GetReport(labID, period.From, period.To)
 	if result.Error != nil {
 		return []types.ToolReturning{}, result.Error
 	}
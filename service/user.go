@@ -29,6 +29,14 @@ func NewUserService() *UserService {
 }
 
 func (us UserService) SaveUser(user types.User) (types.User, error) {
+	if len(user.Language) == 0 {
+		user.Language = types.DefaultLanguage
+	}
+
+	if len(user.Status) == 0 {
+		user.Status = types.UserStatusPending
+	}
+
 	result, err := us.Repository.Save(&user)
 	if err != nil {
 		return types.User{}, err
@@ -54,6 +62,14 @@ func (us UserService) UpdateUserType(id int64, userType types.UserType) error {
 	return us.Repository.UpdateUserType(id, userType)
 }
 
+func (us UserService) UpdateLanguage(id int64, language types.Language) error {
+	return us.Repository.UpdateLanguage(id, language)
+}
+
+func (us UserService) UpdateUserStatus(id int64, status types.UserStatus) error {
+	return us.Repository.UpdateStatus(id, status)
+}
+
 func (us UserService) FindByID(id int64) (types.User, error) {
 	result := us.Query.FindByID(id)
 	if result.Error == sql.ErrNoRows {
@@ -66,3 +82,106 @@ func (us UserService) FindByID(id int64) (types.User, error) {
 
 	return result.Result.(types.User), nil
 }
+
+// FindByNIM returns the account registered with the NIM.
+func (us UserService) FindByNIM(nim string) (types.User, error) {
+	result := us.Query.FindByNIM(nim)
+	if result.Error != nil {
+		return types.User{}, result.Error
+	}
+
+	return result.Result.(types.User), nil
+}
+
+// GetPendingRegistrations returns the students waiting for their registration
+// to be approved.
+func (us UserService) GetPendingRegistrations() ([]types.User, error) {
+	result := us.Query.GetPendingRegistrations()
+	if result.Error != nil {
+		return []types.User{}, result.Error
+	}
+
+	return result.Result.([]types.User), nil
+}
+
+// GetAdmins returns the users who can respond to the requests of the lab.
+func (us UserService) GetAdmins(labID int64) ([]types.User, error) {
+	result := us.Query.GetAdmins(labID)
+	if result.Error != nil {
+		return []types.User{}, result.Error
+	}
+
+	return result.Result.([]types.User), nil
+}
+
+func (us UserService) FindByUsername(username string) (types.User, error) {
+	result := us.Query.FindByUsername(username)
+	if result.Error != nil {
+		return types.User{}, result.Error
+	}
+
+	return result.Result.(types.User), nil
+}
+
+func (us UserService) UpdateUsername(id int64, username string) error {
+	return us.Repository.UpdateUsername(id, username)
+}
+
+func (us UserService) UpdateRole(id int64, role types.Role) error {
+	return us.Repository.UpdateRole(id, role)
+}
+
+func (us UserService) UpdateAdminLab(id, labID int64) error {
+	return us.Repository.UpdateAdminLab(id, labID)
+}
+
+func (us UserService) UpdateLab(id, labID int64) error {
+	return us.Repository.UpdateLab(id, labID)
+}
+
+func (us UserService) DeleteUserWithChatSessions(id int64) error {
+	return us.Repository.DeleteWithChatSessions(id)
+}
+
+// SoftDeleteUser removes the student but keeps the borrowing history.
+func (us UserService) SoftDeleteUser(id int64) error {
+	return us.Repository.SoftDelete(id)
+}
+
+// SearchUsers returns the students of the lab matching the name, NIM or batch.
+func (us UserService) SearchUsers(keyword string, labID int64) ([]types.User, error) {
+	result := us.Query.Search(keyword, labID, types.UserSearchLimit)
+	if result.Error != nil {
+		return []types.User{}, result.Error
+	}
+
+	return result.Result.([]types.User), nil
+}
+
+// GetSuspendedUsers returns the students of the lab who may not borrow.
+func (us UserService) GetSuspendedUsers(labID int64) ([]types.User, error) {
+	result := us.Query.GetSuspended(labID)
+	if result.Error != nil {
+		return []types.User{}, result.Error
+	}
+
+	return result.Result.([]types.User), nil
+}
+
+// IsLabStudent tells whether the user borrows from the lab or did before.
+func (us UserService) IsLabStudent(id, labID int64) (bool, error) {
+	result := us.Query.IsLabStudent(id, labID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.Result.(bool), nil
+}
+
+func (us UserService) SuspendUser(id int64, reason string) error {
+	return us.Repository.Suspend(id, reason)
+}
+
+func (us UserService) UnsuspendUser(id int64) error {
+	return us.Repository.Unsuspend(id)
+}
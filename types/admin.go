@@ -0,0 +1,50 @@
+package types
+
+import (
+	"database/sql"
+	"time"
+)
+
+type (
+	// AdminInvitation is a single-use code an admin gives to someone joining
+	// the admins, see AdminInvitationValidity.
+	AdminInvitation struct {
+		ID        int64         `json:"id"`
+		Code      string        `json:"code"`
+		LabID     int64         `json:"lab_id"`
+		CreatedBy int64         `json:"created_by"`
+		CreatedAt string        `json:"created_at"`
+		ExpiresAt time.Time     `json:"expires_at"`
+		UsedBy    sql.NullInt64 `json:"used_by"`
+		UsedAt    sql.NullTime  `json:"used_at"`
+	}
+
+	AdminRequestStatus string
+
+	// AdminRequest is someone in the admin group asking to become an admin
+	// without an invitation code, waiting for another admin to approve it.
+	AdminRequest struct {
+		ID         int64              `json:"id"`
+		UserID     int64              `json:"user_id"`
+		LabID      int64              `json:"lab_id"`
+		Name       string             `json:"name"`
+		Username   string             `json:"username"`
+		Language   Language           `json:"language"`
+		Status     AdminRequestStatus `json:"status"`
+		CreatedAt  string             `json:"created_at"`
+		ResolvedAt sql.NullTime       `json:"resolved_at"`
+		ResolvedBy sql.NullString     `json:"resolved_by"`
+		// ResolvedByUserID is the admin who approved or rejected the request,
+		// zero while it is pending or once the admin has been deleted.
+		ResolvedByUserID int64 `json:"resolved_by_user_id"`
+	}
+)
+
+// AdminInvitationValidity is how long an invitation code can be used.
+const AdminInvitationValidity = 24 * time.Hour
+
+const (
+	AdminRequestStatusPending  AdminRequestStatus = "PENDING"
+	AdminRequestStatusApproved AdminRequestStatus = "APPROVED"
+	AdminRequestStatusRejected AdminRequestStatus = "REJECTED"
+)
@@ -0,0 +1,78 @@
+package types
+
+import "database/sql"
+
+type (
+	AuditAction string
+	AuditEntity string
+
+	// AuditEntry records an action of an admin on an entity. Before and After
+	// hold the entity as JSON, and are null when it did not exist before or no
+	// longer exists after the action.
+	AuditEntry struct {
+		ID          int64          `json:"id"`
+		LabID       int64          `json:"lab_id"`
+		ActorUserID int64          `json:"actor_user_id"`
+		ActorName   string         `json:"actor_name"`
+		Action      AuditAction    `json:"action"`
+		Entity      AuditEntity    `json:"entity"`
+		EntityID    int64          `json:"entity_id"`
+		Before      sql.NullString `json:"before"`
+		After       sql.NullString `json:"after"`
+		CreatedAt   string         `json:"created_at"`
+	}
+
+	// AuditCommandOrder is "/audit [entitas] [id]" or "/audit oleh [@username|id]".
+	AuditCommandOrder struct {
+		Entity   AuditEntity
+		EntityID int64
+		Actor    string
+	}
+)
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionApprove AuditAction = "approve"
+	AuditActionReject  AuditAction = "reject"
+	AuditActionSuspend AuditAction = "suspend"
+	AuditActionResume  AuditAction = "resume"
+	AuditActionRevoke  AuditAction = "revoke"
+	AuditActionRole    AuditAction = "role"
+	AuditActionClaim   AuditAction = "claim"
+
+	AuditEntityTool          AuditEntity = "tool"
+	AuditEntityCategory      AuditEntity = "category"
+	AuditEntityMaintenance   AuditEntity = "maintenance"
+	AuditEntityBorrow        AuditEntity = "borrow"
+	AuditEntityToolReturning AuditEntity = "tool_returning"
+	AuditEntityUser          AuditEntity = "user"
+	AuditEntityNIMCollision  AuditEntity = "nim_collision"
+	AuditEntityAdmin         AuditEntity = "admin"
+	AuditEntityAdminRequest  AuditEntity = "admin_request"
+	AuditEntityInvitation    AuditEntity = "invitation"
+	AuditEntityLab           AuditEntity = "lab"
+
+	// AuditLimit is the number of audit entries shown at once.
+	AuditLimit = 20
+)
+
+var (
+	AuditCommandActor string = "oleh"
+
+	// AuditEntityCommands maps the entity names typed in "/audit [entitas] [id]".
+	AuditEntityCommands = map[string]AuditEntity{
+		"barang":       AuditEntityTool,
+		"kategori":     AuditEntityCategory,
+		"perawatan":    AuditEntityMaintenance,
+		"peminjaman":   AuditEntityBorrow,
+		"pengembalian": AuditEntityToolReturning,
+		"pengguna":     AuditEntityUser,
+		"nim":          AuditEntityNIMCollision,
+		"pengurus":     AuditEntityAdmin,
+		"permintaan":   AuditEntityAdminRequest,
+		"undangan":     AuditEntityInvitation,
+		"lab":          AuditEntityLab,
+	}
+)
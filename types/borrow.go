@@ -15,9 +15,20 @@ type (
 		CreatedAt   string         `json:"created_at"`
 		ConfirmedAt sql.NullTime   `json:"confirmed_at"`
 		ConfirmedBy sql.NullString `json:"confirmed_by"`
-		Reason      sql.NullString `json:"reason"`
-		Tool        Tool           `json:"tool"`
-		User        User           `json:"user"`
+		// ConfirmedByUser is the admin who responded to the borrow, with no ID
+		// when the admin is unknown or has been deleted.
+		ConfirmedByUser User           `json:"confirmed_by_user"`
+		Reason          sql.NullString `json:"reason"`
+		AdminNotes      sql.NullString `json:"admin_notes"`
+		Tool            Tool           `json:"tool"`
+		User            User           `json:"user"`
+	}
+
+	// Consumption is how much of a consumable was handed out in a period.
+	Consumption struct {
+		Tool     Tool `json:"tool"`
+		Amount   int  `json:"amount"`
+		Requests int  `json:"requests"`
 	}
 )
 
@@ -34,11 +45,23 @@ var (
 		"reject":   "REJECT",
 		"progress": "PROGRESS",
 		"returned": "RETURNED",
+
+		// an approved request of a consumable, which is never returned
+		"consumed": "CONSUMED",
 	}
 
 	BorrowMinimalDuration = 7
 )
 
+// ConfirmerName is the current name of the admin who responded to the borrow,
+// or the name stored at the time when the admin is unknown.
+func (b Borrow) ConfirmerName() string {
+	if len(b.ConfirmedByUser.Name) > 0 {
+		return b.ConfirmedByUser.Name
+	}
+	return b.ConfirmedBy.String
+}
+
 func GetBorrowStatus(s string) BorrowStatus {
 	return borrowStatusMap[s]
 }
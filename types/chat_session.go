@@ -30,15 +30,28 @@ var (
 
 	Topic map[string]TopicType = map[string]TopicType{
 		"register_init":     "RGR_init",
-		"register_confirm":  "RGR_confirm",
+		"register_name":     "RGR_name",
+		"register_nim":      "RGR_nim",
+		"register_batch":    "RGR_batch",
+		"register_address":  "RGR_address",
+		"register_phone":    "RGR_phone",
 		"register_complete": "RGR_complete",
 
+		"profile_init":     "PRF_init",
+		"profile_complete": "PRF_complete",
+
 		"borrow_init":    "BRW_init",
 		"borrow_amount":  "BRW_amount",
 		"borrow_date":    "BRW_date",
 		"borrow_reason":  "BRW_reason",
 		"borrow_confirm": "BRW_confirm",
 
+		// requesting a consumable, which has no duration and is never returned
+		"consume_init":    "CSM_init",
+		"consume_amount":  "CSM_amount",
+		"consume_reason":  "CSM_reason",
+		"consume_confirm": "CSM_confirm",
+
 		"tool_returning_init":     "RET_init",
 		"tool_returning_confirm":  "RET_confim",
 		"tool_returning_complete": "RET_complete",
@@ -69,5 +82,15 @@ var (
 		"manage_photo_init":    "MNG_photo_init",
 		"manage_photo_upload":  "MNG_photo_upload",
 		"manage_photo_confirm": "MNG_photo_confirm",
+
+		"maintenance_init":       "MNT_init",
+		"maintenance_type":       "MNT_type",
+		"maintenance_date":       "MNT_date",
+		"maintenance_technician": "MNT_technician",
+		"maintenance_notes":      "MNT_notes",
+		"maintenance_confirm":    "MNT_confirm",
+
+		// an earlier answer of the running flow is being changed
+		"edit_answer": "EDIT_answer",
 	}
 )
@@ -6,53 +6,104 @@ const (
 	CommandCheck    = "cek"
 	CommandBorrow   = "pinjam"
 	CommandReturn   = "pengembalian"
+	CommandHistory  = "riwayat"
+	CommandProfile  = "profil"
 	CommandHelp     = "bantuan"
+	CommandLanguage = "bahasa"
 
 	// admin stuffs
-	CommandAdmin   = "pengurus"
-	CommandRespond = "tanggapi"
-	CommandManage  = "kelola"
-	CommandReport  = "laporan"
+	CommandAdmin       = "pengurus"
+	CommandRespond     = "tanggapi"
+	CommandManage      = "kelola"
+	CommandReport      = "laporan"
+	CommandMaintenance = "perawatan"
+	CommandStatistics  = "statistik"
+	CommandRole        = "peran"
+	CommandUser        = "pengguna"
+	CommandAudit       = "audit"
+	CommandLab         = "lab"
 )
 
 type (
 	RespondType string
 	ManageType  string
 	ReportType  string
+	AdminType   string
+
+	// ExportFormat is the file format a report is exported to.
+	ExportFormat string
 
 	CheckCommandOrder struct {
 		ID   int64
 		Text string
 	}
 
+	// CheckFilterOrder browses the tools of a category or a tag. A category order
+	// without category ID shows the top level categories.
+	CheckFilterOrder struct {
+		Type   string
+		Filter ToolFilter
+		Cursor Cursor
+	}
+
 	RespondCommandOrder struct {
 		Type RespondType
 		ID   int64
 		Text string
 	}
 
+	// AdminCommandOrder is "/pengurus [jenis] [argumen]". Type is empty when
+	// the argument is an invitation code.
+	AdminCommandOrder struct {
+		Type AdminType
+		Text string
+	}
+
 	ManageCommandOrder struct {
 		Type ManageType
 		ID   int64
 	}
 
+	// ReportCommandOrder is "/laporan [jenis] [tahun]-[bulan] [format]". The
+	// report is sent as a message when Format is empty.
 	ReportCommandOrder struct {
-		Type ReportType
-		Text string
+		Type   ReportType
+		Text   string
+		Format ExportFormat
 	}
 )
 
 var (
-	CheckTypePhoto string = "foto"
+	CheckTypePhoto    string = "foto"
+	CheckTypeNext     string = "berikutnya"
+	CheckTypePrevious string = "sebelumnya"
+	CheckTypeCategory string = "kategori"
+	CheckTypeTag      string = "tag"
+
+	// StartPayloadBorrow prefixes the tool ID in the deep link that opens the bot
+	// to borrow a tool, e.g. "/start pinjam_5".
+	StartPayloadBorrow string = "pinjam_"
 
 	RespondTypeBorrow        RespondType = "pinjam"
 	RespondTypeToolReturning RespondType = "kembali"
+	RespondTypeNIMCollision  RespondType = "nim"
+	RespondTypeRegistration  RespondType = "registrasi"
+	RespondTypeAdminRequest  RespondType = "pengurus"
 
-	ManageTypeAdd    ManageType = "tambah"
-	ManageTypeEdit   ManageType = "edit"
-	ManageTypeDelete ManageType = "hapus"
-	ManageTypePhoto  ManageType = "foto"
+	AdminTypeInvite AdminType = "undang"
+	AdminTypeRevoke AdminType = "cabut"
+	AdminTypeList   AdminType = "daftar"
+
+	ManageTypeAdd      ManageType = "tambah"
+	ManageTypeEdit     ManageType = "edit"
+	ManageTypeDelete   ManageType = "hapus"
+	ManageTypePhoto    ManageType = "foto"
+	ManageTypeCategory ManageType = "kategori"
 
 	ReportTypeBorrow        ReportType = "pinjam"
 	ReportTypeToolReturning ReportType = "kembali"
+	ReportTypeConsumption   ReportType = "pemakaian"
+
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
 )
@@ -0,0 +1,24 @@
+package types
+
+type (
+	// Lab is a laboratory with its own tools and admins. The admins of a lab
+	// work in its admin group.
+	Lab struct {
+		ID           int64  `json:"id"`
+		Name         string `json:"name"`
+		AdminGroupID int64  `json:"admin_group_id"`
+		CreatedAt    string `json:"created_at"`
+	}
+
+	// LabCommandOrder is "/lab [id]" choosing a lab in a private chat, or
+	// "/lab [nama]" setting up the lab of an admin group.
+	LabCommandOrder struct {
+		ID   int64
+		Name string
+	}
+)
+
+// HasAdminGroup tells whether an admin group has been set up for the lab.
+func (l Lab) HasAdminGroup() bool {
+	return l.AdminGroupID != 0
+}
@@ -0,0 +1,13 @@
+package types
+
+type Language string
+
+const (
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+
+	// DefaultLanguage is used for users without a preference and for the admin group.
+	DefaultLanguage = LanguageIndonesian
+)
+
+var Languages = []Language{LanguageIndonesian, LanguageEnglish}
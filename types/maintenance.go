@@ -0,0 +1,75 @@
+package types
+
+import (
+	"database/sql"
+	"time"
+)
+
+type (
+	ToolStatus      string
+	MaintenanceType string
+
+	// Maintenance is a record of a calibration, repair or other maintenance done
+	// on a tool.
+	Maintenance struct {
+		ID          int64           `json:"id"`
+		ToolID      int64           `json:"tool_id"`
+		Type        MaintenanceType `json:"type"`
+		PerformedAt string          `json:"performed_at"`
+		Technician  string          `json:"technician"`
+		Notes       string          `json:"notes"`
+		CreatedAt   string          `json:"created_at"`
+	}
+
+	// CalibrationDue is a tool whose calibration is due. A tool that has never
+	// been calibrated has no LastCalibratedAt and is due right away.
+	CalibrationDue struct {
+		Tool             Tool
+		LastCalibratedAt sql.NullTime
+	}
+
+	MaintenanceCommandOrder struct {
+		ToolID int64
+		Type   string
+		Text   string
+	}
+)
+
+const (
+	ToolStatusActive       ToolStatus = "ACTIVE"
+	ToolStatusOutOfService ToolStatus = "OUT_OF_SERVICE"
+
+	MaintenanceTypeCalibration MaintenanceType = "kalibrasi"
+	MaintenanceTypeRepair      MaintenanceType = "perbaikan"
+	MaintenanceTypeRoutine     MaintenanceType = "perawatan"
+
+	// CalibrationReminderDays is how many days before a calibration is due that
+	// the admins are reminded of it.
+	CalibrationReminderDays = 7
+
+	// CalibrationReminderHour is the hour of the day the reminder is sent.
+	CalibrationReminderHour = 7
+
+	// MaintenanceHistoryLimit is the number of maintenance records shown for a tool.
+	MaintenanceHistoryLimit = 10
+)
+
+var (
+	MaintenanceCommandRecord   string = "catat"
+	MaintenanceCommandInterval string = "interval"
+	MaintenanceCommandBroken   string = "rusak"
+	MaintenanceCommandActive   string = "aktif"
+)
+
+// DueAt is the date the next calibration is due.
+func (c CalibrationDue) DueAt() time.Time {
+	if !c.LastCalibratedAt.Valid {
+		return time.Time{}
+	}
+	return c.LastCalibratedAt.Time.AddDate(0, 0, c.Tool.CalibrationIntervalDays)
+}
+
+// MaintenanceTypes are the kinds of maintenance that can be recorded.
+func MaintenanceTypes() []MaintenanceType {
+	return []MaintenanceType{MaintenanceTypeCalibration, MaintenanceTypeRepair, MaintenanceTypeRoutine}
+}
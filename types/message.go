@@ -1,11 +1,14 @@
 package types
 
 type (
+	// MessageRequest is a message to send. ReplyMarkup is an
+	// InlineKeyboardMarkup, a ReplyKeyboardMarkup or a ReplyKeyboardRemove,
+	// since Telegram takes one kind of keyboard per message.
 	MessageRequest struct {
-		ChatID      int64                `json:"chat_id"`
-		Text        string               `json:"text"`
-		ParseMode   string               `json:"parse_mode"`
-		ReplyMarkup InlineKeyboardMarkup `json:"reply_markup"`
+		ChatID      int64       `json:"chat_id"`
+		Text        string      `json:"text"`
+		ParseMode   string      `json:"parse_mode"`
+		ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 	}
 
 	PhotoRequest struct {
@@ -13,6 +16,24 @@ type (
 		Photo  string `json:"photo"`
 	}
 
+	// DocumentRequest uploads Content as a file named FileName, so it is sent as
+	// multipart form data instead of JSON.
+	DocumentRequest struct {
+		ChatID   int64
+		Caption  string
+		FileName string
+		Content  []byte
+	}
+
+	// PhotoUploadRequest uploads Content as a new photo, unlike PhotoRequest
+	// which sends a photo already on Telegram.
+	PhotoUploadRequest struct {
+		ChatID   int64
+		Caption  string
+		FileName string
+		Content  []byte
+	}
+
 	PhotoGroupRequest struct {
 		ChatID int64             `json:"chat_id"`
 		Media  []InputMediaPhoto `json:"media"`
@@ -23,12 +44,56 @@ type (
 		Media string `json:"media"`
 	}
 
+	// InlineKeyboardMarkup holds the buttons below a message.
 	InlineKeyboardMarkup struct {
 		InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
 	}
 
+	// ReplyKeyboardMarkup is shown in place of the keyboard of the user.
+	ReplyKeyboardMarkup struct {
+		Keyboard        [][]KeyboardButton `json:"keyboard"`
+		ResizeKeyboard  bool               `json:"resize_keyboard,omitempty"`
+		OneTimeKeyboard bool               `json:"one_time_keyboard,omitempty"`
+	}
+
+	// ReplyKeyboardRemove gives the user the own keyboard back after a
+	// ReplyKeyboardMarkup.
+	ReplyKeyboardRemove struct {
+		RemoveKeyboard bool `json:"remove_keyboard"`
+	}
+
+	KeyboardButton struct {
+		Text           string `json:"text"`
+		RequestContact bool   `json:"request_contact,omitempty"`
+	}
+
 	InlineKeyboardButton struct {
 		Text         string `json:"text"`
-		CallbackData string `json:"callback_data"`
+		CallbackData string `json:"callback_data,omitempty"`
+		URL          string `json:"url,omitempty"`
+	}
+
+	AnswerInlineQueryRequest struct {
+		InlineQueryID string                     `json:"inline_query_id"`
+		Results       []InlineQueryResultArticle `json:"results"`
+		CacheTime     int                        `json:"cache_time"`
+		IsPersonal    bool                       `json:"is_personal"`
+		NextOffset    string                     `json:"next_offset"`
+		SwitchPMText  string                     `json:"switch_pm_text,omitempty"`
+		SwitchPMParam string                     `json:"switch_pm_parameter,omitempty"`
+	}
+
+	InlineQueryResultArticle struct {
+		Type                string                  `json:"type"`
+		ID                  string                  `json:"id"`
+		Title               string                  `json:"title"`
+		Description         string                  `json:"description"`
+		InputMessageContent InputTextMessageContent `json:"input_message_content"`
+		ReplyMarkup         *InlineKeyboardMarkup   `json:"reply_markup,omitempty"`
+	}
+
+	InputTextMessageContent struct {
+		MessageText string `json:"message_text"`
+		ParseMode   string `json:"parse_mode"`
 	}
 )
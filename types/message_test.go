@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRequestReplyMarkupJSON(t *testing.T) {
+	t.Run("inline keyboard", func(t *testing.T) {
+		r, err := json.Marshal(MessageRequest{ReplyMarkup: InlineKeyboardMarkup{
+			InlineKeyboard: [][]InlineKeyboardButton{{{Text: "Ya", CallbackData: "yes"}}},
+		}})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"chat_id":0,"text":"","parse_mode":"","reply_markup":{"inline_keyboard":[[{"text":"Ya","callback_data":"yes"}]]}}`, string(r))
+	})
+
+	t.Run("reply keyboard", func(t *testing.T) {
+		r, err := json.Marshal(MessageRequest{ReplyMarkup: ReplyKeyboardMarkup{
+			Keyboard:        [][]KeyboardButton{{{Text: "Bagikan", RequestContact: true}}},
+			ResizeKeyboard:  true,
+			OneTimeKeyboard: true,
+		}})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"chat_id":0,"text":"","parse_mode":"","reply_markup":{"keyboard":[[{"text":"Bagikan","request_contact":true}]],"resize_keyboard":true,"one_time_keyboard":true}}`, string(r))
+	})
+
+	t.Run("remove keyboard", func(t *testing.T) {
+		r, err := json.Marshal(MessageRequest{ReplyMarkup: ReplyKeyboardRemove{RemoveKeyboard: true}})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"chat_id":0,"text":"","parse_mode":"","reply_markup":{"remove_keyboard":true}}`, string(r))
+	})
+
+	t.Run("without keyboard", func(t *testing.T) {
+		r, err := json.Marshal(MessageRequest{})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"chat_id":0,"text":"","parse_mode":""}`, string(r))
+	})
+}
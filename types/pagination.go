@@ -0,0 +1,28 @@
+package types
+
+// Cursor selects a page of rows ordered by ID. When Before is set the page
+// holds the rows right before it, otherwise the rows right after After.
+type Cursor struct {
+	After  int64
+	Before int64
+	Limit  int
+}
+
+const (
+	// ToolPageSize is the number of tools on one page of /cek.
+	ToolPageSize = 20
+
+	// InlineQueryPageSize is the number of tools answered to one inline query.
+	// Telegram accepts at most 50 results.
+	InlineQueryPageSize = 20
+
+	// InlineQueryCacheTime is how long in seconds Telegram may cache an answer
+	// to an inline query. Stock changes often, so it is kept short.
+	InlineQueryCacheTime = 30
+
+	// HistoryPageSize is the number of borrows on one page of /riwayat.
+	HistoryPageSize = 10
+
+	// MessageMaxLength is the maximum length of a Telegram message text.
+	MessageMaxLength = 4096
+)
@@ -5,4 +5,5 @@ type QuestionRegistration struct {
 	NIM     string
 	Batch   int
 	Address string
+	Phone   string
 }
@@ -0,0 +1,31 @@
+package types
+
+import "time"
+
+type (
+	ReportPeriodType string
+
+	// ReportPeriod is the [From, To) window a report covers. To is exclusive so
+	// consecutive periods never overlap.
+	ReportPeriod struct {
+		Type ReportPeriodType
+		From time.Time
+		To   time.Time
+	}
+)
+
+var (
+	ReportPeriodMonth    ReportPeriodType = "month"
+	ReportPeriodSemester ReportPeriodType = "semester"
+	ReportPeriodYear     ReportPeriodType = "year"
+	ReportPeriodRange    ReportPeriodType = "range"
+)
+
+// ReportRangeSeparator separates the first and the last day of a date range,
+// e.g. "2021-01-01..2021-06-30".
+const ReportRangeSeparator = ".."
+
+// LastDay is the last day included in the period.
+func (rp ReportPeriod) LastDay() time.Time {
+	return rp.To.AddDate(0, 0, -1)
+}
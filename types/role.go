@@ -0,0 +1,91 @@
+package types
+
+type (
+	// Role tells what an admin may do, see Role.Can.
+	Role string
+
+	Permission string
+
+	// RoleCommandOrder is "/peran [@username|id] [peran]".
+	RoleCommandOrder struct {
+		Target string
+		Role   Role
+	}
+)
+
+const (
+	RoleHead      Role = "kepala"
+	RoleAssistant Role = "asisten"
+	RoleLecturer  Role = "dosen"
+	RoleViewer    Role = "pengamat"
+)
+
+const (
+	// PermissionBorrowApprove responds to borrowing and returning requests.
+	PermissionBorrowApprove Permission = "borrow.approve"
+	// PermissionUserApprove responds to registrations and duplicate NIMs.
+	PermissionUserApprove Permission = "user.approve"
+	// PermissionUserManage suspends and deletes students.
+	PermissionUserManage Permission = "user.manage"
+	// PermissionToolView sees the tools which are not available to students.
+	PermissionToolView Permission = "tool.view"
+	// PermissionToolManage edits the tools and records their maintenance.
+	PermissionToolManage Permission = "tool.manage"
+	// PermissionReportView reads the reports and statistics.
+	PermissionReportView Permission = "report.view"
+	// PermissionAdminManage invites and revokes admins and changes their roles.
+	PermissionAdminManage Permission = "admin.manage"
+	// PermissionAuditView reads the audit trail of the admins.
+	PermissionAuditView Permission = "audit.view"
+)
+
+// Roles lists the roles from the most to the least permissions.
+var Roles = []Role{RoleHead, RoleAssistant, RoleLecturer, RoleViewer}
+
+var rolePermissions = map[Role][]Permission{
+	RoleHead: {
+		PermissionBorrowApprove,
+		PermissionUserApprove,
+		PermissionUserManage,
+		PermissionToolView,
+		PermissionToolManage,
+		PermissionReportView,
+		PermissionAdminManage,
+		PermissionAuditView,
+	},
+	RoleAssistant: {
+		PermissionBorrowApprove,
+		PermissionUserApprove,
+		PermissionUserManage,
+		PermissionToolView,
+		PermissionToolManage,
+		PermissionReportView,
+	},
+	RoleLecturer: {
+		PermissionBorrowApprove,
+		PermissionToolView,
+		PermissionReportView,
+	},
+	RoleViewer: {
+		PermissionToolView,
+		PermissionReportView,
+	},
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
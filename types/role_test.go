@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleCan(t *testing.T) {
+	t.Run("head", func(t *testing.T) {
+		assert.True(t, RoleHead.Can(PermissionAdminManage))
+		assert.True(t, RoleHead.Can(PermissionToolManage))
+		assert.True(t, RoleHead.Can(PermissionAuditView))
+		assert.False(t, RoleAssistant.Can(PermissionAuditView))
+	})
+
+	t.Run("lecturer", func(t *testing.T) {
+		assert.True(t, RoleLecturer.Can(PermissionBorrowApprove))
+		assert.False(t, RoleLecturer.Can(PermissionToolManage))
+	})
+
+	t.Run("viewer", func(t *testing.T) {
+		assert.True(t, RoleViewer.Can(PermissionReportView))
+		assert.False(t, RoleViewer.Can(PermissionBorrowApprove))
+	})
+
+	t.Run("without role", func(t *testing.T) {
+		assert.False(t, Role("").Can(PermissionToolView))
+	})
+}
+
+func TestRoleIsValid(t *testing.T) {
+	for _, role := range Roles {
+		assert.True(t, role.IsValid())
+		assert.NotEmpty(t, role.Permissions())
+	}
+	assert.False(t, Role("mahasiswa").IsValid())
+}
@@ -0,0 +1,76 @@
+package types
+
+import "time"
+
+type (
+	// ToolUtilization compares how long the units of an asset were lent out in a
+	// period with how long they could have been. A unit lent out for the whole
+	// period adds as many borrowed days as the period has days.
+	ToolUtilization struct {
+		Tool          Tool    `json:"tool"`
+		BorrowedDays  float64 `json:"borrowed_days"`
+		AvailableDays float64 `json:"available_days"`
+	}
+
+	ToolBorrowCount struct {
+		Tool    Tool `json:"tool"`
+		Borrows int  `json:"borrows"`
+		Amount  int  `json:"amount"`
+	}
+
+	// UsageSummary sums up the borrows confirmed and the returns completed in a
+	// period.
+	UsageSummary struct {
+		Borrows         int           `json:"borrows"`
+		AverageDuration float64       `json:"average_duration"`
+		AverageResponse time.Duration `json:"average_response"`
+		Returns         int           `json:"returns"`
+		LateReturns     int           `json:"late_returns"`
+	}
+
+	BatchUsage struct {
+		Batch   uint16 `json:"batch"`
+		Users   int    `json:"users"`
+		Borrows int    `json:"borrows"`
+		Amount  int    `json:"amount"`
+	}
+
+	// MonthlyUsage counts the borrows confirmed in a month and how many of them
+	// were returned late or are overdue.
+	MonthlyUsage struct {
+		Month   time.Time `json:"month"`
+		Borrows int       `json:"borrows"`
+		Overdue int       `json:"overdue"`
+	}
+
+	Statistics struct {
+		Period       ReportPeriod      `json:"period"`
+		Summary      UsageSummary      `json:"summary"`
+		Utilizations []ToolUtilization `json:"utilizations"`
+		TopTools     []ToolBorrowCount `json:"top_tools"`
+		Batches      []BatchUsage      `json:"batches"`
+		Months       []MonthlyUsage    `json:"months"`
+	}
+)
+
+const (
+	StatisticsUtilizationLimit = 10
+	StatisticsTopToolsLimit    = 5
+)
+
+// Rate is the share of the available days the tool was lent out, from 0 to 1.
+func (tu ToolUtilization) Rate() float64 {
+	if tu.AvailableDays <= 0 {
+		return 0
+	}
+	return tu.BorrowedDays / tu.AvailableDays
+}
+
+// LateRate is the share of the returns made after the borrow duration ended,
+// from 0 to 1.
+func (us UsageSummary) LateRate() float64 {
+	if us.Returns == 0 {
+		return 0
+	}
+	return float64(us.LateReturns) / float64(us.Returns)
+}
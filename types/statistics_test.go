@@ -0,0 +1,17 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolUtilizationRate(t *testing.T) {
+	assert.Equal(t, 0.25, ToolUtilization{BorrowedDays: 15, AvailableDays: 60}.Rate())
+	assert.Zero(t, ToolUtilization{BorrowedDays: 15}.Rate())
+}
+
+func TestUsageSummaryLateRate(t *testing.T) {
+	assert.Equal(t, 0.5, UsageSummary{Returns: 4, LateReturns: 2}.LateRate())
+	assert.Zero(t, UsageSummary{}.LateRate())
+}
@@ -5,17 +5,61 @@ import "database/sql"
 type (
 	ToolField string
 
+	// ToolKind tells whether a tool is returned after being borrowed or used up.
+	ToolKind string
+
 	Tool struct {
-		ID                    int64        `json:"id"`
-		Name                  string       `json:"name"`
-		Brand                 string       `json:"brand"`
-		ProductType           string       `json:"product_type"`
-		Weight                float32      `json:"weight"`
-		Stock                 int64        `json:"stock"`
-		AdditionalInformation string       `json:"additional_info"`
-		CreatedAt             string       `json:"created_at"`
-		UpdatedAt             string       `json:"updated_at"`
-		DeletedAt             sql.NullTime `json:"deleted_at"`
+		ID                      int64        `json:"id"`
+		LabID                   int64        `json:"lab_id"`
+		Name                    string       `json:"name"`
+		Brand                   string       `json:"brand"`
+		ProductType             string       `json:"product_type"`
+		Weight                  float32      `json:"weight"`
+		Stock                   int64        `json:"stock"`
+		Kind                    ToolKind     `json:"kind"`
+		MinStock                int64        `json:"min_stock"`
+		AdditionalInformation   string       `json:"additional_info"`
+		CategoryID              int64        `json:"category_id"`
+		Tags                    []string     `json:"tags"`
+		Location                ToolLocation `json:"location"`
+		Status                  ToolStatus   `json:"status"`
+		CalibrationIntervalDays int          `json:"calibration_interval_days"`
+		CreatedAt               string       `json:"created_at"`
+		UpdatedAt               string       `json:"updated_at"`
+		DeletedAt               sql.NullTime `json:"deleted_at"`
+	}
+
+	// ToolLocation is where a tool is stored in the lab.
+	ToolLocation struct {
+		Room    string `json:"room"`
+		Cabinet string `json:"cabinet"`
+		Shelf   string `json:"shelf"`
+	}
+
+	// ToolCategory groups tools. A category without parent is a top level
+	// category.
+	ToolCategory struct {
+		ID        int64  `json:"id"`
+		ParentID  int64  `json:"parent_id"`
+		Name      string `json:"name"`
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+	}
+
+	// ToolFilter narrows a list of tools. Zero values do not filter. A category
+	// also matches the tools of its subcategories.
+	ToolFilter struct {
+		LabID      int64
+		CategoryID int64
+		Tag        string
+		Status     ToolStatus
+	}
+
+	// ToolPage is a page of tools ordered by ID.
+	ToolPage struct {
+		Tools       []Tool
+		HasPrevious bool
+		HasNext     bool
 	}
 )
 
@@ -27,4 +71,53 @@ const (
 	ToolFieldStock          ToolField = "stok"
 	ToolFieldAdditionalInfo ToolField = "keterangan"
 	ToolFieldPhoto          ToolField = "foto"
+	ToolFieldCategory       ToolField = "kategori"
+	ToolFieldTags           ToolField = "tag"
+	ToolFieldRoom           ToolField = "ruang"
+	ToolFieldCabinet        ToolField = "lemari"
+	ToolFieldShelf          ToolField = "rak"
+	ToolFieldKind           ToolField = "jenis"
+	ToolFieldMinStock       ToolField = "stok_minimum"
+)
+
+const (
+	// ToolKindAsset is borrowed and returned through the tool returning flow.
+	ToolKindAsset ToolKind = "ASSET"
+
+	// ToolKindConsumable, e.g. solder or resistors, is handed out and never
+	// returned.
+	ToolKindConsumable ToolKind = "CONSUMABLE"
+)
+
+const (
+	// ToolFieldEmptyValue clears an optional tool field in the manage edit flow.
+	ToolFieldEmptyValue = "-"
+
+	// ToolCategorySeparator separates the category names of a category path,
+	// e.g. "Elektronika/Alat Ukur".
+	ToolCategorySeparator = "/"
+
+	// ToolTagMaxLength is the longest tag in bytes. The page buttons of
+	// "/cek tag [tag] sebelumnya [id]" carry the tag in their callback data,
+	// which Telegram limits to 64 bytes.
+	ToolTagMaxLength = 24
 )
+
+const (
+	// ToolSearchLimit is the number of tools shown for a keyword search.
+	ToolSearchLimit = 10
+
+	// ToolSearchMinSimilarity is the minimum pg_trgm word similarity between the
+	// keyword and a tool for the tool to be a search result.
+	ToolSearchMinSimilarity = 0.3
+)
+
+// IsConsumable tells whether the tool is used up instead of returned.
+func (t Tool) IsConsumable() bool {
+	return t.Kind == ToolKindConsumable
+}
+
+// IsLowStock tells whether a consumable has run down to its minimum stock.
+func (t Tool) IsLowStock() bool {
+	return t.IsConsumable() && t.Stock <= t.MinStock
+}
@@ -5,14 +5,17 @@ import "database/sql"
 type (
 	ToolReturningStatus string
 	ToolReturning       struct {
-		ID             int64               `json:"id"`
-		CreatedAt      string              `json:"created_at"`
-		ConfirmedAt    sql.NullTime        `json:"confirmed_at"`
-		ConfirmedBy    sql.NullString      `json:"confirmed_by"`
-		BorrowID       int64               `json:"borrow_id"`
-		Status         ToolReturningStatus `json:"status"`
-		AdditionalInfo string              `json:"additional_info"`
-		Borrow         Borrow              `json:"borrow"`
+		ID          int64          `json:"id"`
+		CreatedAt   string         `json:"created_at"`
+		ConfirmedAt sql.NullTime   `json:"confirmed_at"`
+		ConfirmedBy sql.NullString `json:"confirmed_by"`
+		// ConfirmedByUser is the admin who responded to the returning, see
+		// Borrow.ConfirmedByUser.
+		ConfirmedByUser User                `json:"confirmed_by_user"`
+		BorrowID        int64               `json:"borrow_id"`
+		Status          ToolReturningStatus `json:"status"`
+		AdditionalInfo  string              `json:"additional_info"`
+		Borrow          Borrow              `json:"borrow"`
 	}
 )
 
@@ -26,6 +29,15 @@ var (
 	}
 )
 
+// ConfirmerName is the current name of the admin who responded to the
+// returning, see Borrow.ConfirmerName.
+func (tr ToolReturning) ConfirmerName() string {
+	if len(tr.ConfirmedByUser.Name) > 0 {
+		return tr.ConfirmedByUser.Name
+	}
+	return tr.ConfirmedBy.String
+}
+
 func GetToolReturningStatus(s string) ToolReturningStatus {
 	return toolReturningStatusMap[s]
 }
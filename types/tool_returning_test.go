@@ -1,6 +1,7 @@
 package types
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +21,22 @@ func TestGetToolReturningStatus(t *testing.T) {
 		assert.Equal(t, ToolReturningStatus(""), r)
 	})
 }
+
+func TestToolReturningConfirmerName(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		tr := ToolReturning{
+			ConfirmedBy:     sql.NullString{Valid: true, String: "Budi"},
+			ConfirmedByUser: User{ID: 9, Name: "Budi Santoso"},
+		}
+
+		assert.Equal(t, "Budi Santoso", tr.ConfirmerName())
+	})
+
+	t.Run("unknown admin", func(t *testing.T) {
+		tr := ToolReturning{
+			ConfirmedBy: sql.NullString{Valid: true, String: "Budi"},
+		}
+
+		assert.Equal(t, "Budi", tr.ConfirmerName())
+	})
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolIsLowStock(t *testing.T) {
+	t.Run("consumable at minimum", func(t *testing.T) {
+		tool := Tool{Kind: ToolKindConsumable, Stock: 5, MinStock: 5}
+
+		assert.True(t, tool.IsLowStock())
+	})
+
+	t.Run("consumable above minimum", func(t *testing.T) {
+		tool := Tool{Kind: ToolKindConsumable, Stock: 6, MinStock: 5}
+
+		assert.False(t, tool.IsLowStock())
+	})
+
+	t.Run("asset", func(t *testing.T) {
+		tool := Tool{Kind: ToolKindAsset, Stock: 0, MinStock: 5}
+
+		assert.False(t, tool.IsLowStock())
+	})
+}
@@ -1,16 +1,87 @@
 package types
 
+import "database/sql"
+
 type (
 	UserType string
 
+	// UserStatus tells whether an admin has approved the registration of a
+	// student.
+	UserStatus string
+
+	// NIM is what a NIM tells about the student, see config.NIMPatterns. The
+	// fields are empty when the pattern has no such group.
+	NIM struct {
+		Faculty string
+		Program string
+		Year    int
+	}
+
+	NIMCollisionStatus string
+
+	// NIMCollision is a registration with a NIM already used by another account,
+	// waiting for an admin to decide which account the NIM belongs to.
+	NIMCollision struct {
+		ID             int64              `json:"id"`
+		UserID         int64              `json:"user_id"`
+		ExistingUserID int64              `json:"existing_user_id"`
+		Name           string             `json:"name"`
+		NIM            string             `json:"nim"`
+		Batch          uint16             `json:"batch"`
+		Address        string             `json:"address"`
+		Phone          string             `json:"phone"`
+		Language       Language           `json:"language"`
+		Status         NIMCollisionStatus `json:"status"`
+		CreatedAt      string             `json:"created_at"`
+		ResolvedAt     sql.NullTime       `json:"resolved_at"`
+		ResolvedBy     sql.NullString     `json:"resolved_by"`
+		// ResolvedByUserID is the admin who approved or rejected the collision,
+		// zero while it is pending or once the admin has been deleted.
+		ResolvedByUserID int64 `json:"resolved_by_user_id"`
+		ExistingUser     User  `json:"existing_user"`
+	}
+
+	// UserField is a profile field a student can change with the profile command.
+	UserField string
+
 	User struct {
-		ID        int64    `json:"id"`
-		Name      string   `json:"name"`
-		NIM       string   `json:"nim"`
-		Batch     uint16   `json:"batch"`
-		Address   string   `json:"address"`
-		CreatedAt string   `json:"created_at"`
-		UserType  UserType `json:"user_type"`
+		ID        int64      `json:"id"`
+		Name      string     `json:"name"`
+		NIM       string     `json:"nim"`
+		Batch     uint16     `json:"batch"`
+		Address   string     `json:"address"`
+		Phone     string     `json:"phone"`
+		Username  string     `json:"username"`
+		CreatedAt string     `json:"created_at"`
+		UserType  UserType   `json:"user_type"`
+		Language  Language   `json:"language"`
+		Status    UserStatus `json:"status"`
+		Role      Role       `json:"role"`
+
+		// AdminLabID is the lab an admin looks after, LabID the lab a student
+		// borrows from. Zero when not chosen.
+		AdminLabID int64 `json:"admin_lab_id"`
+		LabID      int64 `json:"lab_id"`
+
+		// SuspendedAt is set while the student may not borrow.
+		SuspendedAt      sql.NullTime `json:"suspended_at"`
+		SuspensionReason string       `json:"suspension_reason"`
+	}
+
+	// UserDetail is what an admin sees of a student: the account with its
+	// borrows and how many of them were returned late.
+	UserDetail struct {
+		User    User     `json:"user"`
+		Borrows []Borrow `json:"borrows"`
+		Late    int      `json:"late"`
+	}
+
+	// UserCommandOrder is "/pengguna cari [kata kunci]" or
+	// "/pengguna [id] [tangguhkan [alasan]|pulihkan|hapus [ya]]".
+	UserCommandOrder struct {
+		ID   int64
+		Type string
+		Text string
 	}
 )
 
@@ -19,3 +90,50 @@ const (
 	UserTypeAdmin   UserType = "admin"
 	UserTypeBoth    UserType = "both"
 )
+
+const (
+	UserStatusPending  UserStatus = "PENDING"
+	UserStatusApproved UserStatus = "APPROVED"
+	UserStatusRejected UserStatus = "REJECTED"
+)
+
+const (
+	// UserSearchLimit is the number of users shown for a search.
+	UserSearchLimit = 20
+
+	// UserHistoryLimit is the number of latest borrows shown in the detail of a
+	// user.
+	UserHistoryLimit = 5
+)
+
+var (
+	UserCommandSearch    string = "cari"
+	UserCommandSuspend   string = "tangguhkan"
+	UserCommandUnsuspend string = "pulihkan"
+	UserCommandDelete    string = "hapus"
+)
+
+const (
+	UserFieldName    UserField = "nama"
+	UserFieldNIM     UserField = "nim"
+	UserFieldBatch   UserField = "angkatan"
+	UserFieldAddress UserField = "alamat"
+)
+
+const (
+	NIMCollisionStatusPending  NIMCollisionStatus = "PENDING"
+	NIMCollisionStatusApproved NIMCollisionStatus = "APPROVED"
+	NIMCollisionStatusRejected NIMCollisionStatus = "REJECTED"
+)
+
+// IsApproved tells whether the registration of the student has been
+// approved. An account without a NIM, as while its NIM is under review, is
+// not.
+func (u User) IsApproved() bool {
+	return u.Status == UserStatusApproved && len(u.NIM) > 0
+}
+
+// IsSuspended tells whether the student may not borrow for now.
+func (u User) IsSuspended() bool {
+	return u.SuspendedAt.Valid
+}
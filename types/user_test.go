@@ -0,0 +1,13 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIsApproved(t *testing.T) {
+	assert.True(t, User{NIM: "21120118130055", Status: UserStatusApproved}.IsApproved())
+	assert.False(t, User{NIM: "21120118130055", Status: UserStatusPending}.IsApproved())
+	assert.False(t, User{Status: UserStatusApproved}.IsApproved())
+}
@@ -4,10 +4,11 @@ type (
 	RequestType string
 
 	TeleMessageFrom struct {
-		ID        int64  `json:"id"`
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Username  string `json:"username"`
+		ID           int64  `json:"id"`
+		FirstName    string `json:"first_name"`
+		LastName     string `json:"last_name"`
+		Username     string `json:"username"`
+		LanguageCode string `json:"language_code"`
 	}
 
 	teleMessageChat struct {
@@ -23,6 +24,14 @@ type (
 		Height       int    `json:"height"`
 	}
 
+	// TeleContact is shared through a keyboard button requesting the contact.
+	// UserID is only filled when the contact belongs to a Telegram user.
+	TeleContact struct {
+		PhoneNumber string `json:"phone_number"`
+		FirstName   string `json:"first_name"`
+		UserID      int64  `json:"user_id"`
+	}
+
 	TeleMessage struct {
 		MessageID    int64           `json:"message_id"`
 		From         TeleMessageFrom `json:"from"`
@@ -30,6 +39,7 @@ type (
 		Chat         teleMessageChat `json:"chat"`
 		MediaGroupID string          `json:"media_group_id"`
 		Photo        []TelePhotoSize `json:"photo"`
+		Contact      *TeleContact    `json:"contact"`
 	}
 
 	WebhookRequest struct {
@@ -46,6 +56,17 @@ type (
 	InlineCallbackQuery struct {
 		CallbackQuery teleCallbackQuery `json:"callback_query"`
 	}
+
+	InlineQuery struct {
+		ID     string          `json:"id"`
+		From   TeleMessageFrom `json:"from"`
+		Query  string          `json:"query"`
+		Offset string          `json:"offset"`
+	}
+
+	InlineQueryRequest struct {
+		InlineQuery InlineQuery `json:"inline_query"`
+	}
 )
 
 var (